@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"url-sentinel/internal/agent"
+	"url-sentinel/internal/config"
+	"url-sentinel/internal/monitor"
+	"url-sentinel/internal/tracing"
+)
+
+const (
+	envLocal = "local"
+	envDev   = "dev"
+	envProd  = "prod"
+)
+
+func main() {
+	// Load configuration
+	cfg := config.MustLoadAgent()
+
+	// Setup logger
+	logger := setupLogger(cfg.Env).With(slog.String("location", cfg.Location))
+	logger.Info("starting url-sentinel agent",
+		slog.String("env", cfg.Env),
+		slog.String("server_url", cfg.ServerURL),
+	)
+
+	// Initialize tracing
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing)
+	if err != nil {
+		logger.Error("failed to setup tracing", slog.Any("error", err))
+		os.Exit(1)
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			logger.Error("failed to shutdown tracing", slog.Any("error", err))
+		}
+	}()
+
+	// Initialize agent
+	client := agent.NewClient(cfg.ServerURL, cfg.Token)
+	checker := monitor.NewChecker(cfg.Location, cfg.CheckTimeout)
+	a := agent.New(client, checker, cfg.SyncInterval, logger)
+
+	// Run until interrupt signal
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	a.Run(ctx)
+
+	logger.Info("agent stopped")
+}
+
+func setupLogger(env string) *slog.Logger {
+	var handler slog.Handler
+
+	switch env {
+	case envLocal, envDev:
+		handler = slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+			Level: slog.LevelDebug,
+		})
+	case envProd:
+		handler = slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+			Level: slog.LevelInfo,
+		})
+	default:
+		handler = slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+			Level: slog.LevelInfo,
+		})
+	}
+
+	return slog.New(handler)
+}
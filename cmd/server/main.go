@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log/slog"
 	"net/http"
@@ -12,15 +13,28 @@ import (
 	"url-sentinel/internal/config"
 	"url-sentinel/internal/delivery/http/handler"
 	mw "url-sentinel/internal/delivery/http/middleware"
+	"url-sentinel/internal/domain/entity"
+	"url-sentinel/internal/domain/repository"
+	"url-sentinel/internal/events"
+	"url-sentinel/internal/metrics"
 	"url-sentinel/internal/monitor"
-	"url-sentinel/internal/repository/postgres"
+	"url-sentinel/internal/reconcile"
+	"url-sentinel/internal/repository/storage"
+	"url-sentinel/internal/retention"
+	"url-sentinel/internal/stream"
+	"url-sentinel/internal/tracing"
 	"url-sentinel/internal/usecase"
 
 	"github.com/go-chi/chi"
 	"github.com/go-chi/chi/middleware"
+	"github.com/google/uuid"
 	_ "github.com/lib/pq"
 )
 
+// storageWorkers is how many check results are stored concurrently, the
+// results of a URL are stored one at a time in the order they were published
+const storageWorkers = 8
+
 const (
 	envLocal = "local"
 	envDev   = "dev"
@@ -28,6 +42,10 @@ const (
 )
 
 func main() {
+	bootstrapAdminKey := flag.String("bootstrap-admin-key", "", "create an admin API key with the given name, print it and exit")
+	plan := flag.Bool("plan", false, "print the changes the monitors file would make and exit")
+	flag.Parse()
+
 	// Load configuration
 	cfg := config.MustLoad()
 
@@ -38,42 +56,181 @@ func main() {
 		slog.String("address", cfg.HTTPServer.Address),
 	)
 
-	// Initialize database
-	db, err := postgres.New(cfg.Database.DSN())
+	// Initialize tracing
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing)
+	if err != nil {
+		logger.Error("failed to setup tracing", slog.Any("error", err))
+		os.Exit(1)
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			logger.Error("failed to shutdown tracing", slog.Any("error", err))
+		}
+	}()
+
+	// Initialize storage
+	store, err := storage.Open(*cfg)
 	if err != nil {
-		logger.Error("failed to connect to database", slog.Any("error", err))
+		logger.Error("failed to open storage", slog.String("driver", cfg.Storage.Driver), slog.Any("error", err))
 		os.Exit(1)
 	}
 	defer func() {
-		if err := db.Close(); err != nil {
-			logger.Error("failed to close database", slog.Any("error", err))
+		if err := store.Close(); err != nil {
+			logger.Error("failed to close storage", slog.Any("error", err))
 		}
 	}()
-	logger.Info("database connected successfully")
+	logger.Info("storage opened successfully", slog.String("driver", cfg.Storage.Driver))
+
+	// Run the migrate command and exit if requested
+	if flag.Arg(0) == "migrate" {
+		if err := runMigrate(context.Background(), store.Migrator, flag.Args()[1:], os.Stdout); err != nil {
+			logger.Error("failed to migrate", slog.Any("error", err))
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Apply or verify schema migrations
+	if err := prepareSchema(context.Background(), store.Migrator, cfg.Storage.AutoMigrate, logger); err != nil {
+		logger.Error("failed to prepare database schema", slog.Any("error", err))
+		os.Exit(1)
+	}
 
 	// Initialize repositories
-	urlRepo := postgres.NewURLRepository(db.DB)
-	checkRepo := postgres.NewCheckRepository(db.DB)
+	urlRepo := store.URLs
+	checkRepo := store.Checks
+	incidentRepo := store.Incidents
+	statusPageRepo := store.StatusPages
+	apiKeyRepo := store.APIKeys
+	projectRepo := store.Projects
+
+	authUseCase := usecase.NewAuthUseCase(apiKeyRepo, projectRepo)
+
+	// Create the first admin key in the default project and exit if requested
+	if *bootstrapAdminKey != "" {
+		key, plaintext, err := authUseCase.CreateAPIKey(context.Background(), entity.DefaultProjectID, *bootstrapAdminKey, []entity.Scope{entity.ScopeAdmin}, nil)
+		if err != nil {
+			logger.Error("failed to create admin api key", slog.Any("error", err))
+			os.Exit(1)
+		}
+		logger.Info("admin api key created", slog.String("id", key.ID.String()), slog.String("prefix", key.Prefix))
+		fmt.Println(plaintext)
+		return
+	}
+
+	// Monitors declared in the monitors file belong to the configured project
+	monitorsProjectID := entity.DefaultProjectID
+	if cfg.Monitors.ProjectID != "" {
+		if monitorsProjectID, err = uuid.Parse(cfg.Monitors.ProjectID); err != nil {
+			logger.Error("invalid monitors project id", slog.Any("error", err))
+			os.Exit(1)
+		}
+	}
+
+	// Print the changes of the monitors file and exit if requested
+	if *plan {
+		if cfg.Monitors.File == "" {
+			logger.Error("no monitors file configured")
+			os.Exit(1)
+		}
+		planner := reconcile.NewReconciler(cfg.Monitors.File, monitorsProjectID, cfg.Monitors.Prune, usecase.NewURLUseCase(urlRepo, projectRepo, nil, nil, nil), logger)
+		changes, err := planner.Plan(context.Background())
+		if err != nil {
+			logger.Error("failed to plan monitors file", slog.Any("error", err))
+			os.Exit(1)
+		}
+		if err := reconcile.WritePlan(os.Stdout, changes); err != nil {
+			logger.Error("failed to print plan", slog.Any("error", err))
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Initialize the event bus, closed last so queued checks are still stored
+	bus := events.NewBus(logger)
+	defer bus.Close()
+
+	// Initialize check use cases evaluating URL state centrally
+	incidentUseCase := usecase.NewIncidentUseCase(urlRepo, checkRepo, incidentRepo, bus, logger)
+	checkUseCase := usecase.NewCheckUseCase(checkRepo, urlRepo, incidentUseCase, bus)
+	contentUseCase := usecase.NewContentUseCase(store.Contents, urlRepo, bus, logger)
 
 	// Initialize and start monitor
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	mon := monitor.NewMonitor(urlRepo, checkRepo, logger)
+	// Initialize metrics
+	m := metrics.New(store.DB)
+	m.RegisterBus(bus)
+
+	// Subscribe storage, metrics and live streams to check results. Live
+	// streams may miss events rather than slow the monitor down.
+	hub := stream.NewHub()
+	bus.Subscribe("storage", events.SubscribeOptions{Buffer: 1024, Workers: storageWorkers, OrderBy: events.ByURL},
+		events.On(checkUseCase.HandleCheckCompleted),
+	)
+	bus.Subscribe("metrics", events.SubscribeOptions{Buffer: 1024},
+		events.On(m.CheckCompleted),
+		events.On(m.URLDeleted),
+		events.On(m.ContentChanged),
+	)
+	bus.Subscribe("stream", events.SubscribeOptions{Buffer: 1024, DropWhenFull: true},
+		events.On(hub.CheckCompleted),
+		events.On(hub.URLStateChanged),
+		events.On(hub.ContentChanged),
+	)
+
+	checker := monitor.NewChecker(cfg.Monitor.Location, cfg.Monitor.CheckTimeout)
+	// The monitor checks URLs of every project
+	allURLs := monitor.URLSourceFunc(func(ctx context.Context) ([]*entity.URL, error) {
+		page, err := urlRepo.List(ctx, uuid.Nil, repository.URLFilter{})
+		if err != nil {
+			return nil, err
+		}
+		return page.URLs, nil
+	})
+	mon := monitor.NewMonitor(allURLs, checker, contentUseCase, bus, logger)
+	m.RegisterMonitor(mon)
 	if err := mon.Start(ctx); err != nil {
 		logger.Error("failed to start monitor", slog.Any("error", err))
 	}
 
 	// Initialize use cases with monitor for dynamic URL management
-	urlUseCase := usecase.NewURLUseCase(urlRepo, mon)
-	checkUseCase := usecase.NewCheckUseCase(checkRepo)
+	urlUseCase := usecase.NewURLUseCase(urlRepo, projectRepo, mon, checkUseCase, bus)
+	projectUseCase := usecase.NewProjectUseCase(projectRepo, urlRepo, mon, bus)
+	statusPageUseCase := usecase.NewStatusPageUseCase(statusPageRepo, urlRepo, checkRepo, incidentRepo)
+	badgeUseCase := usecase.NewBadgeUseCase(urlRepo, checkRepo, incidentRepo)
+
+	// Reconcile the monitors file now, then on change and SIGHUP
+	if cfg.Monitors.File != "" {
+		reconciler := reconcile.NewReconciler(cfg.Monitors.File, monitorsProjectID, cfg.Monitors.Prune, urlUseCase, logger)
+		if err := reconciler.Reconcile(ctx); err != nil {
+			logger.Error("failed to reconcile monitors file", slog.Any("error", err))
+		}
+		go reconciler.Run(ctx, cfg.Monitors.PollInterval)
+	}
+
+	// Expire old checks and create upcoming partitions now, then periodically
+	janitor := retention.NewJanitor(checkRepo, cfg.Retention.Checks, logger)
+	if err := janitor.Sweep(ctx); err != nil {
+		logger.Error("failed to sweep checks", slog.Any("error", err))
+	}
+	go janitor.Run(ctx, cfg.Retention.SweepInterval)
 
 	// Initialize handlers
 	urlHandler := handler.NewURLHandler(urlUseCase, logger)
 	checkHandler := handler.NewCheckHandler(checkUseCase, logger)
+	incidentHandler := handler.NewIncidentHandler(incidentUseCase, logger)
+	contentHandler := handler.NewContentHandler(contentUseCase, logger)
+	statusPageHandler := handler.NewStatusPageHandler(statusPageUseCase, logger)
+	badgeHandler := handler.NewBadgeHandler(badgeUseCase, logger)
+	apiKeyHandler := handler.NewAPIKeyHandler(authUseCase, logger)
+	projectHandler := handler.NewProjectHandler(projectUseCase, logger)
+	agentHandler := handler.NewAgentHandler(urlUseCase, checkUseCase, logger)
+	eventsHandler := handler.NewEventsHandler(hub, logger)
 
 	// Setup router
-	router := setupRouter(urlHandler, checkHandler, logger)
+	router := setupRouter(cfg, m, urlHandler, checkHandler, incidentHandler, contentHandler, statusPageHandler, badgeHandler, apiKeyHandler, projectHandler, agentHandler, eventsHandler, authUseCase, logger)
 
 	// Setup HTTP server
 	server := &http.Server{
@@ -106,6 +263,9 @@ func main() {
 		// Stop monitor
 		mon.Stop()
 
+		// End live event streams, they would otherwise hold the shutdown
+		hub.Close()
+
 		// Graceful shutdown with timeout
 		ctx, cancel := context.WithTimeout(context.Background(), cfg.HTTPServer.ShutdownTimeout)
 		defer cancel()
@@ -123,8 +283,19 @@ func main() {
 }
 
 func setupRouter(
+	cfg *config.Config,
+	m *metrics.Metrics,
 	urlHandler *handler.URLHandler,
 	checkHandler *handler.CheckHandler,
+	incidentHandler *handler.IncidentHandler,
+	contentHandler *handler.ContentHandler,
+	statusPageHandler *handler.StatusPageHandler,
+	badgeHandler *handler.BadgeHandler,
+	apiKeyHandler *handler.APIKeyHandler,
+	projectHandler *handler.ProjectHandler,
+	agentHandler *handler.AgentHandler,
+	eventsHandler *handler.EventsHandler,
+	authUseCase *usecase.AuthUseCase,
 	logger *slog.Logger,
 ) *chi.Mux {
 	router := chi.NewRouter()
@@ -132,7 +303,9 @@ func setupRouter(
 	// Middleware
 	router.Use(middleware.RequestID)
 	router.Use(middleware.RealIP)
+	router.Use(mw.Tracing())
 	router.Use(mw.Logger(logger))
+	router.Use(mw.Metrics(m))
 	router.Use(middleware.Recoverer)
 	router.Use(middleware.URLFormat)
 
@@ -142,15 +315,103 @@ func setupRouter(
 		fmt.Fprint(w, "OK")
 	})
 
+	// Prometheus metrics endpoint, its series cover the URLs of every project
+	router.Group(func(r chi.Router) {
+		r.Use(mw.Auth(authUseCase, logger))
+		r.Use(mw.RequireScope(entity.ScopeMetricsRead))
+		r.Use(mw.RequireProject(entity.DefaultProjectID))
+
+		r.Method(http.MethodGet, "/metrics", m.Handler())
+	})
+
+	// Public status pages, GET /status/{slug}.json renders JSON
+	router.Get("/status/{slug}", statusPageHandler.Render)
+
 	// URL routes
 	router.Route("/urls", func(r chi.Router) {
-		r.Post("/", urlHandler.Create)
-		r.Get("/", urlHandler.List)
-		r.Get("/{id}", urlHandler.Get)
-		r.Delete("/{id}", urlHandler.Delete)
-		r.Get("/{id}/history", checkHandler.GetHistory)
+		// Public badges, authorized by the URL's public token
+		r.Get("/{id}/badge/status", badgeHandler.Status)
+		r.Get("/{id}/badge/uptime", badgeHandler.Uptime)
+		r.Get("/{id}/badge/latency", badgeHandler.Latency)
+
+		r.Group(func(r chi.Router) {
+			r.Use(mw.Auth(authUseCase, logger))
+
+			r.With(mw.RequireScope(entity.ScopeURLsWrite)).Post("/", urlHandler.Create)
+			r.With(mw.RequireScope(entity.ScopeURLsRead)).Get("/", urlHandler.List)
+			r.With(mw.RequireScope(entity.ScopeURLsWrite)).Post("/import", urlHandler.Import)
+			r.With(mw.RequireScope(entity.ScopeURLsRead)).Get("/export", urlHandler.Export)
+			r.With(mw.RequireScope(entity.ScopeURLsRead)).Get("/{id}", urlHandler.Get)
+			r.With(mw.RequireScope(entity.ScopeURLsWrite)).Patch("/{id}", urlHandler.Update)
+			r.With(mw.RequireScope(entity.ScopeURLsWrite)).Delete("/{id}", urlHandler.Delete)
+			r.With(mw.RequireScope(entity.ScopeURLsWrite)).Post("/{id}/check", urlHandler.CheckNow)
+			r.With(mw.RequireScope(entity.ScopeChecksRead)).Get("/{id}/history", checkHandler.GetHistory)
+			r.With(mw.RequireScope(entity.ScopeChecksRead)).Get("/{id}/locations", checkHandler.GetLocations)
+			r.With(mw.RequireScope(entity.ScopeChecksRead)).Get("/{id}/incidents", incidentHandler.List)
+			r.With(mw.RequireScope(entity.ScopeChecksRead)).Get("/{id}/content-changes", contentHandler.ListChanges)
+		})
 	})
 
+	// Live check results, state changes and content changes
+	router.Route("/events", func(r chi.Router) {
+		r.Use(mw.Auth(authUseCase, logger))
+		r.Use(mw.RequireScope(entity.ScopeChecksRead))
+
+		r.Get("/", eventsHandler.Stream)
+		r.Get("/ws", eventsHandler.WebSocket)
+	})
+
+	// Dry-run checks, nothing is stored
+	router.Route("/check", func(r chi.Router) {
+		r.Use(mw.Auth(authUseCase, logger))
+
+		r.With(mw.RequireScope(entity.ScopeURLsWrite)).Post("/test", urlHandler.Test)
+	})
+
+	// Statistics routes
+	router.Route("/stats", func(r chi.Router) {
+		r.Use(mw.Auth(authUseCase, logger))
+
+		r.With(mw.RequireScope(entity.ScopeChecksRead)).Get("/uptime", checkHandler.GetStatsByLabel)
+	})
+
+	// Status page routes
+	router.Route("/status-pages", func(r chi.Router) {
+		r.Use(mw.Auth(authUseCase, logger))
+
+		r.With(mw.RequireScope(entity.ScopeURLsWrite)).Post("/", statusPageHandler.Create)
+		r.With(mw.RequireScope(entity.ScopeURLsRead)).Get("/", statusPageHandler.List)
+		r.With(mw.RequireScope(entity.ScopeURLsRead)).Get("/{id}", statusPageHandler.Get)
+		r.With(mw.RequireScope(entity.ScopeURLsWrite)).Put("/{id}", statusPageHandler.Update)
+		r.With(mw.RequireScope(entity.ScopeURLsWrite)).Delete("/{id}", statusPageHandler.Delete)
+	})
+
+	// Admin routes, restricted to admin keys of the default project
+	router.Route("/admin", func(r chi.Router) {
+		r.Use(mw.Auth(authUseCase, logger))
+		r.Use(mw.RequireScope(entity.ScopeAdmin))
+		r.Use(mw.RequireProject(entity.DefaultProjectID))
+
+		r.Post("/api-keys", apiKeyHandler.Create)
+		r.Get("/api-keys", apiKeyHandler.List)
+		r.Delete("/api-keys/{id}", apiKeyHandler.Delete)
+
+		r.Post("/projects", projectHandler.Create)
+		r.Get("/projects", projectHandler.List)
+		r.Get("/projects/{id}", projectHandler.Get)
+		r.Put("/projects/{id}", projectHandler.Update)
+		r.Delete("/projects/{id}", projectHandler.Delete)
+	})
+
+	// Remote agent routes
+	if cfg.Agents.Token != "" {
+		router.Route("/agent", func(r chi.Router) {
+			r.Use(mw.AgentAuth(cfg.Agents.Token))
+			r.Get("/urls", agentHandler.ListURLs)
+			r.Post("/checks", agentHandler.ReportCheck)
+		})
+	}
+
 	return router
 }
 
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"url-sentinel/internal/repository/migrate"
+)
+
+const migrateUsage = "usage: server migrate up | down [steps] | status"
+
+// runMigrate runs the migrate subcommand with its arguments
+func runMigrate(ctx context.Context, migrator *migrate.Migrator, args []string, out io.Writer) error {
+	if migrator == nil {
+		return errors.New("the storage driver has no schema to migrate")
+	}
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		for _, m := range applied {
+			fmt.Fprintf(out, "applied %03d_%s\n", m.Version, m.Name)
+		}
+		if err == nil && len(applied) == 0 {
+			fmt.Fprintln(out, "no pending migrations")
+		}
+		return err
+
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n <= 0 {
+				return fmt.Errorf("invalid number of steps %q", args[1])
+			}
+			steps = n
+		}
+		reverted, err := migrator.Down(ctx, steps)
+		for _, m := range reverted {
+			fmt.Fprintf(out, "reverted %03d_%s\n", m.Version, m.Name)
+		}
+		if err == nil && len(reverted) == 0 {
+			fmt.Fprintln(out, "no applied migrations")
+		}
+		return err
+
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+		for _, s := range statuses {
+			state, appliedAt := "pending", "-"
+			if s.AppliedAt != nil {
+				state, appliedAt = "applied", s.AppliedAt.Format(time.RFC3339)
+			}
+			if s.Unknown {
+				state = "unknown"
+			}
+			fmt.Fprintf(tw, "%03d\t%s\t%s\t%s\n", s.Version, s.Name, state, appliedAt)
+		}
+		return tw.Flush()
+	}
+
+	return errors.New(migrateUsage)
+}
+
+// prepareSchema applies pending migrations when enabled, otherwise it fails
+// while any is pending so the server never runs against an older schema
+func prepareSchema(ctx context.Context, migrator *migrate.Migrator, autoMigrate bool, logger *slog.Logger) error {
+	if migrator == nil {
+		return nil
+	}
+
+	if autoMigrate {
+		applied, err := migrator.Up(ctx)
+		for _, m := range applied {
+			logger.Info("migration applied", slog.Int("version", m.Version), slog.String("name", m.Name))
+		}
+		return err
+	}
+
+	pending, err := migrator.Pending(ctx)
+	if err != nil {
+		return err
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("%d pending migrations, run \"server migrate up\"", len(pending))
+	}
+
+	return nil
+}
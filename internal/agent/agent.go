@@ -0,0 +1,68 @@
+package agent
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"url-sentinel/internal/events"
+	"url-sentinel/internal/monitor"
+)
+
+// reportWorkers is how many check results are reported to the server
+// concurrently, the results of a URL are reported in the order of the checks
+const reportWorkers = 4
+
+// Agent runs checks from a remote location and reports results to the server
+type Agent struct {
+	monitor      *monitor.Monitor
+	bus          *events.Bus
+	syncInterval time.Duration
+	logger       *slog.Logger
+}
+
+// New creates a new agent pulling its assignments through the given client
+func New(client *Client, checker *monitor.Checker, syncInterval time.Duration, logger *slog.Logger) *Agent {
+	// Check results are reported to the server by a subscriber of the local bus
+	bus := events.NewBus(logger)
+	bus.Subscribe("report", events.SubscribeOptions{Workers: reportWorkers, OrderBy: events.ByURL},
+		events.On(func(ctx context.Context, e events.CheckCompleted) error {
+			return client.ReportCheck(ctx, e.Check, e.ErrorClass)
+		}),
+	)
+
+	return &Agent{
+		monitor:      monitor.NewMonitor(client, checker, nil, bus, logger),
+		bus:          bus,
+		syncInterval: syncInterval,
+		logger:       logger,
+	}
+}
+
+// Run keeps the set of watched URLs in sync with the server until ctx is cancelled
+func (a *Agent) Run(ctx context.Context) {
+	ticker := time.NewTicker(a.syncInterval)
+	defer ticker.Stop()
+
+	// Watchers of the monitor stop with ctx
+	if err := a.monitor.Start(ctx); err != nil {
+		a.logger.Error("failed to sync assigned urls", slog.Any("error", err))
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			a.monitor.Stop()
+			a.bus.Close()
+			return
+		case <-ticker.C:
+			a.sync(ctx)
+		}
+	}
+}
+
+func (a *Agent) sync(ctx context.Context) {
+	if err := a.monitor.Sync(ctx); err != nil {
+		a.logger.Error("failed to sync assigned urls", slog.Any("error", err))
+	}
+}
@@ -0,0 +1,143 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"url-sentinel/internal/delivery/http/dto"
+	"url-sentinel/internal/domain/entity"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+// Client talks to the agent API of the central server
+type Client struct {
+	baseURL string
+	token   string
+	client  *http.Client
+}
+
+// NewClient creates a new agent API client
+func NewClient(baseURL, token string) *Client {
+	return &Client{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		token:   token,
+		client: &http.Client{
+			Timeout:   10 * time.Second,
+			Transport: otelhttp.NewTransport(http.DefaultTransport),
+		},
+	}
+}
+
+// List fetches the URLs assigned to this agent
+func (c *Client) List(ctx context.Context) ([]*entity.URL, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, "/agent/urls", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp []dto.URLResponse
+	if err := c.do(req, http.StatusOK, &resp); err != nil {
+		return nil, fmt.Errorf("failed to list urls: %w", err)
+	}
+
+	urls := make([]*entity.URL, 0, len(resp))
+	for _, item := range resp {
+		interval, err := time.ParseDuration(item.CheckInterval)
+		if err != nil {
+			return nil, fmt.Errorf("invalid check interval for url %s: %w", item.ID, err)
+		}
+		redirects, err := entity.ParseRedirectPolicy(item.FollowRedirects)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redirect policy for url %s: %w", item.ID, err)
+		}
+
+		urls = append(urls, &entity.URL{
+			ID:                   item.ID,
+			Address:              item.Address,
+			CheckInterval:        interval,
+			Labels:               item.Labels,
+			Redirects:            redirects,
+			ExpectFinalURL:       item.ExpectFinalURL,
+			ExpectRedirectDomain: item.ExpectRedirectDomain,
+			Paused:               item.Paused,
+			CreatedAt:            item.CreatedAt,
+		})
+	}
+
+	return urls, nil
+}
+
+// ReportCheck reports a check result and the error class of its failure to
+// the server, which stores it and evaluates the URL state
+func (c *Client) ReportCheck(ctx context.Context, check *entity.Check, errorClass string) error {
+	redirects := make([]dto.Redirect, 0, len(check.Redirects))
+	for _, r := range check.Redirects {
+		redirects = append(redirects, dto.Redirect{Code: r.Code, Location: r.Location})
+	}
+
+	body, err := json.Marshal(dto.ReportCheckRequest{
+		URLID:      check.URLID,
+		Location:   check.Location,
+		Trigger:    string(check.Trigger),
+		Status:     check.Status,
+		Code:       check.Code,
+		Duration:   check.Duration.String(),
+		ErrorClass: errorClass,
+		Redirects:  redirects,
+		CheckedAt:  check.CheckedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode check: %w", err)
+	}
+
+	req, err := c.newRequest(ctx, http.MethodPost, "/agent/checks", body)
+	if err != nil {
+		return err
+	}
+
+	if err := c.do(req, http.StatusCreated, nil); err != nil {
+		return fmt.Errorf("failed to report check: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Client) newRequest(ctx context.Context, method, path string, body []byte) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	return req, nil
+}
+
+func (c *Client) do(req *http.Request, expected int, out any) error {
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != expected {
+		var errResp dto.ErrorResponse
+		_ = json.NewDecoder(resp.Body).Decode(&errResp)
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, errResp.Error)
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
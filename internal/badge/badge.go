@@ -0,0 +1,54 @@
+package badge
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+)
+
+// Badge colors
+const (
+	ColorBrightGreen = "#4c1"
+	ColorGreen       = "#97ca00"
+	ColorYellow      = "#dfb317"
+	ColorOrange      = "#fe7d37"
+	ColorRed         = "#e05d44"
+	ColorGrey        = "#9f9f9f"
+)
+
+const (
+	charWidth = 7  // approximate width of a character in 11px Verdana
+	padding   = 10 // horizontal padding of each half
+)
+
+// Render draws a flat shields-style badge with a label and a colored message
+func Render(label, message, color string) []byte {
+	labelWidth := textWidth(label)
+	messageWidth := textWidth(message)
+	width := labelWidth + messageWidth
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="20" role="img" aria-label="%s: %s">`,
+		width, html.EscapeString(label), html.EscapeString(message))
+	fmt.Fprintf(&buf, `<title>%s: %s</title>`, html.EscapeString(label), html.EscapeString(message))
+	buf.WriteString(`<linearGradient id="s" x2="0" y2="100%"><stop offset="0" stop-color="#bbb" stop-opacity=".1"/><stop offset="1" stop-opacity=".1"/></linearGradient>`)
+	fmt.Fprintf(&buf, `<clipPath id="r"><rect width="%d" height="20" rx="3" fill="#fff"/></clipPath>`, width)
+	fmt.Fprintf(&buf, `<g clip-path="url(#r)"><rect width="%d" height="20" fill="#555"/><rect x="%d" width="%d" height="20" fill="%s"/><rect width="%d" height="20" fill="url(#s)"/></g>`,
+		labelWidth, labelWidth, messageWidth, color, width)
+	buf.WriteString(`<g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">`)
+	writeText(&buf, labelWidth/2, label)
+	writeText(&buf, labelWidth+messageWidth/2, message)
+	buf.WriteString(`</g></svg>`)
+
+	return buf.Bytes()
+}
+
+func writeText(buf *bytes.Buffer, x int, text string) {
+	escaped := html.EscapeString(text)
+	fmt.Fprintf(buf, `<text x="%d" y="15" fill="#010101" fill-opacity=".3">%s</text>`, x, escaped)
+	fmt.Fprintf(buf, `<text x="%d" y="14">%s</text>`, x, escaped)
+}
+
+func textWidth(text string) int {
+	return len([]rune(text))*charWidth + 2*padding
+}
@@ -0,0 +1,221 @@
+// Package cli implements sentinelctl, a command-line client of the REST API
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"url-sentinel/internal/delivery/http/dto"
+
+	"github.com/google/uuid"
+)
+
+// Client talks to the REST API of the server
+type Client struct {
+	baseURL string
+	apiKey  string
+	client  *http.Client
+}
+
+// NewClient creates a new API client authenticating with the API key
+func NewClient(baseURL, apiKey string) *Client {
+	return &Client{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		apiKey:  apiKey,
+		client:  &http.Client{Timeout: 60 * time.Second},
+	}
+}
+
+// CreateURL adds a URL for monitoring
+func (c *Client) CreateURL(ctx context.Context, req dto.CreateURLRequest) (*dto.URLResponse, error) {
+	var resp dto.URLResponse
+	if err := c.doJSON(ctx, http.MethodPost, "/urls", nil, req, http.StatusCreated, &resp); err != nil {
+		return nil, fmt.Errorf("failed to create url: %w", err)
+	}
+	return &resp, nil
+}
+
+// ListURLs lists URLs matching the query and returns the cursor of the next page, empty on the last page
+func (c *Client) ListURLs(ctx context.Context, query url.Values) ([]dto.URLResponse, string, error) {
+	var resp []dto.URLResponse
+	header, err := c.do(ctx, http.MethodGet, "/urls", query, nil, "", http.StatusOK, &resp)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list urls: %w", err)
+	}
+	return resp, header.Get("X-Next-Cursor"), nil
+}
+
+// GetURL fetches a URL
+func (c *Client) GetURL(ctx context.Context, id uuid.UUID) (*dto.URLResponse, error) {
+	var resp dto.URLResponse
+	if err := c.doJSON(ctx, http.MethodGet, "/urls/"+id.String(), nil, nil, http.StatusOK, &resp); err != nil {
+		return nil, fmt.Errorf("failed to get url: %w", err)
+	}
+	return &resp, nil
+}
+
+// UpdateURL changes the settings of a URL, omitted fields keep their value
+func (c *Client) UpdateURL(ctx context.Context, id uuid.UUID, req dto.UpdateURLRequest) (*dto.URLResponse, error) {
+	var resp dto.URLResponse
+	if err := c.doJSON(ctx, http.MethodPatch, "/urls/"+id.String(), nil, req, http.StatusOK, &resp); err != nil {
+		return nil, fmt.Errorf("failed to update url: %w", err)
+	}
+	return &resp, nil
+}
+
+// DeleteURL deletes a URL
+func (c *Client) DeleteURL(ctx context.Context, id uuid.UUID) error {
+	if err := c.doJSON(ctx, http.MethodDelete, "/urls/"+id.String(), nil, nil, http.StatusNoContent, nil); err != nil {
+		return fmt.Errorf("failed to delete url: %w", err)
+	}
+	return nil
+}
+
+// History fetches the check history of a URL
+func (c *Client) History(ctx context.Context, id uuid.UUID) ([]dto.CheckResponse, error) {
+	var resp []dto.CheckResponse
+	if err := c.doJSON(ctx, http.MethodGet, "/urls/"+id.String()+"/history", nil, nil, http.StatusOK, &resp); err != nil {
+		return nil, fmt.Errorf("failed to get history: %w", err)
+	}
+	return resp, nil
+}
+
+// Incidents fetches the incidents of a URL
+func (c *Client) Incidents(ctx context.Context, id uuid.UUID) ([]dto.IncidentResponse, error) {
+	var resp []dto.IncidentResponse
+	if err := c.doJSON(ctx, http.MethodGet, "/urls/"+id.String()+"/incidents", nil, nil, http.StatusOK, &resp); err != nil {
+		return nil, fmt.Errorf("failed to list incidents: %w", err)
+	}
+	return resp, nil
+}
+
+// ContentChanges fetches the content changes of a URL
+func (c *Client) ContentChanges(ctx context.Context, id uuid.UUID) ([]dto.ContentChangeResponse, error) {
+	var resp []dto.ContentChangeResponse
+	if err := c.doJSON(ctx, http.MethodGet, "/urls/"+id.String()+"/content-changes", nil, nil, http.StatusOK, &resp); err != nil {
+		return nil, fmt.Errorf("failed to list content changes: %w", err)
+	}
+	return resp, nil
+}
+
+// CheckNow checks a URL immediately
+func (c *Client) CheckNow(ctx context.Context, id uuid.UUID) (*dto.CheckResponse, error) {
+	var resp dto.CheckResponse
+	if err := c.doJSON(ctx, http.MethodPost, "/urls/"+id.String()+"/check", nil, nil, http.StatusCreated, &resp); err != nil {
+		return nil, fmt.Errorf("failed to check url: %w", err)
+	}
+	return &resp, nil
+}
+
+// StatsByLabel fetches uptime statistics grouped by the values of a label
+func (c *Client) StatsByLabel(ctx context.Context, query url.Values) ([]dto.LabelStatsResponse, error) {
+	var resp []dto.LabelStatsResponse
+	if err := c.doJSON(ctx, http.MethodGet, "/stats/uptime", query, nil, http.StatusOK, &resp); err != nil {
+		return nil, fmt.Errorf("failed to get stats: %w", err)
+	}
+	return resp, nil
+}
+
+// ImportURLs uploads a file of URL definitions. A report with failed rows is
+// returned together with an error.
+func (c *Client) ImportURLs(ctx context.Context, query url.Values, contentType string, body []byte) (*dto.ImportResponse, error) {
+	var resp dto.ImportResponse
+	_, err := c.do(ctx, http.MethodPost, "/urls/import", query, body, contentType, http.StatusOK, &resp)
+	if err != nil {
+		if resp.Failed > 0 {
+			return &resp, fmt.Errorf("import rejected, %d rows failed", resp.Failed)
+		}
+		return nil, fmt.Errorf("failed to import urls: %w", err)
+	}
+	return &resp, nil
+}
+
+// ExportURLs downloads the URL definitions of the project in the format
+func (c *Client) ExportURLs(ctx context.Context, format string) ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := c.do(ctx, http.MethodGet, "/urls/export", url.Values{"format": {format}}, nil, "", http.StatusOK, &buf); err != nil {
+		return nil, fmt.Errorf("failed to export urls: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// doJSON sends in encoded as JSON, nil sends no body
+func (c *Client) doJSON(ctx context.Context, method, path string, query url.Values, in any, expected int, out any) error {
+	var body []byte
+	if in != nil {
+		var err error
+		if body, err = json.Marshal(in); err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+	}
+
+	_, err := c.do(ctx, method, path, query, body, "application/json", expected, out)
+	return err
+}
+
+// do sends the request and decodes the JSON response into out, a *bytes.Buffer
+// receives the raw body. Responses with an unexpected status are still decoded
+// into out when possible, their error message is returned.
+func (c *Client) do(
+	ctx context.Context,
+	method, path string,
+	query url.Values,
+	body []byte,
+	contentType string,
+	expected int,
+	out any,
+) (http.Header, error) {
+	target := c.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	if body != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != expected {
+		if out != nil {
+			_ = json.Unmarshal(data, out)
+		}
+		var errResp dto.ErrorResponse
+		_ = json.Unmarshal(data, &errResp)
+		return resp.Header, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, errResp.Error)
+	}
+
+	switch out := out.(type) {
+	case nil:
+	case *bytes.Buffer:
+		out.Write(data)
+	default:
+		if err := json.Unmarshal(data, out); err != nil {
+			return resp.Header, fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+
+	return resp.Header, nil
+}
@@ -0,0 +1,591 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"url-sentinel/internal/config"
+	"url-sentinel/internal/delivery/http/dto"
+	"url-sentinel/internal/urlfile"
+
+	"github.com/google/uuid"
+)
+
+const usage = `Usage: sentinelctl [flags] <command> [args]
+
+Commands:
+  urls add <address> [-interval 1m] [-quorum N] [-label k=v]... [-watch-content] [-ignore-selector css]... [-ignore-pattern regexp]... [-alert-on-change] [-follow-redirects on|off|N] [-expect-final-url url] [-expect-redirect-domain domain]
+  urls list [-q text] [-state up|down|unknown] [-interval 30s] [-label k=v,...] [-sort field] [-order asc|desc] [-limit N]
+  urls get <id>
+  urls update <id> [-interval 1m] [-quorum N] [-label k=v]... [-remove-label k]... [-follow-redirects on|off|N] [-expect-final-url url] [-expect-redirect-domain domain]
+  urls delete <id>
+  history <id> [-limit N]
+  incidents <id>
+  content-changes <id>
+  check-now <id>
+  stats -by <label key> [-label k=v,...] [-period 30d]
+  import <file|-> [-format json|yaml|csv] [-on-conflict skip|update] [-dry-run]
+  export [-format json|yaml|csv]
+  watch [-every 5s] [urls list filters]
+
+Flags:
+`
+
+var (
+	ErrUsage = errors.New("invalid usage")
+)
+
+// app runs commands against the API
+type app struct {
+	client *Client
+	out    *printer
+	stdout io.Writer
+}
+
+// Run parses the global flags and runs the command in args
+func Run(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("sentinelctl", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprint(stderr, usage)
+		fs.PrintDefaults()
+	}
+
+	configPath := fs.String("config", defaultConfigPath(), "config file with server, api_key and output")
+	server := fs.String("server", "", "server URL, overrides the config file and SENTINEL_SERVER")
+	apiKey := fs.String("api-key", "", "API key, overrides the config file and SENTINEL_API_KEY")
+	output := fs.String("o", "", "output format: table, json or yaml")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return ErrUsage
+	}
+
+	cfg, err := config.LoadCLI(*configPath)
+	if err != nil {
+		return err
+	}
+	if *server != "" {
+		cfg.Server = *server
+	}
+	if *apiKey != "" {
+		cfg.APIKey = *apiKey
+	}
+	if *output != "" {
+		cfg.Output = *output
+	}
+
+	a := &app{
+		client: NewClient(cfg.Server, cfg.APIKey),
+		out:    &printer{w: stdout, format: cfg.Output},
+		stdout: stdout,
+	}
+
+	command, rest := fs.Arg(0), fs.Args()[1:]
+	switch command {
+	case "urls":
+		if len(rest) == 0 {
+			return fmt.Errorf("%w: urls needs a subcommand: add, list, get, update or delete", ErrUsage)
+		}
+		switch rest[0] {
+		case "add":
+			return a.urlsAdd(ctx, rest[1:])
+		case "list":
+			return a.urlsList(ctx, rest[1:])
+		case "get":
+			return a.urlsGet(ctx, rest[1:])
+		case "update":
+			return a.urlsUpdate(ctx, rest[1:])
+		case "delete":
+			return a.urlsDelete(ctx, rest[1:])
+		}
+		return fmt.Errorf("%w: unknown urls subcommand %q", ErrUsage, rest[0])
+	case "history":
+		return a.history(ctx, rest)
+	case "incidents":
+		return a.incidents(ctx, rest)
+	case "content-changes":
+		return a.contentChanges(ctx, rest)
+	case "check-now":
+		return a.checkNow(ctx, rest)
+	case "stats":
+		return a.stats(ctx, rest)
+	case "import":
+		return a.importURLs(ctx, rest)
+	case "export":
+		return a.exportURLs(ctx, rest)
+	case "watch":
+		return a.watch(ctx, rest)
+	default:
+		fs.Usage()
+		return fmt.Errorf("%w: unknown command %q", ErrUsage, command)
+	}
+}
+
+// defaultConfigPath returns sentinelctl/config.yaml in the user config directory
+func defaultConfigPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "sentinelctl.yaml"
+	}
+	return filepath.Join(dir, "sentinelctl", "config.yaml")
+}
+
+func (a *app) urlsAdd(ctx context.Context, args []string) error {
+	fs := newFlagSet("urls add")
+	interval := fs.String("interval", "1m", "check interval")
+	quorum := fs.Int("quorum", 0, "failing locations required to declare the URL down, server default when 0")
+	labels := labelsFlag{}
+	fs.Var(labels, "label", "label as key=value, repeatable")
+	watchContent := fs.Bool("watch-content", false, "detect changes of the response body")
+	var ignoreSelectors, ignorePatterns listFlag
+	fs.Var(&ignoreSelectors, "ignore-selector", "CSS selector of HTML elements left out of the watched content, repeatable")
+	fs.Var(&ignorePatterns, "ignore-pattern", "regular expression of text left out of the watched content, repeatable")
+	alertOnChange := fs.Bool("alert-on-change", false, "fail the check that detects a content change")
+	followRedirects := fs.String("follow-redirects", "", "on, off or the most redirects a check follows, server default when empty")
+	expectFinalURL := fs.String("expect-final-url", "", "fail checks whose response does not come from this URL after redirects")
+	expectRedirectDomain := fs.String("expect-redirect-domain", "", "fail checks redirected off this domain and its subdomains")
+
+	pos, err := parseArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	// Any content option enables the watch
+	var contentWatch *dto.ContentWatch
+	if *watchContent || *alertOnChange || len(ignoreSelectors) > 0 || len(ignorePatterns) > 0 {
+		contentWatch = &dto.ContentWatch{
+			IgnoreSelectors: ignoreSelectors,
+			IgnorePatterns:  ignorePatterns,
+			Alert:           *alertOnChange,
+		}
+	}
+
+	u, err := a.client.CreateURL(ctx, dto.CreateURLRequest{
+		Address:              pos[0],
+		CheckInterval:        *interval,
+		Quorum:               *quorum,
+		Labels:               labels,
+		ContentWatch:         contentWatch,
+		FollowRedirects:      *followRedirects,
+		ExpectFinalURL:       *expectFinalURL,
+		ExpectRedirectDomain: *expectRedirectDomain,
+	})
+	if err != nil {
+		return err
+	}
+
+	return a.out.print(u, urlTable(u))
+}
+
+func (a *app) urlsList(ctx context.Context, args []string) error {
+	fs := newFlagSet("urls list")
+	query := listFlags(fs)
+
+	if _, err := parseArgs(fs, args, 0); err != nil {
+		return err
+	}
+
+	urls, next, err := a.client.ListURLs(ctx, query())
+	if err != nil {
+		return err
+	}
+
+	if err := a.out.print(urls, urlsTable(urls)); err != nil {
+		return err
+	}
+	if next != "" && a.out.format == OutputTable {
+		fmt.Fprintf(a.stdout, "\nnext page: -after %s\n", next)
+	}
+	return nil
+}
+
+func (a *app) urlsGet(ctx context.Context, args []string) error {
+	id, err := parseID(newFlagSet("urls get"), args)
+	if err != nil {
+		return err
+	}
+
+	u, err := a.client.GetURL(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return a.out.print(u, urlTable(u))
+}
+
+// urlsUpdate changes the settings of a URL, settings without a flag keep their value
+func (a *app) urlsUpdate(ctx context.Context, args []string) error {
+	fs := newFlagSet("urls update")
+	interval := fs.String("interval", "", "new check interval")
+	quorum := fs.Int("quorum", 0, "new quorum")
+	labels := labelsFlag{}
+	fs.Var(labels, "label", "label to set as key=value, repeatable")
+	var remove listFlag
+	fs.Var(&remove, "remove-label", "label key to remove, repeatable")
+	followRedirects := fs.String("follow-redirects", "", "new redirect policy: on, off or the most redirects a check follows")
+	expectFinalURL := fs.String("expect-final-url", "", "URL the response must come from after redirects, empty removes the expectation")
+	expectRedirectDomain := fs.String("expect-redirect-domain", "", "domain redirects must stay on, empty removes the expectation")
+	paused := fs.Bool("paused", false, "stop checking the URL, -paused=false resumes checks")
+
+	pos, err := parseArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	id, err := uuid.Parse(pos[0])
+	if err != nil {
+		return fmt.Errorf("%w: invalid id %q", ErrUsage, pos[0])
+	}
+
+	var req dto.UpdateURLRequest
+	if *interval != "" {
+		req.CheckInterval = interval
+	}
+	if *quorum != 0 {
+		req.Quorum = quorum
+	}
+	if *followRedirects != "" {
+		req.FollowRedirects = followRedirects
+	}
+	// Expectations are removed by setting them to an empty value, checks are resumed with -paused=false
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "paused":
+			req.Paused = paused
+		case "expect-final-url":
+			req.ExpectFinalURL = expectFinalURL
+		case "expect-redirect-domain":
+			req.ExpectRedirectDomain = expectRedirectDomain
+		}
+	})
+	if len(labels) > 0 || len(remove) > 0 {
+		req.Labels = make(map[string]*string, len(labels)+len(remove))
+		for _, key := range remove {
+			req.Labels[key] = nil
+		}
+		for key, value := range labels {
+			req.Labels[key] = &value
+		}
+	}
+
+	u, err := a.client.UpdateURL(ctx, id, req)
+	if err != nil {
+		return err
+	}
+	return a.out.print(u, urlTable(u))
+}
+
+func (a *app) urlsDelete(ctx context.Context, args []string) error {
+	id, err := parseID(newFlagSet("urls delete"), args)
+	if err != nil {
+		return err
+	}
+
+	if err := a.client.DeleteURL(ctx, id); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(a.stdout, "deleted %s\n", id)
+	return nil
+}
+
+func (a *app) history(ctx context.Context, args []string) error {
+	fs := newFlagSet("history")
+	limit := fs.Int("limit", 20, "number of most recent checks to show, 0 shows all")
+
+	pos, err := parseArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	id, err := uuid.Parse(pos[0])
+	if err != nil {
+		return fmt.Errorf("%w: invalid id %q", ErrUsage, pos[0])
+	}
+
+	checks, err := a.client.History(ctx, id)
+	if err != nil {
+		return err
+	}
+	if *limit > 0 && len(checks) > *limit {
+		checks = checks[:*limit]
+	}
+
+	return a.out.print(checks, checksTable(checks))
+}
+
+func (a *app) incidents(ctx context.Context, args []string) error {
+	id, err := parseID(newFlagSet("incidents"), args)
+	if err != nil {
+		return err
+	}
+
+	incidents, err := a.client.Incidents(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return a.out.print(incidents, incidentsTable(incidents))
+}
+
+func (a *app) contentChanges(ctx context.Context, args []string) error {
+	id, err := parseID(newFlagSet("content-changes"), args)
+	if err != nil {
+		return err
+	}
+
+	changes, err := a.client.ContentChanges(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return a.out.print(changes, contentChangesTable(changes))
+}
+
+func (a *app) checkNow(ctx context.Context, args []string) error {
+	id, err := parseID(newFlagSet("check-now"), args)
+	if err != nil {
+		return err
+	}
+
+	check, err := a.client.CheckNow(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	checks := []dto.CheckResponse{*check}
+	return a.out.print(check, checksTable(checks))
+}
+
+func (a *app) stats(ctx context.Context, args []string) error {
+	fs := newFlagSet("stats")
+	by := fs.String("by", "", "label key to group URLs by (required)")
+	selector := fs.String("label", "", "only URLs carrying these labels, e.g. env=prod")
+	period := fs.String("period", "", "period such as 24h or 30d, server default when empty")
+
+	if _, err := parseArgs(fs, args, 0); err != nil {
+		return err
+	}
+	if *by == "" {
+		return fmt.Errorf("%w: -by is required", ErrUsage)
+	}
+
+	query := url.Values{"by": {*by}}
+	setIf(query, "label", *selector)
+	setIf(query, "period", *period)
+
+	stats, err := a.client.StatsByLabel(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	return a.out.print(stats, labelStatsTable(*by, stats))
+}
+
+func (a *app) importURLs(ctx context.Context, args []string) error {
+	fs := newFlagSet("import")
+	format := fs.String("format", "", "json, yaml or csv, taken from the file extension when empty")
+	onConflict := fs.String("on-conflict", "skip", "what to do with existing addresses: skip or update")
+	dryRun := fs.Bool("dry-run", false, "validate and report without importing")
+
+	pos, err := parseArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	var body []byte
+	if pos[0] == "-" {
+		body, err = io.ReadAll(os.Stdin)
+	} else {
+		body, err = os.ReadFile(pos[0])
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read import file: %w", err)
+	}
+
+	if *format == "" {
+		*format = strings.TrimPrefix(filepath.Ext(pos[0]), ".")
+	}
+	parsed, err := urlfile.ParseFormat(*format)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrUsage, err)
+	}
+
+	query := url.Values{
+		"format":      {string(parsed)},
+		"on_conflict": {*onConflict},
+		"dry_run":     {strconv.FormatBool(*dryRun)},
+	}
+
+	report, importErr := a.client.ImportURLs(ctx, query, parsed.ContentType(), body)
+	if report != nil {
+		if err := a.out.print(report, importTable(report)); err != nil {
+			return err
+		}
+	}
+	return importErr
+}
+
+func (a *app) exportURLs(ctx context.Context, args []string) error {
+	fs := newFlagSet("export")
+	format := fs.String("format", "yaml", "json, yaml or csv")
+
+	if _, err := parseArgs(fs, args, 0); err != nil {
+		return err
+	}
+
+	data, err := a.client.ExportURLs(ctx, *format)
+	if err != nil {
+		return err
+	}
+
+	_, err = a.stdout.Write(data)
+	return err
+}
+
+// watch redraws the URL status table until ctx is cancelled
+func (a *app) watch(ctx context.Context, args []string) error {
+	fs := newFlagSet("watch")
+	every := fs.Duration("every", 5*time.Second, "refresh interval")
+	query := listFlags(fs)
+
+	if _, err := parseArgs(fs, args, 0); err != nil {
+		return err
+	}
+
+	table := &printer{w: a.stdout, format: OutputTable}
+	ticker := time.NewTicker(*every)
+	defer ticker.Stop()
+
+	for {
+		urls, _, err := a.client.ListURLs(ctx, query())
+
+		// Move the cursor home and clear the screen before redrawing
+		fmt.Fprint(a.stdout, "\033[H\033[2J")
+		fmt.Fprintf(a.stdout, "%s, every %s, Ctrl+C to exit\n\n", time.Now().Format(time.DateTime), *every)
+		if err != nil {
+			fmt.Fprintln(a.stdout, err)
+		} else if err := table.print(urls, urlsTable(urls)); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// listFlags registers the GET /urls filters and returns a function building their query
+func listFlags(fs *flag.FlagSet) func() url.Values {
+	search := fs.String("q", "", "substring of the address")
+	state := fs.String("state", "", "up, down, unknown or paused")
+	interval := fs.String("interval", "", "exact check interval, e.g. 30s")
+	selector := fs.String("label", "", "label selector, e.g. team=payments,env=prod")
+	sort := fs.String("sort", "", "created_at, address, last_checked or uptime")
+	order := fs.String("order", "", "asc or desc")
+	limit := fs.Int("limit", 0, "page size, the server default of 100 when 0")
+	after := fs.String("after", "", "cursor of the next page")
+
+	return func() url.Values {
+		query := url.Values{}
+		setIf(query, "q", *search)
+		setIf(query, "state", *state)
+		setIf(query, "interval", *interval)
+		setIf(query, "label", *selector)
+		setIf(query, "sort", *sort)
+		setIf(query, "order", *order)
+		setIf(query, "after", *after)
+		if *limit > 0 {
+			query.Set("limit", strconv.Itoa(*limit))
+		}
+		return query
+	}
+}
+
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	return fs
+}
+
+// parseArgs parses flags placed before, between or after positional arguments
+// and checks that exactly n positional arguments were given
+func parseArgs(fs *flag.FlagSet, args []string, n int) ([]string, error) {
+	var pos []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		pos = append(pos, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+
+	if len(pos) != n {
+		return nil, fmt.Errorf("%w: %s expects %d arguments, got %d", ErrUsage, fs.Name(), n, len(pos))
+	}
+	return pos, nil
+}
+
+// parseID parses a command taking a single URL ID
+func parseID(fs *flag.FlagSet, args []string) (uuid.UUID, error) {
+	pos, err := parseArgs(fs, args, 1)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	id, err := uuid.Parse(pos[0])
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%w: invalid id %q", ErrUsage, pos[0])
+	}
+	return id, nil
+}
+
+func setIf(query url.Values, key, value string) {
+	if value != "" {
+		query.Set(key, value)
+	}
+}
+
+// labelsFlag collects repeated key=value flags
+type labelsFlag map[string]string
+
+func (l labelsFlag) String() string {
+	return formatLabels(l)
+}
+
+func (l labelsFlag) Set(value string) error {
+	key, val, ok := strings.Cut(value, "=")
+	if !ok || key == "" {
+		return fmt.Errorf("label must be key=value, got %q", value)
+	}
+	l[key] = val
+	return nil
+}
+
+// listFlag collects repeated flags
+type listFlag []string
+
+func (l *listFlag) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *listFlag) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
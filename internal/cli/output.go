@@ -0,0 +1,298 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"url-sentinel/internal/delivery/http/dto"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Output formats
+const (
+	OutputTable = "table"
+	OutputJSON  = "json"
+	OutputYAML  = "yaml"
+)
+
+var (
+	ErrUnknownOutput = errors.New("unknown output format, expected table, json or yaml")
+)
+
+// printer renders API responses in the chosen output format
+type printer struct {
+	w      io.Writer
+	format string
+}
+
+// print writes v as JSON or YAML, or calls table to render it as a table
+func (p *printer) print(v any, table func(tw *tabwriter.Writer)) error {
+	switch p.format {
+	case OutputJSON:
+		enc := json.NewEncoder(p.w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	case OutputYAML:
+		return writeYAML(p.w, v)
+	case OutputTable, "":
+		tw := tabwriter.NewWriter(p.w, 0, 0, 2, ' ', 0)
+		table(tw)
+		return tw.Flush()
+	default:
+		return ErrUnknownOutput
+	}
+}
+
+// writeYAML renders v with the field names of its JSON encoding, in the same order
+func writeYAML(w io.Writer, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return err
+	}
+	blockStyle(&node)
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(&node); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// blockStyle turns the flow style and quoting of decoded JSON into plain YAML block style
+func blockStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		blockStyle(child)
+	}
+}
+
+func urlsTable(urls []dto.URLResponse) func(tw *tabwriter.Writer) {
+	return func(tw *tabwriter.Writer) {
+		fmt.Fprintln(tw, "ID\tADDRESS\tSTATE\tCODE\tDURATION\tLAST CHECK\tINTERVAL\tLABELS")
+		for _, u := range urls {
+			s := u.Status
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				u.ID,
+				u.Address,
+				s.State,
+				orDash(s.LastCode),
+				orDash(s.LastDuration),
+				since(s.LastCheckedAt),
+				u.CheckInterval,
+				formatLabels(u.Labels),
+			)
+		}
+	}
+}
+
+func urlTable(u *dto.URLResponse) func(tw *tabwriter.Writer) {
+	return func(tw *tabwriter.Writer) {
+		s := u.Status
+		fmt.Fprintf(tw, "ID:\t%s\n", u.ID)
+		fmt.Fprintf(tw, "Address:\t%s\n", u.Address)
+		fmt.Fprintf(tw, "Interval:\t%s\n", u.CheckInterval)
+		fmt.Fprintf(tw, "Quorum:\t%d\n", u.Quorum)
+		fmt.Fprintf(tw, "Labels:\t%s\n", formatLabels(u.Labels))
+		fmt.Fprintf(tw, "Content watch:\t%s\n", formatContentWatch(u.ContentWatch))
+		fmt.Fprintf(tw, "Follow redirects:\t%s\n", u.FollowRedirects)
+		if u.ExpectFinalURL != "" {
+			fmt.Fprintf(tw, "Expected final URL:\t%s\n", u.ExpectFinalURL)
+		}
+		if u.ExpectRedirectDomain != "" {
+			fmt.Fprintf(tw, "Expected redirect domain:\t%s\n", u.ExpectRedirectDomain)
+		}
+		fmt.Fprintf(tw, "State:\t%s (since %s)\n", s.State, since(s.StateSince))
+		fmt.Fprintf(tw, "Last check:\t%s, code %s, %s\n", since(s.LastCheckedAt), orDash(s.LastCode), orDash(s.LastDuration))
+		fmt.Fprintf(tw, "Consecutive failures:\t%d\n", s.ConsecutiveFailures)
+		fmt.Fprintf(tw, "Uptime (24h):\t%s\n", percent(s.Uptime))
+		fmt.Fprintf(tw, "Public token:\t%s\n", u.PublicToken)
+		fmt.Fprintf(tw, "Created:\t%s\n", u.CreatedAt.Format(time.RFC3339))
+	}
+}
+
+func checksTable(checks []dto.CheckResponse) func(tw *tabwriter.Writer) {
+	return func(tw *tabwriter.Writer) {
+		fmt.Fprintln(tw, "CHECKED AT\tLOCATION\tTRIGGER\tSTATUS\tCODE\tDURATION\tREDIRECTS")
+		for _, c := range checks {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%d\t%s\t%s\n",
+				c.CheckedAt.Local().Format(time.DateTime),
+				c.Location,
+				c.Trigger,
+				statusText(c.Status),
+				c.Code,
+				c.Duration,
+				formatRedirects(c.Redirects),
+			)
+		}
+	}
+}
+
+func incidentsTable(incidents []dto.IncidentResponse) func(tw *tabwriter.Writer) {
+	return func(tw *tabwriter.Writer) {
+		fmt.Fprintln(tw, "ID\tSTARTED\tRESOLVED\tDURATION\tLOCATIONS")
+		for _, inc := range incidents {
+			resolved, end := "-", time.Now()
+			if inc.ResolvedAt != nil {
+				resolved, end = inc.ResolvedAt.Local().Format(time.DateTime), *inc.ResolvedAt
+			}
+			locations := make([]string, 0, len(inc.Locations))
+			for _, l := range inc.Locations {
+				locations = append(locations, fmt.Sprintf("%s=%s", l.Location, statusText(l.Status)))
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n",
+				inc.ID,
+				inc.StartedAt.Local().Format(time.DateTime),
+				resolved,
+				end.Sub(inc.StartedAt).Round(time.Second),
+				strings.Join(locations, ","),
+			)
+		}
+	}
+}
+
+func contentChangesTable(changes []dto.ContentChangeResponse) func(tw *tabwriter.Writer) {
+	return func(tw *tabwriter.Writer) {
+		fmt.Fprintln(tw, "ID\tDETECTED\tHASH\tLINES")
+		for _, c := range changes {
+			added, removed := diffStat(c.Diff)
+			fmt.Fprintf(tw, "%s\t%s\t%s -> %s\t+%d -%d\n",
+				c.ID,
+				c.DetectedAt.Local().Format(time.DateTime),
+				shortHash(c.PreviousHash),
+				shortHash(c.Hash),
+				added,
+				removed,
+			)
+		}
+	}
+}
+
+func labelStatsTable(key string, stats []dto.LabelStatsResponse) func(tw *tabwriter.Writer) {
+	return func(tw *tabwriter.Writer) {
+		fmt.Fprintf(tw, "%s\tURLS\tCHECKS\tUPTIME\tAVG DURATION\n", strings.ToUpper(key))
+		for _, s := range stats {
+			fmt.Fprintf(tw, "%s\t%d\t%d\t%s\t%s\n", s.Value, s.URLs, s.Checks, percent(s.Uptime), s.AvgDuration)
+		}
+	}
+}
+
+func importTable(report *dto.ImportResponse) func(tw *tabwriter.Writer) {
+	return func(tw *tabwriter.Writer) {
+		fmt.Fprintln(tw, "ROW\tADDRESS\tACTION\tERROR")
+		for _, row := range report.Rows {
+			fmt.Fprintf(tw, "%d\t%s\t%s\t%s\n", row.Row, row.Address, row.Action, row.Error)
+		}
+		fmt.Fprintf(tw, "\ncreated %d, updated %d, skipped %d, failed %d", report.Created, report.Updated, report.Skipped, report.Failed)
+		if report.DryRun {
+			fmt.Fprint(tw, " (dry run, nothing imported)")
+		}
+		fmt.Fprintln(tw)
+	}
+}
+
+func statusText(ok bool) string {
+	if ok {
+		return "ok"
+	}
+	return "fail"
+}
+
+func orDash[T any](v *T) string {
+	if v == nil {
+		return "-"
+	}
+	return fmt.Sprint(*v)
+}
+
+func percent(v *float64) string {
+	if v == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%.2f%%", *v*100)
+}
+
+// since renders a past time relative to now, e.g. "42s ago"
+func since(t *time.Time) string {
+	if t == nil {
+		return "never"
+	}
+	return time.Since(*t).Round(time.Second).String() + " ago"
+}
+
+// formatLabels renders labels in selector syntax with sorted keys
+func formatLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return "-"
+	}
+	pairs := make([]string, 0, len(labels))
+	for key, value := range labels {
+		pairs = append(pairs, key+"="+value)
+	}
+	slices.Sort(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// formatContentWatch summarizes content watch settings, "off" when disabled
+func formatContentWatch(watch *dto.ContentWatch) string {
+	if watch == nil {
+		return "off"
+	}
+	parts := []string{"on"}
+	if watch.Alert {
+		parts = append(parts, "alert")
+	}
+	if len(watch.IgnoreSelectors) > 0 {
+		parts = append(parts, "ignore "+strings.Join(watch.IgnoreSelectors, " "))
+	}
+	if len(watch.IgnorePatterns) > 0 {
+		parts = append(parts, "ignore /"+strings.Join(watch.IgnorePatterns, "/ /")+"/")
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatRedirects summarizes a redirect chain by its length and last location, "-" when empty
+func formatRedirects(redirects []dto.Redirect) string {
+	if len(redirects) == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%d to %s", len(redirects), redirects[len(redirects)-1].Location)
+}
+
+// diffStat counts the added and removed lines of a unified diff, the file
+// header before the first hunk is skipped
+func diffStat(diff string) (added, removed int) {
+	inHunk := false
+	for _, line := range strings.Split(diff, "\n") {
+		switch {
+		case strings.HasPrefix(line, "@@"):
+			inHunk = true
+		case !inHunk:
+		case strings.HasPrefix(line, "+"):
+			added++
+		case strings.HasPrefix(line, "-"):
+			removed++
+		}
+	}
+	return added, removed
+}
+
+// shortHash abbreviates a content hash like git abbreviates commits
+func shortHash(hash string) string {
+	if len(hash) > 12 {
+		return hash[:12]
+	}
+	return hash
+}
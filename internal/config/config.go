@@ -12,11 +12,52 @@ import (
 // Config holds application configuration
 type Config struct {
 	Env        string     `yaml:"env" env:"ENV" env-default:"local"`
+	Storage    Storage    `yaml:"storage"`
 	Database   Database   `yaml:"database"`
 	HTTPServer HTTPServer `yaml:"http_server"`
+	Monitor    Monitor    `yaml:"monitor"`
+	Agents     Agents     `yaml:"agents"`
+	Monitors   Monitors   `yaml:"monitors"`
+	Retention  Retention  `yaml:"retention"`
+	Tracing    Tracing    `yaml:"tracing"`
 }
 
-// Database holds database configuration
+// AgentConfig holds remote probe agent configuration
+type AgentConfig struct {
+	Env          string        `yaml:"env" env:"ENV" env-default:"local"`
+	ServerURL    string        `yaml:"server_url" env:"AGENT_SERVER_URL" env-default:"http://localhost:8080"`
+	Token        string        `yaml:"token" env:"AGENT_TOKEN" env-required:"true"`
+	Location     string        `yaml:"location" env:"AGENT_LOCATION" env-required:"true"`
+	SyncInterval time.Duration `yaml:"sync_interval" env:"AGENT_SYNC_INTERVAL" env-default:"30s"`
+	CheckTimeout time.Duration `yaml:"check_timeout" env:"AGENT_CHECK_TIMEOUT" env-default:"10s"`
+	Tracing      Tracing       `yaml:"tracing"`
+}
+
+// CLIConfig holds sentinelctl configuration
+type CLIConfig struct {
+	Server string `yaml:"server" env:"SENTINEL_SERVER" env-default:"http://localhost:8080"`
+	APIKey string `yaml:"api_key" env:"SENTINEL_API_KEY"`
+	Output string `yaml:"output" env:"SENTINEL_OUTPUT" env-default:"table"` // table, json or yaml
+}
+
+// Storage drivers
+const (
+	StoragePostgres = "postgres"
+	StorageSQLite   = "sqlite"
+	StorageMemory   = "memory"
+)
+
+// Storage selects where data is kept
+type Storage struct {
+	Driver string `yaml:"driver" env:"STORAGE_DRIVER" env-default:"postgres"`    // postgres, sqlite or memory
+	Path   string `yaml:"path" env:"STORAGE_PATH" env-default:"url-sentinel.db"` // database file of the sqlite driver
+
+	// AutoMigrate applies pending migrations on startup. When disabled they are
+	// applied with "server migrate up" and startup fails while any is pending.
+	AutoMigrate bool `yaml:"auto_migrate" env:"STORAGE_AUTO_MIGRATE" env-default:"true"`
+}
+
+// Database holds PostgreSQL configuration
 type Database struct {
 	Host     string `yaml:"host" env:"DB_HOST" env-default:"localhost"`
 	Port     string `yaml:"port" env:"DB_PORT" env-default:"5432"`
@@ -43,15 +84,74 @@ type HTTPServer struct {
 	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" env:"HTTP_SHUTDOWN_TIMEOUT" env-default:"10s"`
 }
 
+// Monitor holds configuration of the built-in monitor
+type Monitor struct {
+	Location     string        `yaml:"location" env:"MONITOR_LOCATION" env-default:"central"`
+	CheckTimeout time.Duration `yaml:"check_timeout" env:"MONITOR_CHECK_TIMEOUT" env-default:"10s"`
+}
+
+// Agents holds configuration of the remote agent API
+type Agents struct {
+	Token string `yaml:"token" env:"AGENT_TOKEN"` // agent API is disabled when empty
+}
+
+// Monitors holds configuration of the declarative monitors file
+type Monitors struct {
+	File         string        `yaml:"file" env:"MONITORS_FILE"`             // reconciling is disabled when empty
+	ProjectID    string        `yaml:"project_id" env:"MONITORS_PROJECT_ID"` // defaults to the default project
+	Prune        bool          `yaml:"prune" env:"MONITORS_PRUNE" env-default:"false"`
+	PollInterval time.Duration `yaml:"poll_interval" env:"MONITORS_POLL_INTERVAL" env-default:"10s"`
+}
+
+// Retention holds configuration of the check history lifetime
+type Retention struct {
+	Checks        time.Duration `yaml:"checks" env:"RETENTION_CHECKS" env-default:"0"` // checks are kept forever when zero
+	SweepInterval time.Duration `yaml:"sweep_interval" env:"RETENTION_SWEEP_INTERVAL" env-default:"1h"`
+}
+
+// Tracing holds OpenTelemetry tracing configuration
+type Tracing struct {
+	Endpoint    string  `yaml:"endpoint" env:"TRACING_ENDPOINT"` // OTLP/HTTP host:port, tracing is disabled when empty
+	Insecure    bool    `yaml:"insecure" env:"TRACING_INSECURE" env-default:"false"`
+	ServiceName string  `yaml:"service_name" env:"TRACING_SERVICE_NAME" env-default:"url-sentinel"`
+	SampleRatio float64 `yaml:"sample_ratio" env:"TRACING_SAMPLE_RATIO" env-default:"1"`
+}
+
 // MustLoad loads configuration from file and environment variables
 func MustLoad() *Config {
 	var cfg Config
+	mustRead(&cfg)
+	return &cfg
+}
+
+// MustLoadAgent loads agent configuration from file and environment variables
+func MustLoadAgent() *AgentConfig {
+	var cfg AgentConfig
+	mustRead(&cfg)
+	return &cfg
+}
 
+// LoadCLI loads sentinelctl configuration from the file, if it exists, and environment variables
+func LoadCLI(path string) (*CLIConfig, error) {
+	var cfg CLIConfig
+
+	if _, err := os.Stat(path); err == nil {
+		if err := cleanenv.ReadConfig(path, &cfg); err != nil {
+			return nil, fmt.Errorf("failed to read config file: %w", err)
+		}
+	} else if err := cleanenv.ReadEnv(&cfg); err != nil {
+		return nil, fmt.Errorf("failed to read environment variables: %w", err)
+	}
+
+	return &cfg, nil
+}
+
+func mustRead(cfg any) {
 	// Try to load from config file if CONFIG_PATH is set
 	configPath := os.Getenv("CONFIG_PATH")
 	if configPath != "" {
 		if _, err := os.Stat(configPath); err == nil {
-			if err := cleanenv.ReadConfig(configPath, &cfg); err != nil {
+			if err := cleanenv.ReadConfig(configPath, cfg); err != nil {
 				log.Fatalf("failed to read config file: %v", err)
 			}
 		} else {
@@ -60,9 +160,7 @@ func MustLoad() *Config {
 	}
 
 	// Read from environment variables (will override file config)
-	if err := cleanenv.ReadEnv(&cfg); err != nil {
+	if err := cleanenv.ReadEnv(cfg); err != nil {
 		log.Fatalf("failed to read environment variables: %v", err)
 	}
-
-	return &cfg
 }
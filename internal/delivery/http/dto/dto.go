@@ -1,6 +1,7 @@
 package dto
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
@@ -8,28 +9,315 @@ import (
 
 // CreateURLRequest represents the request to create a new URL
 type CreateURLRequest struct {
-	Address       string `json:"address"`
-	CheckInterval string `json:"check_interval"` // e.g. "30s", "1m", "5m"
+	Address              string            `json:"address"`
+	CheckInterval        string            `json:"check_interval"`                   // e.g. "30s", "1m", "5m"
+	Quorum               int               `json:"quorum"`                           // failing locations required to declare the URL down, defaults to 1
+	Labels               map[string]string `json:"labels,omitempty"`                 // e.g. {"team": "payments", "env": "prod"}
+	ContentWatch         *ContentWatch     `json:"content_watch,omitempty"`          // detects changes of the response body when set
+	FollowRedirects      string            `json:"follow_redirects,omitempty"`       // on (default), off or the most redirects to follow, e.g. "3"
+	ExpectFinalURL       string            `json:"expect_final_url,omitempty"`       // checks fail unless the response comes from this URL after redirects
+	ExpectRedirectDomain string            `json:"expect_redirect_domain,omitempty"` // checks fail when a redirect leaves this domain or its subdomains, e.g. "example.com"
+}
+
+// UpdateURLRequest represents a partial update of a URL, omitted fields keep their value
+type UpdateURLRequest struct {
+	CheckInterval        *string            `json:"check_interval,omitempty"`
+	Quorum               *int               `json:"quorum,omitempty"`
+	Labels               map[string]*string `json:"labels,omitempty"`                 // null removes a label, labels not listed are kept
+	ContentWatch         json.RawMessage    `json:"content_watch,omitempty"`          // replaces the content watch, null disables it
+	FollowRedirects      *string            `json:"follow_redirects,omitempty"`       // on, off or the most redirects to follow
+	ExpectFinalURL       *string            `json:"expect_final_url,omitempty"`       // an empty string removes the expectation
+	ExpectRedirectDomain *string            `json:"expect_redirect_domain,omitempty"` // an empty string removes the expectation
+	Paused               *bool              `json:"paused,omitempty"`                 // true stops checks until set back to false
+}
+
+// ContentWatch represents the content change detection settings of a URL
+type ContentWatch struct {
+	IgnoreSelectors []string `json:"ignore_selectors,omitempty"` // CSS selectors of HTML elements left out, e.g. "#clock"
+	IgnorePatterns  []string `json:"ignore_patterns,omitempty"`  // regular expressions of text left out, e.g. timestamps
+	Alert           bool     `json:"alert"`                      // a change fails the check that detected it
+}
+
+// TestCheckRequest represents a dry-run check, the URL fields are validated like on creation
+type TestCheckRequest struct {
+	CreateURLRequest
+	Method     string             `json:"method,omitempty"` // defaults to GET
+	Headers    map[string]string  `json:"headers,omitempty"`
+	Body       string             `json:"body,omitempty"`
+	Assertions []AssertionRequest `json:"assertions,omitempty"`
+}
+
+// AssertionRequest represents a condition the dry-run response must satisfy
+type AssertionRequest struct {
+	Type     string `json:"type"`               // status_code, body_contains, header, response_time, final_url or redirect_domain
+	Property string `json:"property,omitempty"` // header name for header assertions
+	Value    string `json:"value"`              // e.g. "2xx", "ok", "application/json", "500ms", "https://example.com/", "example.com"
+}
+
+// TestCheckResponse represents the detailed outcome of a dry-run check
+type TestCheckResponse struct {
+	Check         CheckResponse             `json:"check"`
+	Passed        bool                      `json:"passed"` // check succeeded and all assertions passed
+	ErrorClass    string                    `json:"error_class,omitempty"`
+	Error         string                    `json:"error,omitempty"`
+	Timings       TimingsResponse           `json:"timings"`
+	Headers       map[string][]string       `json:"headers,omitempty"`
+	FinalURL      string                    `json:"final_url,omitempty"` // URL of the response after following redirects
+	Body          string                    `json:"body"`
+	BodyTruncated bool                      `json:"body_truncated"`
+	Assertions    []AssertionResultResponse `json:"assertions"`
+}
+
+// TimingsResponse represents the phases of a check, e.g. "12ms"
+type TimingsResponse struct {
+	DNS       string `json:"dns"`
+	Connect   string `json:"connect"`
+	TLS       string `json:"tls"`
+	FirstByte string `json:"first_byte"`
+	Total     string `json:"total"`
+}
+
+// AssertionResultResponse represents the outcome of a single assertion
+type AssertionResultResponse struct {
+	AssertionRequest
+	Passed bool   `json:"passed"`
+	Actual string `json:"actual"`
+}
+
+// ImportResponse represents the per-row report of a URL import, nothing is imported when a row failed
+type ImportResponse struct {
+	DryRun  bool                `json:"dry_run"`
+	Created int                 `json:"created"`
+	Updated int                 `json:"updated"`
+	Skipped int                 `json:"skipped"`
+	Failed  int                 `json:"failed"`
+	Rows    []ImportRowResponse `json:"rows"`
+}
+
+// ImportRowResponse represents the outcome of a single imported row
+type ImportRowResponse struct {
+	Row     int        `json:"row"` // 1-based, not counting the CSV header
+	Address string     `json:"address"`
+	Action  string     `json:"action"` // created, updated, skipped or failed
+	ID      *uuid.UUID `json:"id,omitempty"`
+	Error   string     `json:"error,omitempty"`
 }
 
 // URLResponse represents a URL in API responses
 type URLResponse struct {
-	ID            uuid.UUID `json:"id"`
-	Address       string    `json:"address"`
-	CheckInterval string    `json:"check_interval"`
-	CreatedAt     time.Time `json:"created_at"`
+	ID                   uuid.UUID         `json:"id"`
+	ProjectID            uuid.UUID         `json:"project_id"`
+	Address              string            `json:"address"`
+	CheckInterval        string            `json:"check_interval"`
+	Quorum               int               `json:"quorum"`
+	PublicToken          string            `json:"public_token"` // grants access to badges
+	Labels               map[string]string `json:"labels"`
+	ContentWatch         *ContentWatch     `json:"content_watch"`                    // null when changes are not detected
+	FollowRedirects      string            `json:"follow_redirects"`                 // on, off or the most redirects followed
+	ExpectFinalURL       string            `json:"expect_final_url,omitempty"`       // omitted when any final URL is accepted
+	ExpectRedirectDomain string            `json:"expect_redirect_domain,omitempty"` // omitted when redirects may go anywhere
+	Paused               bool              `json:"paused"`
+	Status               URLStatusResponse `json:"status"`
+	CreatedAt            time.Time         `json:"created_at"`
+}
+
+// URLStatusResponse represents the current status of a URL, fields other
+// than state are null until the URL is checked for the first time
+type URLStatusResponse struct {
+	State               string     `json:"state"` // up, down, unknown or paused
+	LastStatus          *bool      `json:"last_status"`
+	LastCode            *int       `json:"last_code"`
+	LastDuration        *string    `json:"last_duration"`
+	LastCheckedAt       *time.Time `json:"last_checked_at"`
+	StateSince          *time.Time `json:"state_since"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	Uptime              *float64   `json:"uptime"` // share of successful checks in the 24 hours up to the last check
 }
 
 // CheckResponse represents a check result in API responses
 type CheckResponse struct {
-	ID        uuid.UUID `json:"id"`
-	URLID     uuid.UUID `json:"url_id"`
+	ID        uuid.UUID  `json:"id"`
+	URLID     uuid.UUID  `json:"url_id"`
+	Location  string     `json:"location"`
+	Trigger   string     `json:"trigger"` // scheduled or manual
+	Status    bool       `json:"status"`
+	Code      int        `json:"code"`
+	Duration  string     `json:"duration"`            // e.g. "123ms"
+	Redirects []Redirect `json:"redirects,omitempty"` // redirect responses in the order they were received
+	CheckedAt time.Time  `json:"checked_at"`
+}
+
+// Redirect represents a redirect response received by a check
+type Redirect struct {
+	Code     int    `json:"code"`
+	Location string `json:"location"` // absolute URL the response redirected to
+}
+
+// EventResponse represents a live event pushed over SSE or WebSocket
+type EventResponse struct {
+	Type          string                 `json:"type"` // check, state_change, content_change or lagged
+	URLID         *uuid.UUID             `json:"url_id,omitempty"`
+	Address       string                 `json:"address,omitempty"`
+	Labels        map[string]string      `json:"labels,omitempty"`
+	Check         *CheckResponse         `json:"check,omitempty"`
+	StateChange   *StateChangeResponse   `json:"state_change,omitempty"`
+	ContentChange *ContentChangeResponse `json:"content_change,omitempty"`
+	Dropped       int64                  `json:"dropped,omitempty"` // events missed by a lagging client so far
+	At            time.Time              `json:"at"`
+}
+
+// StateChangeResponse represents a transition of a URL between states
+type StateChangeResponse struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// ContentChangeResponse represents a detected change of a URL's content
+type ContentChangeResponse struct {
+	ID           uuid.UUID `json:"id"`
+	URLID        uuid.UUID `json:"url_id"`
+	PreviousHash string    `json:"previous_hash"`
+	Hash         string    `json:"hash"`
+	Diff         string    `json:"diff"` // unified diff of the normalized text
+	DetectedAt   time.Time `json:"detected_at"`
+}
+
+// ReportCheckRequest represents a check result pushed by a remote agent
+type ReportCheckRequest struct {
+	URLID      uuid.UUID  `json:"url_id"`
+	Location   string     `json:"location"`
+	Trigger    string     `json:"trigger,omitempty"` // scheduled (default) or manual
+	Status     bool       `json:"status"`
+	Code       int        `json:"code"`
+	Duration   string     `json:"duration"`              // e.g. "123ms"
+	ErrorClass string     `json:"error_class,omitempty"` // why the check failed, e.g. "timeout" or "redirect"
+	Redirects  []Redirect `json:"redirects,omitempty"`
+	CheckedAt  time.Time  `json:"checked_at"`
+}
+
+// LocationStatusResponse represents the result of a single location in API responses
+type LocationStatusResponse struct {
+	Location  string    `json:"location"`
 	Status    bool      `json:"status"`
 	Code      int       `json:"code"`
-	Duration  string    `json:"duration"` // e.g. "123ms"
 	CheckedAt time.Time `json:"checked_at"`
 }
 
+// IncidentResponse represents an incident in API responses
+type IncidentResponse struct {
+	ID         uuid.UUID                `json:"id"`
+	URLID      uuid.UUID                `json:"url_id"`
+	Locations  []LocationStatusResponse `json:"locations"`
+	StartedAt  time.Time                `json:"started_at"`
+	ResolvedAt *time.Time               `json:"resolved_at"`
+}
+
+// StatusComponent represents a group of URLs on a status page
+type StatusComponent struct {
+	Name   string      `json:"name"`
+	URLIDs []uuid.UUID `json:"url_ids"`
+}
+
+// StatusPageRequest represents the request to create or update a status page
+type StatusPageRequest struct {
+	Slug       string            `json:"slug"`
+	Title      string            `json:"title"`
+	LogoText   string            `json:"logo_text"`
+	Components []StatusComponent `json:"components"`
+}
+
+// StatusPageResponse represents a status page configuration in API responses
+type StatusPageResponse struct {
+	ID         uuid.UUID         `json:"id"`
+	Slug       string            `json:"slug"`
+	Title      string            `json:"title"`
+	LogoText   string            `json:"logo_text"`
+	Components []StatusComponent `json:"components"`
+	CreatedAt  time.Time         `json:"created_at"`
+}
+
+// DailyUptimeResponse represents the uptime of a URL over a single day
+type DailyUptimeResponse struct {
+	Date   string   `json:"date"`   // e.g. "2024-01-31"
+	Uptime *float64 `json:"uptime"` // share of successful checks, null without data
+}
+
+// MonitorReportResponse represents the state of a URL on a status page
+type MonitorReportResponse struct {
+	URLID   uuid.UUID             `json:"url_id"`
+	Address string                `json:"address"`
+	State   string                `json:"state"`
+	Uptime  *float64              `json:"uptime"`
+	Days    []DailyUptimeResponse `json:"days"`
+}
+
+// ComponentReportResponse represents a component of a rendered status page
+type ComponentReportResponse struct {
+	Name     string                  `json:"name"`
+	Monitors []MonitorReportResponse `json:"monitors"`
+}
+
+// StatusReportResponse represents a rendered status page
+type StatusReportResponse struct {
+	Slug        string                    `json:"slug"`
+	Title       string                    `json:"title"`
+	LogoText    string                    `json:"logo_text"`
+	Components  []ComponentReportResponse `json:"components"`
+	Incidents   []IncidentResponse        `json:"incidents"`
+	GeneratedAt time.Time                 `json:"generated_at"`
+}
+
+// CreateAPIKeyRequest represents the request to create a new API key
+type CreateAPIKeyRequest struct {
+	ProjectID *uuid.UUID `json:"project_id,omitempty"` // project of the calling key when omitted
+	Name      string     `json:"name"`
+	Scopes    []string   `json:"scopes"`               // urls:read, urls:write, checks:read, metrics:read, admin
+	ExpiresAt *time.Time `json:"expires_at,omitempty"` // never expires when omitted
+}
+
+// APIKeyResponse represents an API key in API responses
+type APIKeyResponse struct {
+	ID         uuid.UUID  `json:"id"`
+	ProjectID  uuid.UUID  `json:"project_id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// CreateAPIKeyResponse represents a newly created API key including its plaintext value
+type CreateAPIKeyResponse struct {
+	APIKeyResponse
+	Key string `json:"key"` // shown only once
+}
+
+// ProjectRequest represents the request body for creating or updating a project
+type ProjectRequest struct {
+	Name        string `json:"name"`
+	MaxURLs     int    `json:"max_urls"`               // 0 means unlimited
+	MinInterval string `json:"min_interval,omitempty"` // e.g. "1m", no minimum when omitted
+}
+
+// ProjectResponse represents a project in API responses
+type ProjectResponse struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	MaxURLs     int       `json:"max_urls"`
+	MinInterval string    `json:"min_interval"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// LabelStatsResponse represents aggregated check results of URLs sharing a label value
+type LabelStatsResponse struct {
+	Value       string   `json:"value"`
+	URLs        int      `json:"urls"`
+	Checks      int      `json:"checks"`
+	Uptime      *float64 `json:"uptime"` // share of successful checks, null without data
+	AvgDuration string   `json:"avg_duration"`
+}
+
 // ErrorResponse represents an error in API responses
 type ErrorResponse struct {
 	Error string `json:"error"`
@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"url-sentinel/internal/delivery/http/dto"
+	"url-sentinel/internal/domain/entity"
+	"url-sentinel/internal/domain/repository"
+	"url-sentinel/internal/usecase"
+
+	"github.com/google/uuid"
+)
+
+// AgentHandler handles HTTP requests from remote probe agents
+type AgentHandler struct {
+	urlUseCase   *usecase.URLUseCase
+	checkUseCase *usecase.CheckUseCase
+	logger       *slog.Logger
+}
+
+// NewAgentHandler creates a new agent handler
+func NewAgentHandler(
+	urlUseCase *usecase.URLUseCase,
+	checkUseCase *usecase.CheckUseCase,
+	logger *slog.Logger,
+) *AgentHandler {
+	return &AgentHandler{
+		urlUseCase:   urlUseCase,
+		checkUseCase: checkUseCase,
+		logger:       logger,
+	}
+}
+
+// ListURLs handles GET /agent/urls
+func (h *AgentHandler) ListURLs(w http.ResponseWriter, r *http.Request) {
+	// Agents check the URLs of every project
+	page, err := h.urlUseCase.ListURLs(r.Context(), uuid.Nil, repository.URLFilter{})
+	if err != nil {
+		h.logger.Error("failed to list urls", slog.Any("error", err))
+		h.respondError(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	resp := make([]dto.URLResponse, 0, len(page.URLs))
+	for _, url := range page.URLs {
+		resp = append(resp, newURLResponse(url))
+	}
+
+	h.respondJSON(w, resp, http.StatusOK)
+}
+
+// ReportCheck handles POST /agent/checks
+func (h *AgentHandler) ReportCheck(w http.ResponseWriter, r *http.Request) {
+	var req dto.ReportCheckRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error("failed to decode request", slog.Any("error", err))
+		h.respondError(w, "invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	if req.Location == "" {
+		h.respondError(w, "location is required", http.StatusBadRequest)
+		return
+	}
+
+	duration, err := time.ParseDuration(req.Duration)
+	if err != nil {
+		h.logger.Info("invalid duration format", slog.Any("error", err))
+		h.respondError(w, "invalid duration format", http.StatusBadRequest)
+		return
+	}
+
+	check := entity.NewCheck(req.URLID, req.Location, req.Status, req.Code, duration)
+	switch trigger := entity.Trigger(req.Trigger); trigger {
+	case "": // NewCheck defaults to scheduled
+	case entity.TriggerScheduled, entity.TriggerManual:
+		check.Trigger = trigger
+	default:
+		h.respondError(w, "trigger must be scheduled or manual", http.StatusBadRequest)
+		return
+	}
+	for _, r := range req.Redirects {
+		check.Redirects = append(check.Redirects, entity.Redirect{Code: r.Code, Location: r.Location})
+	}
+	if !req.CheckedAt.IsZero() {
+		check.CheckedAt = req.CheckedAt.UTC()
+	}
+
+	if err := h.checkUseCase.ReportCheck(r.Context(), check, req.ErrorClass); err != nil {
+		if errors.Is(err, repository.ErrURLNotFound) {
+			h.respondError(w, "url not found", http.StatusNotFound)
+			return
+		}
+		h.logger.Error("failed to report check", slog.Any("error", err))
+		h.respondError(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	h.respondJSON(w, newCheckResponse(check), http.StatusCreated)
+}
+
+func (h *AgentHandler) respondJSON(w http.ResponseWriter, data interface{}, status int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		h.logger.Error("failed to encode response", slog.Any("error", err))
+	}
+}
+
+func (h *AgentHandler) respondError(w http.ResponseWriter, message string, status int) {
+	h.respondJSON(w, dto.ErrorResponse{Error: message}, status)
+}
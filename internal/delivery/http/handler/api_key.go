@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"url-sentinel/internal/delivery/http/dto"
+	"url-sentinel/internal/domain/entity"
+	"url-sentinel/internal/domain/repository"
+	"url-sentinel/internal/usecase"
+
+	"github.com/go-chi/chi"
+	"github.com/google/uuid"
+)
+
+// APIKeyHandler handles HTTP requests for API key administration
+type APIKeyHandler struct {
+	authUseCase *usecase.AuthUseCase
+	logger      *slog.Logger
+}
+
+// NewAPIKeyHandler creates a new API key handler
+func NewAPIKeyHandler(authUseCase *usecase.AuthUseCase, logger *slog.Logger) *APIKeyHandler {
+	return &APIKeyHandler{
+		authUseCase: authUseCase,
+		logger:      logger,
+	}
+}
+
+// Create handles POST /admin/api-keys
+func (h *APIKeyHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreateAPIKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error("failed to decode request", slog.Any("error", err))
+		h.respondError(w, "invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	scopes := make([]entity.Scope, 0, len(req.Scopes))
+	for _, scope := range req.Scopes {
+		scopes = append(scopes, entity.Scope(scope))
+	}
+
+	project := projectID(r)
+	if req.ProjectID != nil {
+		project = *req.ProjectID
+	}
+
+	key, plaintext, err := h.authUseCase.CreateAPIKey(r.Context(), project, req.Name, scopes, req.ExpiresAt)
+	if err != nil {
+		if errors.Is(err, repository.ErrProjectNotFound) {
+			h.respondError(w, "project not found", http.StatusBadRequest)
+			return
+		}
+		for _, validationErr := range []error{
+			entity.ErrAPIKeyNameRequired,
+			entity.ErrScopesRequired,
+			entity.ErrInvalidScope,
+			entity.ErrInvalidExpiry,
+		} {
+			if errors.Is(err, validationErr) {
+				h.respondError(w, validationErr.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		h.logger.Error("failed to create api key", slog.Any("error", err))
+		h.respondError(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	h.logger.Info("api key created",
+		slog.String("id", key.ID.String()),
+		slog.String("project_id", key.ProjectID.String()),
+		slog.String("name", key.Name),
+		slog.String("prefix", key.Prefix),
+	)
+
+	resp := dto.CreateAPIKeyResponse{
+		APIKeyResponse: newAPIKeyResponse(key),
+		Key:            plaintext,
+	}
+
+	h.respondJSON(w, resp, http.StatusCreated)
+}
+
+// List handles GET /admin/api-keys
+func (h *APIKeyHandler) List(w http.ResponseWriter, r *http.Request) {
+	keys, err := h.authUseCase.ListAPIKeys(r.Context())
+	if err != nil {
+		h.logger.Error("failed to list api keys", slog.Any("error", err))
+		h.respondError(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	resp := make([]dto.APIKeyResponse, 0, len(keys))
+	for _, key := range keys {
+		resp = append(resp, newAPIKeyResponse(key))
+	}
+
+	h.respondJSON(w, resp, http.StatusOK)
+}
+
+// Delete handles DELETE /admin/api-keys/{id}
+func (h *APIKeyHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	idParam := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		h.logger.Info("invalid api key id", slog.String("id", idParam))
+		h.respondError(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.authUseCase.DeleteAPIKey(r.Context(), id); err != nil {
+		if errors.Is(err, repository.ErrAPIKeyNotFound) {
+			h.respondError(w, "api key not found", http.StatusNotFound)
+			return
+		}
+		h.logger.Error("failed to delete api key", slog.Any("error", err))
+		h.respondError(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func newAPIKeyResponse(key *entity.APIKey) dto.APIKeyResponse {
+	scopes := make([]string, 0, len(key.Scopes))
+	for _, scope := range key.Scopes {
+		scopes = append(scopes, string(scope))
+	}
+
+	return dto.APIKeyResponse{
+		ID:         key.ID,
+		ProjectID:  key.ProjectID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Scopes:     scopes,
+		ExpiresAt:  key.ExpiresAt,
+		LastUsedAt: key.LastUsedAt,
+		CreatedAt:  key.CreatedAt,
+	}
+}
+
+func (h *APIKeyHandler) respondJSON(w http.ResponseWriter, data interface{}, status int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		h.logger.Error("failed to encode response", slog.Any("error", err))
+	}
+}
+
+func (h *APIKeyHandler) respondError(w http.ResponseWriter, message string, status int) {
+	h.respondJSON(w, dto.ErrorResponse{Error: message}, status)
+}
@@ -0,0 +1,226 @@
+package handler
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"url-sentinel/internal/badge"
+	"url-sentinel/internal/domain/entity"
+	"url-sentinel/internal/usecase"
+
+	"github.com/go-chi/chi"
+	"github.com/google/uuid"
+)
+
+const (
+	badgeMaxAge          = 60 * time.Second
+	defaultUptimePeriod  = 30 * 24 * time.Hour
+	defaultLatencyPeriod = 24 * time.Hour
+	maxBadgePeriod       = 365 * 24 * time.Hour
+)
+
+// BadgeHandler handles HTTP requests for public SVG badges
+type BadgeHandler struct {
+	badgeUseCase *usecase.BadgeUseCase
+	logger       *slog.Logger
+}
+
+// NewBadgeHandler creates a new badge handler
+func NewBadgeHandler(badgeUseCase *usecase.BadgeUseCase, logger *slog.Logger) *BadgeHandler {
+	return &BadgeHandler{
+		badgeUseCase: badgeUseCase,
+		logger:       logger,
+	}
+}
+
+// Status handles GET /urls/{id}/badge/status.svg
+func (h *BadgeHandler) Status(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseID(w, r)
+	if !ok {
+		return
+	}
+
+	state, err := h.badgeUseCase.GetState(r.Context(), id, r.URL.Query().Get("token"))
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	color := badge.ColorGrey
+	switch state {
+	case entity.StateUp:
+		color = badge.ColorBrightGreen
+	case entity.StateDown:
+		color = badge.ColorRed
+	}
+
+	h.respondSVG(w, r, badge.Render("status", string(state), color))
+}
+
+// Uptime handles GET /urls/{id}/badge/uptime.svg?period=30d
+func (h *BadgeHandler) Uptime(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseID(w, r)
+	if !ok {
+		return
+	}
+
+	period, err := parsePeriod(r.URL.Query().Get("period"), defaultUptimePeriod)
+	if err != nil {
+		h.respondError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	stats, err := h.badgeUseCase.GetStats(r.Context(), id, r.URL.Query().Get("token"), period)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	uptime := stats.Uptime()
+	message, color := "no data", badge.ColorGrey
+	if uptime >= 0 {
+		message = fmt.Sprintf("%.2f%%", uptime*100)
+		switch {
+		case uptime >= 0.999:
+			color = badge.ColorBrightGreen
+		case uptime >= 0.99:
+			color = badge.ColorGreen
+		case uptime >= 0.95:
+			color = badge.ColorYellow
+		case uptime >= 0.90:
+			color = badge.ColorOrange
+		default:
+			color = badge.ColorRed
+		}
+	}
+
+	h.respondSVG(w, r, badge.Render("uptime "+formatPeriod(period), message, color))
+}
+
+// Latency handles GET /urls/{id}/badge/latency.svg?period=24h
+func (h *BadgeHandler) Latency(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseID(w, r)
+	if !ok {
+		return
+	}
+
+	period, err := parsePeriod(r.URL.Query().Get("period"), defaultLatencyPeriod)
+	if err != nil {
+		h.respondError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	stats, err := h.badgeUseCase.GetStats(r.Context(), id, r.URL.Query().Get("token"), period)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	message, color := "no data", badge.ColorGrey
+	if stats.Total > 0 {
+		message = stats.AvgDuration.Round(time.Millisecond).String()
+		switch {
+		case stats.AvgDuration < 300*time.Millisecond:
+			color = badge.ColorBrightGreen
+		case stats.AvgDuration < time.Second:
+			color = badge.ColorYellow
+		case stats.AvgDuration < 3*time.Second:
+			color = badge.ColorOrange
+		default:
+			color = badge.ColorRed
+		}
+	}
+
+	h.respondSVG(w, r, badge.Render("latency", message, color))
+}
+
+func (h *BadgeHandler) parseID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	idParam := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		h.logger.Info("invalid url id", slog.String("id", idParam))
+		h.respondError(w, "invalid id", http.StatusBadRequest)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func (h *BadgeHandler) handleError(w http.ResponseWriter, err error) {
+	if errors.Is(err, usecase.ErrInvalidPublicToken) {
+		h.respondError(w, "not found", http.StatusNotFound)
+		return
+	}
+	h.logger.Error("failed to render badge", slog.Any("error", err))
+	h.respondError(w, "internal server error", http.StatusInternalServerError)
+}
+
+// respondSVG writes the badge with caching headers, answering 304 when the client copy is current
+func (h *BadgeHandler) respondSVG(w http.ResponseWriter, r *http.Request, svg []byte) {
+	sum := sha256.Sum256(svg)
+	etag := `"` + hex.EncodeToString(sum[:8]) + `"`
+
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(badgeMaxAge.Seconds())))
+	w.Header().Set("ETag", etag)
+
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/svg+xml")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(svg); err != nil {
+		h.logger.Error("failed to write badge", slog.Any("error", err))
+	}
+}
+
+func (h *BadgeHandler) respondError(w http.ResponseWriter, message string, status int) {
+	w.Header().Set("Content-Type", "image/svg+xml")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	if _, err := w.Write(badge.Render("badge", message, badge.ColorGrey)); err != nil {
+		h.logger.Error("failed to write badge", slog.Any("error", err))
+	}
+}
+
+// parsePeriod parses durations like "30d", "7d" or "12h"
+func parsePeriod(value string, fallback time.Duration) (time.Duration, error) {
+	if value == "" {
+		return fallback, nil
+	}
+
+	var period time.Duration
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, errors.New("invalid period")
+		}
+		period = time.Duration(n) * 24 * time.Hour
+	} else {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return 0, errors.New("invalid period")
+		}
+		period = d
+	}
+
+	if period <= 0 || period > maxBadgePeriod {
+		return 0, errors.New("invalid period")
+	}
+
+	return period, nil
+}
+
+func formatPeriod(period time.Duration) string {
+	if period%(24*time.Hour) == 0 {
+		return fmt.Sprintf("%dd", period/(24*time.Hour))
+	}
+	return period.String()
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	"url-sentinel/internal/delivery/http/dto"
+	"url-sentinel/internal/domain/entity"
 	"url-sentinel/internal/usecase"
 
 	"github.com/go-chi/chi"
@@ -36,7 +37,7 @@ func (h *CheckHandler) GetHistory(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	checks, err := h.checkUseCase.GetCheckHistory(r.Context(), id)
+	checks, err := h.checkUseCase.GetCheckHistory(r.Context(), projectID(r), id)
 	if err != nil {
 		h.logger.Error("failed to get check history", slog.Any("error", err))
 		h.respondError(w, "internal server error", http.StatusInternalServerError)
@@ -45,19 +46,107 @@ func (h *CheckHandler) GetHistory(w http.ResponseWriter, r *http.Request) {
 
 	resp := make([]dto.CheckResponse, 0, len(checks))
 	for _, check := range checks {
-		resp = append(resp, dto.CheckResponse{
-			ID:        check.ID,
-			URLID:     check.URLID,
-			Status:    check.Status,
-			Code:      check.Code,
-			Duration:  check.Duration.String(),
-			CheckedAt: check.CheckedAt,
+		resp = append(resp, newCheckResponse(check))
+	}
+
+	h.respondJSON(w, resp, http.StatusOK)
+}
+
+// GetLocations handles GET /urls/{id}/locations
+func (h *CheckHandler) GetLocations(w http.ResponseWriter, r *http.Request) {
+	idParam := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		h.logger.Info("invalid url id", slog.String("id", idParam))
+		h.respondError(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	checks, err := h.checkUseCase.GetLocationStatus(r.Context(), projectID(r), id)
+	if err != nil {
+		h.logger.Error("failed to get location status", slog.Any("error", err))
+		h.respondError(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	resp := make([]dto.CheckResponse, 0, len(checks))
+	for _, check := range checks {
+		resp = append(resp, newCheckResponse(check))
+	}
+
+	h.respondJSON(w, resp, http.StatusOK)
+}
+
+// GetStatsByLabel handles GET /stats/uptime?by=team&label=env=prod&period=30d
+func (h *CheckHandler) GetStatsByLabel(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	key := query.Get("by")
+	if key == "" {
+		h.respondError(w, "label key to group by is required", http.StatusBadRequest)
+		return
+	}
+
+	selector, err := entity.ParseLabelSelector(query.Get("label"))
+	if err != nil {
+		h.respondError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	period, err := parsePeriod(query.Get("period"), defaultUptimePeriod)
+	if err != nil {
+		h.respondError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	groups, err := h.checkUseCase.GetStatsByLabel(r.Context(), projectID(r), key, selector, period)
+	if err != nil {
+		h.logger.Error("failed to get stats by label", slog.Any("error", err))
+		h.respondError(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	resp := make([]dto.LabelStatsResponse, 0, len(groups))
+	for _, group := range groups {
+		resp = append(resp, dto.LabelStatsResponse{
+			Value:       group.Value,
+			URLs:        group.URLs,
+			Checks:      group.Total,
+			Uptime:      optionalRatio(group.Uptime()),
+			AvgDuration: group.AvgDuration.String(),
 		})
 	}
 
 	h.respondJSON(w, resp, http.StatusOK)
 }
 
+func newCheckResponse(check *entity.Check) dto.CheckResponse {
+	return dto.CheckResponse{
+		ID:        check.ID,
+		URLID:     check.URLID,
+		Location:  check.Location,
+		Trigger:   string(check.Trigger),
+		Status:    check.Status,
+		Code:      check.Code,
+		Duration:  check.Duration.String(),
+		Redirects: newRedirects(check.Redirects),
+		CheckedAt: check.CheckedAt,
+	}
+}
+
+func newRedirects(redirects []entity.Redirect) []dto.Redirect {
+	if len(redirects) == 0 {
+		return nil
+	}
+
+	resp := make([]dto.Redirect, 0, len(redirects))
+	for _, r := range redirects {
+		resp = append(resp, dto.Redirect{Code: r.Code, Location: r.Location})
+	}
+
+	return resp
+}
+
 func (h *CheckHandler) respondJSON(w http.ResponseWriter, data interface{}, status int) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"url-sentinel/internal/delivery/http/dto"
+	"url-sentinel/internal/domain/entity"
+	"url-sentinel/internal/domain/repository"
+	"url-sentinel/internal/usecase"
+
+	"github.com/go-chi/chi"
+	"github.com/google/uuid"
+)
+
+// ContentHandler handles HTTP requests for content change operations
+type ContentHandler struct {
+	contentUseCase *usecase.ContentUseCase
+	logger         *slog.Logger
+}
+
+// NewContentHandler creates a new content handler
+func NewContentHandler(contentUseCase *usecase.ContentUseCase, logger *slog.Logger) *ContentHandler {
+	return &ContentHandler{
+		contentUseCase: contentUseCase,
+		logger:         logger,
+	}
+}
+
+// ListChanges handles GET /urls/{id}/content-changes
+func (h *ContentHandler) ListChanges(w http.ResponseWriter, r *http.Request) {
+	idParam := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		h.logger.Info("invalid url id", slog.String("id", idParam))
+		h.respondError(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	changes, err := h.contentUseCase.ListChanges(r.Context(), projectID(r), id)
+	if err != nil {
+		if errors.Is(err, repository.ErrURLNotFound) {
+			h.respondError(w, "url not found", http.StatusNotFound)
+			return
+		}
+		h.logger.Error("failed to list content changes", slog.Any("error", err))
+		h.respondError(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	resp := make([]dto.ContentChangeResponse, 0, len(changes))
+	for _, change := range changes {
+		resp = append(resp, newContentChangeResponse(change))
+	}
+
+	h.respondJSON(w, resp, http.StatusOK)
+}
+
+func newContentChangeResponse(change *entity.ContentChange) dto.ContentChangeResponse {
+	return dto.ContentChangeResponse{
+		ID:           change.ID,
+		URLID:        change.URLID,
+		PreviousHash: change.PreviousHash,
+		Hash:         change.Hash,
+		Diff:         change.Diff,
+		DetectedAt:   change.DetectedAt,
+	}
+}
+
+func newContentWatch(req *dto.ContentWatch) *entity.ContentWatch {
+	if req == nil {
+		return nil
+	}
+	return &entity.ContentWatch{
+		IgnoreSelectors: req.IgnoreSelectors,
+		IgnorePatterns:  req.IgnorePatterns,
+		Alert:           req.Alert,
+	}
+}
+
+func newContentWatchResponse(watch *entity.ContentWatch) *dto.ContentWatch {
+	if watch == nil {
+		return nil
+	}
+	return &dto.ContentWatch{
+		IgnoreSelectors: watch.IgnoreSelectors,
+		IgnorePatterns:  watch.IgnorePatterns,
+		Alert:           watch.Alert,
+	}
+}
+
+func (h *ContentHandler) respondJSON(w http.ResponseWriter, data interface{}, status int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		h.logger.Error("failed to encode response", slog.Any("error", err))
+	}
+}
+
+func (h *ContentHandler) respondError(w http.ResponseWriter, message string, status int) {
+	h.respondJSON(w, dto.ErrorResponse{Error: message}, status)
+}
@@ -0,0 +1,225 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"url-sentinel/internal/delivery/http/dto"
+	"url-sentinel/internal/domain/entity"
+	"url-sentinel/internal/stream"
+
+	"github.com/google/uuid"
+	"golang.org/x/net/websocket"
+)
+
+const (
+	// eventsHeartbeat keeps idle event streams alive through proxies
+	eventsHeartbeat = 15 * time.Second
+
+	// eventsWriteTimeout bounds a single write to a WebSocket client
+	eventsWriteTimeout = 10 * time.Second
+)
+
+// EventsHandler streams live check results and state changes
+type EventsHandler struct {
+	hub    *stream.Hub
+	logger *slog.Logger
+}
+
+// NewEventsHandler creates a new events handler
+func NewEventsHandler(hub *stream.Hub, logger *slog.Logger) *EventsHandler {
+	return &EventsHandler{
+		hub:    hub,
+		logger: logger,
+	}
+}
+
+// Stream handles GET /events?url_id=&label= as Server-Sent Events
+func (h *EventsHandler) Stream(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseEventFilter(r)
+	if err != nil {
+		h.respondError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// The stream outlives the server write timeout
+	rc := http.NewResponseController(w)
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		h.logger.Error("failed to disable write deadline", slog.Any("error", err))
+		h.respondError(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	sub := h.hub.Subscribe(filter)
+	defer sub.Close()
+
+	h.forward(r.Context(), sub, func(resp dto.EventResponse) error {
+		data, err := json.Marshal(resp)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", resp.Type, data); err != nil {
+			return err
+		}
+		return rc.Flush()
+	}, func() error {
+		if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+			return err
+		}
+		return rc.Flush()
+	})
+}
+
+// WebSocket handles GET /events/ws?url_id=&label=, every event is a JSON text message
+func (h *EventsHandler) WebSocket(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseEventFilter(r)
+	if err != nil {
+		h.respondError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// The hijacked connection keeps the deadlines of the server timeouts
+	rc := http.NewResponseController(w)
+	_ = rc.SetReadDeadline(time.Time{})
+	_ = rc.SetWriteDeadline(time.Time{})
+
+	websocket.Server{Handler: func(ws *websocket.Conn) {
+		sub := h.hub.Subscribe(filter)
+		defer sub.Close()
+
+		// Incoming messages are ignored, reading only detects the client going away
+		ctx, cancel := context.WithCancel(r.Context())
+		defer cancel()
+		go func() {
+			defer cancel()
+			var msg string
+			for websocket.Message.Receive(ws, &msg) == nil {
+			}
+		}()
+
+		send := func(v any) error {
+			if err := ws.SetWriteDeadline(time.Now().Add(eventsWriteTimeout)); err != nil {
+				return err
+			}
+			return websocket.JSON.Send(ws, v)
+		}
+
+		h.forward(ctx, sub, func(resp dto.EventResponse) error {
+			return send(resp)
+		}, func() error {
+			return send(dto.EventResponse{Type: "heartbeat", At: time.Now().UTC()})
+		})
+	}}.ServeHTTP(w, r)
+}
+
+// forward writes the events of the subscription until ctx is done or a
+// write fails, reporting events the client missed because it lagged behind
+func (h *EventsHandler) forward(
+	ctx context.Context,
+	sub *stream.Subscription,
+	write func(dto.EventResponse) error,
+	heartbeat func() error,
+) {
+	ticker := time.NewTicker(eventsHeartbeat)
+	defer ticker.Stop()
+
+	var reported int64
+	for {
+		var err error
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err = heartbeat()
+		case e, ok := <-sub.Events():
+			if !ok {
+				return
+			}
+			if dropped := sub.Dropped(); dropped > reported {
+				reported = dropped
+				if err = write(dto.EventResponse{Type: "lagged", Dropped: dropped, At: time.Now().UTC()}); err != nil {
+					break
+				}
+			}
+			err = write(newEventResponse(e))
+		}
+
+		if err != nil {
+			h.logger.Debug("event stream closed", slog.Any("error", err))
+			return
+		}
+	}
+}
+
+// parseEventFilter reads the url_id (repeatable or comma-separated) and label
+// selector query parameters, scoped to the project of the API key
+func parseEventFilter(r *http.Request) (stream.Filter, error) {
+	query := r.URL.Query()
+	filter := stream.Filter{ProjectID: projectID(r)}
+
+	for _, param := range query["url_id"] {
+		for _, raw := range strings.Split(param, ",") {
+			id, err := uuid.Parse(strings.TrimSpace(raw))
+			if err != nil {
+				return filter, fmt.Errorf("invalid url_id %q", raw)
+			}
+			filter.URLIDs = append(filter.URLIDs, id)
+		}
+	}
+
+	selector, err := entity.ParseLabelSelector(query.Get("label"))
+	if err != nil {
+		return filter, err
+	}
+	filter.Labels = selector
+
+	return filter, nil
+}
+
+func newEventResponse(e *stream.Event) dto.EventResponse {
+	resp := dto.EventResponse{
+		Type:    string(e.Type),
+		URLID:   &e.URLID,
+		Address: e.Address,
+		Labels:  e.Labels,
+		At:      e.At,
+	}
+
+	switch e.Type {
+	case stream.EventCheck:
+		check := newCheckResponse(e.Check)
+		resp.Check = &check
+	case stream.EventStateChange:
+		resp.StateChange = &dto.StateChangeResponse{
+			From: string(e.Change.From),
+			To:   string(e.Change.To),
+		}
+	case stream.EventContentChange:
+		change := newContentChangeResponse(e.Content)
+		resp.ContentChange = &change
+	}
+
+	return resp
+}
+
+func (h *EventsHandler) respondError(w http.ResponseWriter, message string, status int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(dto.ErrorResponse{Error: message}); err != nil {
+		h.logger.Error("failed to encode response", slog.Any("error", err))
+	}
+}
@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"url-sentinel/internal/delivery/http/dto"
+	"url-sentinel/internal/domain/entity"
+	"url-sentinel/internal/domain/repository"
+	"url-sentinel/internal/usecase"
+
+	"github.com/go-chi/chi"
+	"github.com/google/uuid"
+)
+
+// IncidentHandler handles HTTP requests for incident operations
+type IncidentHandler struct {
+	incidentUseCase *usecase.IncidentUseCase
+	logger          *slog.Logger
+}
+
+// NewIncidentHandler creates a new incident handler
+func NewIncidentHandler(incidentUseCase *usecase.IncidentUseCase, logger *slog.Logger) *IncidentHandler {
+	return &IncidentHandler{
+		incidentUseCase: incidentUseCase,
+		logger:          logger,
+	}
+}
+
+// List handles GET /urls/{id}/incidents
+func (h *IncidentHandler) List(w http.ResponseWriter, r *http.Request) {
+	idParam := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		h.logger.Info("invalid url id", slog.String("id", idParam))
+		h.respondError(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	incidents, err := h.incidentUseCase.ListIncidents(r.Context(), projectID(r), id)
+	if err != nil {
+		if errors.Is(err, repository.ErrURLNotFound) {
+			h.respondError(w, "url not found", http.StatusNotFound)
+			return
+		}
+		h.logger.Error("failed to list incidents", slog.Any("error", err))
+		h.respondError(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	resp := make([]dto.IncidentResponse, 0, len(incidents))
+	for _, incident := range incidents {
+		resp = append(resp, newIncidentResponse(incident))
+	}
+
+	h.respondJSON(w, resp, http.StatusOK)
+}
+
+func newIncidentResponse(incident *entity.Incident) dto.IncidentResponse {
+	locations := make([]dto.LocationStatusResponse, 0, len(incident.Locations))
+	for _, l := range incident.Locations {
+		locations = append(locations, dto.LocationStatusResponse{
+			Location:  l.Location,
+			Status:    l.Status,
+			Code:      l.Code,
+			CheckedAt: l.CheckedAt,
+		})
+	}
+
+	return dto.IncidentResponse{
+		ID:         incident.ID,
+		URLID:      incident.URLID,
+		Locations:  locations,
+		StartedAt:  incident.StartedAt,
+		ResolvedAt: incident.ResolvedAt,
+	}
+}
+
+func (h *IncidentHandler) respondJSON(w http.ResponseWriter, data interface{}, status int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		h.logger.Error("failed to encode response", slog.Any("error", err))
+	}
+}
+
+func (h *IncidentHandler) respondError(w http.ResponseWriter, message string, status int) {
+	h.respondJSON(w, dto.ErrorResponse{Error: message}, status)
+}
@@ -0,0 +1,201 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"url-sentinel/internal/delivery/http/dto"
+	mw "url-sentinel/internal/delivery/http/middleware"
+	"url-sentinel/internal/domain/entity"
+	"url-sentinel/internal/domain/repository"
+	"url-sentinel/internal/usecase"
+
+	"github.com/go-chi/chi"
+	"github.com/google/uuid"
+)
+
+// ProjectHandler handles HTTP requests for project administration
+type ProjectHandler struct {
+	projectUseCase *usecase.ProjectUseCase
+	logger         *slog.Logger
+}
+
+// NewProjectHandler creates a new project handler
+func NewProjectHandler(projectUseCase *usecase.ProjectUseCase, logger *slog.Logger) *ProjectHandler {
+	return &ProjectHandler{
+		projectUseCase: projectUseCase,
+		logger:         logger,
+	}
+}
+
+// Create handles POST /admin/projects
+func (h *ProjectHandler) Create(w http.ResponseWriter, r *http.Request) {
+	req, minInterval, ok := h.decodeRequest(w, r)
+	if !ok {
+		return
+	}
+
+	project, err := h.projectUseCase.CreateProject(r.Context(), req.Name, req.MaxURLs, minInterval)
+	if err != nil {
+		h.handleError(w, "failed to create project", err)
+		return
+	}
+
+	h.logger.Info("project created",
+		slog.String("id", project.ID.String()),
+		slog.String("name", project.Name),
+	)
+
+	h.respondJSON(w, newProjectResponse(project), http.StatusCreated)
+}
+
+// Get handles GET /admin/projects/{id}
+func (h *ProjectHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseID(w, r)
+	if !ok {
+		return
+	}
+
+	project, err := h.projectUseCase.GetProject(r.Context(), id)
+	if err != nil {
+		h.handleError(w, "failed to get project", err)
+		return
+	}
+
+	h.respondJSON(w, newProjectResponse(project), http.StatusOK)
+}
+
+// List handles GET /admin/projects
+func (h *ProjectHandler) List(w http.ResponseWriter, r *http.Request) {
+	projects, err := h.projectUseCase.ListProjects(r.Context())
+	if err != nil {
+		h.handleError(w, "failed to list projects", err)
+		return
+	}
+
+	resp := make([]dto.ProjectResponse, 0, len(projects))
+	for _, project := range projects {
+		resp = append(resp, newProjectResponse(project))
+	}
+
+	h.respondJSON(w, resp, http.StatusOK)
+}
+
+// Update handles PUT /admin/projects/{id}
+func (h *ProjectHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseID(w, r)
+	if !ok {
+		return
+	}
+
+	req, minInterval, ok := h.decodeRequest(w, r)
+	if !ok {
+		return
+	}
+
+	project, err := h.projectUseCase.UpdateProject(r.Context(), id, req.Name, req.MaxURLs, minInterval)
+	if err != nil {
+		h.handleError(w, "failed to update project", err)
+		return
+	}
+
+	h.respondJSON(w, newProjectResponse(project), http.StatusOK)
+}
+
+// Delete handles DELETE /admin/projects/{id}
+func (h *ProjectHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.projectUseCase.DeleteProject(r.Context(), id); err != nil {
+		h.handleError(w, "failed to delete project", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *ProjectHandler) decodeRequest(w http.ResponseWriter, r *http.Request) (dto.ProjectRequest, time.Duration, bool) {
+	var req dto.ProjectRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error("failed to decode request", slog.Any("error", err))
+		h.respondError(w, "invalid request payload", http.StatusBadRequest)
+		return req, 0, false
+	}
+
+	var minInterval time.Duration
+	if req.MinInterval != "" {
+		var err error
+		minInterval, err = time.ParseDuration(req.MinInterval)
+		if err != nil {
+			h.logger.Info("invalid min_interval format", slog.Any("error", err))
+			h.respondError(w, "invalid min_interval format", http.StatusBadRequest)
+			return req, 0, false
+		}
+	}
+
+	return req, minInterval, true
+}
+
+func (h *ProjectHandler) parseID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	idParam := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		h.logger.Info("invalid project id", slog.String("id", idParam))
+		h.respondError(w, "invalid id", http.StatusBadRequest)
+		return uuid.Nil, false
+	}
+
+	return id, true
+}
+
+func (h *ProjectHandler) handleError(w http.ResponseWriter, message string, err error) {
+	switch {
+	case errors.Is(err, repository.ErrProjectNotFound):
+		h.respondError(w, "project not found", http.StatusNotFound)
+	case errors.Is(err, usecase.ErrDefaultProjectProtected):
+		h.respondError(w, usecase.ErrDefaultProjectProtected.Error(), http.StatusConflict)
+	case errors.Is(err, entity.ErrProjectNameRequired):
+		h.respondError(w, entity.ErrProjectNameRequired.Error(), http.StatusBadRequest)
+	case errors.Is(err, entity.ErrInvalidQuota):
+		h.respondError(w, entity.ErrInvalidQuota.Error(), http.StatusBadRequest)
+	default:
+		h.logger.Error(message, slog.Any("error", err))
+		h.respondError(w, "internal server error", http.StatusInternalServerError)
+	}
+}
+
+func newProjectResponse(project *entity.Project) dto.ProjectResponse {
+	return dto.ProjectResponse{
+		ID:          project.ID,
+		Name:        project.Name,
+		MaxURLs:     project.MaxURLs,
+		MinInterval: project.MinInterval.String(),
+		CreatedAt:   project.CreatedAt,
+	}
+}
+
+// projectID returns the project of the authenticated API key
+func projectID(r *http.Request) uuid.UUID {
+	if key := mw.APIKeyFromContext(r.Context()); key != nil {
+		return key.ProjectID
+	}
+	return entity.DefaultProjectID
+}
+
+func (h *ProjectHandler) respondJSON(w http.ResponseWriter, data interface{}, status int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		h.logger.Error("failed to encode response", slog.Any("error", err))
+	}
+}
+
+func (h *ProjectHandler) respondError(w http.ResponseWriter, message string, status int) {
+	h.respondJSON(w, dto.ErrorResponse{Error: message}, status)
+}
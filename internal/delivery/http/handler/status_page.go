@@ -0,0 +1,299 @@
+package handler
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"log/slog"
+	"net/http"
+
+	"url-sentinel/internal/delivery/http/dto"
+	"url-sentinel/internal/domain/entity"
+	"url-sentinel/internal/domain/repository"
+	"url-sentinel/internal/usecase"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/google/uuid"
+)
+
+//go:embed templates/status_page.html
+var templatesFS embed.FS
+
+var statusPageTemplate = template.Must(
+	template.New("status_page.html").Funcs(template.FuncMap{
+		"barClass": uptimeBarClass,
+		"percent":  formatUptime,
+	}).ParseFS(templatesFS, "templates/status_page.html"),
+)
+
+// StatusPageHandler handles HTTP requests for status page operations
+type StatusPageHandler struct {
+	statusPageUseCase *usecase.StatusPageUseCase
+	logger            *slog.Logger
+}
+
+// NewStatusPageHandler creates a new status page handler
+func NewStatusPageHandler(statusPageUseCase *usecase.StatusPageUseCase, logger *slog.Logger) *StatusPageHandler {
+	return &StatusPageHandler{
+		statusPageUseCase: statusPageUseCase,
+		logger:            logger,
+	}
+}
+
+// Create handles POST /status-pages
+func (h *StatusPageHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req dto.StatusPageRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error("failed to decode request", slog.Any("error", err))
+		h.respondError(w, "invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	page, err := h.statusPageUseCase.CreateStatusPage(r.Context(), projectID(r), req.Slug, req.Title, req.LogoText, toStatusComponents(req.Components))
+	if err != nil {
+		h.handleError(w, "failed to create status page", err)
+		return
+	}
+
+	h.respondJSON(w, newStatusPageResponse(page), http.StatusCreated)
+}
+
+// Get handles GET /status-pages/{id}
+func (h *StatusPageHandler) Get(w http.ResponseWriter, r *http.Request) {
+	idParam := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		h.logger.Info("invalid status page id", slog.String("id", idParam))
+		h.respondError(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	page, err := h.statusPageUseCase.GetStatusPage(r.Context(), projectID(r), id)
+	if err != nil {
+		h.handleError(w, "failed to get status page", err)
+		return
+	}
+
+	h.respondJSON(w, newStatusPageResponse(page), http.StatusOK)
+}
+
+// List handles GET /status-pages
+func (h *StatusPageHandler) List(w http.ResponseWriter, r *http.Request) {
+	pages, err := h.statusPageUseCase.ListStatusPages(r.Context(), projectID(r))
+	if err != nil {
+		h.logger.Error("failed to list status pages", slog.Any("error", err))
+		h.respondError(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	resp := make([]dto.StatusPageResponse, 0, len(pages))
+	for _, page := range pages {
+		resp = append(resp, newStatusPageResponse(page))
+	}
+
+	h.respondJSON(w, resp, http.StatusOK)
+}
+
+// Update handles PUT /status-pages/{id}
+func (h *StatusPageHandler) Update(w http.ResponseWriter, r *http.Request) {
+	idParam := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		h.logger.Info("invalid status page id", slog.String("id", idParam))
+		h.respondError(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	var req dto.StatusPageRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error("failed to decode request", slog.Any("error", err))
+		h.respondError(w, "invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	page, err := h.statusPageUseCase.UpdateStatusPage(r.Context(), projectID(r), id, req.Slug, req.Title, req.LogoText, toStatusComponents(req.Components))
+	if err != nil {
+		h.handleError(w, "failed to update status page", err)
+		return
+	}
+
+	h.respondJSON(w, newStatusPageResponse(page), http.StatusOK)
+}
+
+// Delete handles DELETE /status-pages/{id}
+func (h *StatusPageHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	idParam := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		h.logger.Info("invalid status page id", slog.String("id", idParam))
+		h.respondError(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.statusPageUseCase.DeleteStatusPage(r.Context(), projectID(r), id); err != nil {
+		h.handleError(w, "failed to delete status page", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Render handles GET /status/{slug} as HTML and GET /status/{slug}.json as JSON
+func (h *StatusPageHandler) Render(w http.ResponseWriter, r *http.Request) {
+	report, err := h.statusPageUseCase.BuildReport(r.Context(), chi.URLParam(r, "slug"))
+	if err != nil {
+		h.handleError(w, "failed to build status page report", err)
+		return
+	}
+
+	format, _ := r.Context().Value(middleware.URLFormatCtxKey).(string)
+	if format == "json" {
+		h.respondJSON(w, newStatusReportResponse(report), http.StatusOK)
+		return
+	}
+
+	addresses := make(map[uuid.UUID]string)
+	for _, component := range report.Components {
+		for _, monitor := range component.Monitors {
+			addresses[monitor.URL.ID] = monitor.URL.Address
+		}
+	}
+
+	view := struct {
+		*usecase.StatusPageReport
+		Addresses map[uuid.UUID]string
+	}{report, addresses}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := statusPageTemplate.Execute(w, view); err != nil {
+		h.logger.Error("failed to render status page", slog.Any("error", err))
+	}
+}
+
+func (h *StatusPageHandler) handleError(w http.ResponseWriter, message string, err error) {
+	switch {
+	case errors.Is(err, repository.ErrStatusPageNotFound):
+		h.respondError(w, "status page not found", http.StatusNotFound)
+	case errors.Is(err, repository.ErrURLNotFound):
+		h.respondError(w, "status page references an unknown url", http.StatusBadRequest)
+	case errors.Is(err, repository.ErrStatusPageSlugExists):
+		h.respondError(w, "status page slug already exists", http.StatusConflict)
+	case errors.Is(err, entity.ErrInvalidSlug):
+		h.respondError(w, entity.ErrInvalidSlug.Error(), http.StatusBadRequest)
+	case errors.Is(err, entity.ErrStatusPageTitleMissing):
+		h.respondError(w, entity.ErrStatusPageTitleMissing.Error(), http.StatusBadRequest)
+	default:
+		h.logger.Error(message, slog.Any("error", err))
+		h.respondError(w, "internal server error", http.StatusInternalServerError)
+	}
+}
+
+func toStatusComponents(components []dto.StatusComponent) []entity.StatusComponent {
+	result := make([]entity.StatusComponent, 0, len(components))
+	for _, c := range components {
+		result = append(result, entity.StatusComponent{Name: c.Name, URLIDs: c.URLIDs})
+	}
+	return result
+}
+
+func newStatusPageResponse(page *entity.StatusPage) dto.StatusPageResponse {
+	components := make([]dto.StatusComponent, 0, len(page.Components))
+	for _, c := range page.Components {
+		components = append(components, dto.StatusComponent{Name: c.Name, URLIDs: c.URLIDs})
+	}
+
+	return dto.StatusPageResponse{
+		ID:         page.ID,
+		Slug:       page.Slug,
+		Title:      page.Title,
+		LogoText:   page.LogoText,
+		Components: components,
+		CreatedAt:  page.CreatedAt,
+	}
+}
+
+func newStatusReportResponse(report *usecase.StatusPageReport) dto.StatusReportResponse {
+	components := make([]dto.ComponentReportResponse, 0, len(report.Components))
+	for _, component := range report.Components {
+		monitors := make([]dto.MonitorReportResponse, 0, len(component.Monitors))
+		for _, monitor := range component.Monitors {
+			days := make([]dto.DailyUptimeResponse, 0, len(monitor.Days))
+			for _, day := range monitor.Days {
+				days = append(days, dto.DailyUptimeResponse{
+					Date:   day.Day.Format("2006-01-02"),
+					Uptime: optionalRatio(day.Ratio()),
+				})
+			}
+
+			monitors = append(monitors, dto.MonitorReportResponse{
+				URLID:   monitor.URL.ID,
+				Address: monitor.URL.Address,
+				State:   string(monitor.State),
+				Uptime:  optionalRatio(monitor.Uptime),
+				Days:    days,
+			})
+		}
+
+		components = append(components, dto.ComponentReportResponse{
+			Name:     component.Name,
+			Monitors: monitors,
+		})
+	}
+
+	incidents := make([]dto.IncidentResponse, 0, len(report.Incidents))
+	for _, incident := range report.Incidents {
+		incidents = append(incidents, newIncidentResponse(incident))
+	}
+
+	return dto.StatusReportResponse{
+		Slug:        report.Page.Slug,
+		Title:       report.Page.Title,
+		LogoText:    report.Page.LogoText,
+		Components:  components,
+		Incidents:   incidents,
+		GeneratedAt: report.GeneratedAt,
+	}
+}
+
+// optionalRatio converts the -1 "no data" marker to nil
+func optionalRatio(ratio float64) *float64 {
+	if ratio < 0 {
+		return nil
+	}
+	return &ratio
+}
+
+func uptimeBarClass(ratio float64) string {
+	switch {
+	case ratio < 0:
+		return "none"
+	case ratio >= 0.99:
+		return "good"
+	case ratio >= 0.95:
+		return "degraded"
+	default:
+		return "bad"
+	}
+}
+
+func formatUptime(ratio float64) string {
+	if ratio < 0 {
+		return "no data"
+	}
+	return fmt.Sprintf("%.2f%%", ratio*100)
+}
+
+func (h *StatusPageHandler) respondJSON(w http.ResponseWriter, data interface{}, status int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		h.logger.Error("failed to encode response", slog.Any("error", err))
+	}
+}
+
+func (h *StatusPageHandler) respondError(w http.ResponseWriter, message string, status int) {
+	h.respondJSON(w, dto.ErrorResponse{Error: message}, status)
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"url-sentinel/internal/delivery/http/dto"
+	"url-sentinel/internal/domain/entity"
 	"url-sentinel/internal/domain/repository"
 	"url-sentinel/internal/usecase"
 
@@ -46,26 +47,64 @@ func (h *URLHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	redirects, err := entity.ParseRedirectPolicy(req.FollowRedirects)
+	if err != nil {
+		h.respondError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	// Create URL
-	url, err := h.urlUseCase.CreateURL(r.Context(), req.Address, interval)
+	url, err := h.urlUseCase.CreateURL(r.Context(), projectID(r), entity.URLDefinition{
+		Address:              req.Address,
+		CheckInterval:        interval,
+		Quorum:               req.Quorum,
+		Labels:               req.Labels,
+		ContentWatch:         newContentWatch(req.ContentWatch),
+		Redirects:            redirects,
+		ExpectFinalURL:       req.ExpectFinalURL,
+		ExpectRedirectDomain: req.ExpectRedirectDomain,
+	})
 	if err != nil {
 		if errors.Is(err, repository.ErrURLAddressExists) {
 			h.logger.Info("url already exists", slog.String("address", req.Address))
 			h.respondError(w, "url already exists", http.StatusConflict)
 			return
 		}
+		for _, quotaErr := range []error{
+			entity.ErrURLQuotaExceeded,
+			entity.ErrIntervalBelowMinimum,
+		} {
+			if errors.Is(err, quotaErr) {
+				h.logger.Info("project quota violated", slog.Any("error", err))
+				h.respondError(w, quotaErr.Error(), http.StatusUnprocessableEntity)
+				return
+			}
+		}
+		for _, validationErr := range []error{
+			entity.ErrInvalidURLFormat,
+			entity.ErrInvalidCheckInterval,
+			entity.ErrInvalidQuorum,
+			entity.ErrInvalidLabel,
+			entity.ErrTooManyLabels,
+			entity.ErrReservedLabel,
+			entity.ErrInvalidIgnoreSelector,
+			entity.ErrInvalidIgnorePattern,
+			entity.ErrInvalidFinalURL,
+			entity.ErrInvalidRedirectDomain,
+		} {
+			if errors.Is(err, validationErr) {
+				h.logger.Info("invalid url", slog.Any("error", err))
+				h.respondError(w, validationErr.Error(), http.StatusBadRequest)
+				return
+			}
+		}
 		h.logger.Error("failed to create url", slog.Any("error", err))
 		h.respondError(w, "internal server error", http.StatusInternalServerError)
 		return
 	}
 
 	// Prepare response
-	resp := dto.URLResponse{
-		ID:            url.ID,
-		Address:       url.Address,
-		CheckInterval: url.CheckInterval.String(),
-		CreatedAt:     url.CreatedAt,
-	}
+	resp := newURLResponse(url)
 
 	h.respondJSON(w, resp, http.StatusCreated)
 }
@@ -80,7 +119,7 @@ func (h *URLHandler) Get(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	url, err := h.urlUseCase.GetURLByID(r.Context(), id)
+	url, err := h.urlUseCase.GetURLByID(r.Context(), projectID(r), id)
 	if err != nil {
 		if errors.Is(err, repository.ErrURLNotFound) {
 			h.respondError(w, "url not found", http.StatusNotFound)
@@ -91,38 +130,232 @@ func (h *URLHandler) Get(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resp := dto.URLResponse{
-		ID:            url.ID,
-		Address:       url.Address,
-		CheckInterval: url.CheckInterval.String(),
-		CreatedAt:     url.CreatedAt,
-	}
+	resp := newURLResponse(url)
 
 	h.respondJSON(w, resp, http.StatusOK)
 }
 
-// List handles GET /urls
+// List handles GET /urls?q=&state=&interval=&label=&sort=&order=&limit=&after=.
+// The cursor of the next page is returned in the X-Next-Cursor header.
 func (h *URLHandler) List(w http.ResponseWriter, r *http.Request) {
-	urls, err := h.urlUseCase.ListURLs(r.Context())
+	filter, err := parseURLFilter(r.URL.Query())
 	if err != nil {
+		h.respondError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	page, err := h.urlUseCase.ListURLs(r.Context(), projectID(r), filter)
+	if err != nil {
+		if errors.Is(err, repository.ErrInvalidCursor) {
+			h.respondError(w, repository.ErrInvalidCursor.Error(), http.StatusBadRequest)
+			return
+		}
 		h.logger.Error("failed to list urls", slog.Any("error", err))
 		h.respondError(w, "internal server error", http.StatusInternalServerError)
 		return
 	}
 
-	resp := make([]dto.URLResponse, 0, len(urls))
-	for _, url := range urls {
-		resp = append(resp, dto.URLResponse{
-			ID:            url.ID,
-			Address:       url.Address,
-			CheckInterval: url.CheckInterval.String(),
-			CreatedAt:     url.CreatedAt,
-		})
+	if page.Next != nil {
+		w.Header().Set("X-Next-Cursor", encodeCursor(page.Next))
+	}
+
+	resp := make([]dto.URLResponse, 0, len(page.URLs))
+	for _, url := range page.URLs {
+		resp = append(resp, newURLResponse(url))
 	}
 
 	h.respondJSON(w, resp, http.StatusOK)
 }
 
+// CheckNow handles POST /urls/{id}/check
+func (h *URLHandler) CheckNow(w http.ResponseWriter, r *http.Request) {
+	idParam := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		h.logger.Info("invalid url id", slog.String("id", idParam))
+		h.respondError(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	check, err := h.urlUseCase.CheckURLNow(r.Context(), projectID(r), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrURLNotFound):
+			h.respondError(w, "url not found", http.StatusNotFound)
+		case errors.Is(err, usecase.ErrMonitorUnavailable):
+			h.respondError(w, usecase.ErrMonitorUnavailable.Error(), http.StatusServiceUnavailable)
+		default:
+			h.logger.Error("failed to check url", slog.Any("error", err))
+			h.respondError(w, "internal server error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	h.respondJSON(w, newCheckResponse(check), http.StatusCreated)
+}
+
+// Test handles POST /check/test
+func (h *URLHandler) Test(w http.ResponseWriter, r *http.Request) {
+	var req dto.TestCheckRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error("failed to decode request", slog.Any("error", err))
+		h.respondError(w, "invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	interval, err := time.ParseDuration(req.CheckInterval)
+	if err != nil {
+		h.logger.Info("invalid check_interval format", slog.Any("error", err))
+		h.respondError(w, "invalid check_interval format", http.StatusBadRequest)
+		return
+	}
+
+	redirects, err := entity.ParseRedirectPolicy(req.FollowRedirects)
+	if err != nil {
+		h.respondError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	request := entity.CheckRequest{
+		Method:  req.Method,
+		Headers: req.Headers,
+		Body:    req.Body,
+	}
+	assertions := make([]entity.Assertion, 0, len(req.Assertions))
+	for _, a := range req.Assertions {
+		assertions = append(assertions, entity.Assertion{
+			Type:     entity.AssertionType(a.Type),
+			Property: a.Property,
+			Value:    a.Value,
+		})
+	}
+
+	def := entity.URLDefinition{
+		Address:              req.Address,
+		CheckInterval:        interval,
+		Quorum:               req.Quorum,
+		Labels:               req.Labels,
+		Redirects:            redirects,
+		ExpectFinalURL:       req.ExpectFinalURL,
+		ExpectRedirectDomain: req.ExpectRedirectDomain,
+	}
+
+	report, err := h.urlUseCase.TestURL(r.Context(), projectID(r), def, request, assertions)
+	if err != nil {
+		if errors.Is(err, usecase.ErrMonitorUnavailable) {
+			h.respondError(w, usecase.ErrMonitorUnavailable.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		for _, validationErr := range []error{
+			entity.ErrInvalidURLFormat,
+			entity.ErrInvalidCheckInterval,
+			entity.ErrInvalidQuorum,
+			entity.ErrInvalidLabel,
+			entity.ErrTooManyLabels,
+			entity.ErrInvalidFinalURL,
+			entity.ErrInvalidRedirectDomain,
+			entity.ErrInvalidCheckMethod,
+			entity.ErrInvalidAssertion,
+		} {
+			if errors.Is(err, validationErr) {
+				h.logger.Info("invalid dry-run check", slog.Any("error", err))
+				h.respondError(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		h.logger.Error("failed to test url", slog.Any("error", err))
+		h.respondError(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	h.respondJSON(w, newTestCheckResponse(report), http.StatusOK)
+}
+
+// Update handles PATCH /urls/{id}
+func (h *URLHandler) Update(w http.ResponseWriter, r *http.Request) {
+	idParam := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		h.logger.Info("invalid url id", slog.String("id", idParam))
+		h.respondError(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	var req dto.UpdateURLRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error("failed to decode request", slog.Any("error", err))
+		h.respondError(w, "invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	patch := usecase.URLPatch{
+		Quorum: req.Quorum,
+		Labels: req.Labels,
+		Paused: req.Paused,
+	}
+	if req.CheckInterval != nil {
+		interval, err := time.ParseDuration(*req.CheckInterval)
+		if err != nil {
+			h.logger.Info("invalid check_interval format", slog.Any("error", err))
+			h.respondError(w, "invalid check_interval format", http.StatusBadRequest)
+			return
+		}
+		patch.CheckInterval = &interval
+	}
+	if req.FollowRedirects != nil {
+		redirects, err := entity.ParseRedirectPolicy(*req.FollowRedirects)
+		if err != nil {
+			h.respondError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		patch.Redirects = &redirects
+	}
+	patch.ExpectFinalURL, patch.ExpectRedirectDomain = req.ExpectFinalURL, req.ExpectRedirectDomain
+	if req.ContentWatch != nil {
+		var watch *dto.ContentWatch
+		if err := json.Unmarshal(req.ContentWatch, &watch); err != nil {
+			h.respondError(w, "invalid content_watch", http.StatusBadRequest)
+			return
+		}
+		patch.SetWatch, patch.ContentWatch = true, newContentWatch(watch)
+	}
+
+	url, err := h.urlUseCase.UpdateURL(r.Context(), projectID(r), id, patch)
+	if err != nil {
+		if errors.Is(err, repository.ErrURLNotFound) {
+			h.respondError(w, "url not found", http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, entity.ErrIntervalBelowMinimum) {
+			h.logger.Info("project quota violated", slog.Any("error", err))
+			h.respondError(w, entity.ErrIntervalBelowMinimum.Error(), http.StatusUnprocessableEntity)
+			return
+		}
+		for _, validationErr := range []error{
+			entity.ErrInvalidCheckInterval,
+			entity.ErrInvalidQuorum,
+			entity.ErrInvalidLabel,
+			entity.ErrTooManyLabels,
+			entity.ErrReservedLabel,
+			entity.ErrInvalidIgnoreSelector,
+			entity.ErrInvalidIgnorePattern,
+			entity.ErrInvalidFinalURL,
+			entity.ErrInvalidRedirectDomain,
+		} {
+			if errors.Is(err, validationErr) {
+				h.logger.Info("invalid url", slog.Any("error", err))
+				h.respondError(w, validationErr.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		h.logger.Error("failed to update url", slog.Any("error", err))
+		h.respondError(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	h.respondJSON(w, newURLResponse(url), http.StatusOK)
+}
+
 // Delete handles DELETE /urls/{id}
 func (h *URLHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	idParam := chi.URLParam(r, "id")
@@ -133,7 +366,7 @@ func (h *URLHandler) Delete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.urlUseCase.DeleteURL(r.Context(), id); err != nil {
+	if err := h.urlUseCase.DeleteURL(r.Context(), projectID(r), id); err != nil {
 		if errors.Is(err, repository.ErrURLNotFound) {
 			h.respondError(w, "url not found", http.StatusNotFound)
 			return
@@ -146,6 +379,53 @@ func (h *URLHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+func newURLResponse(url *entity.URL) dto.URLResponse {
+	return dto.URLResponse{
+		ID:                   url.ID,
+		ProjectID:            url.ProjectID,
+		Address:              url.Address,
+		CheckInterval:        url.CheckInterval.String(),
+		Quorum:               url.Quorum,
+		PublicToken:          url.PublicToken,
+		Labels:               url.Labels,
+		ContentWatch:         newContentWatchResponse(url.ContentWatch),
+		FollowRedirects:      url.Redirects.String(),
+		ExpectFinalURL:       url.ExpectFinalURL,
+		ExpectRedirectDomain: url.ExpectRedirectDomain,
+		Paused:               url.Paused,
+		Status:               newURLStatusResponse(url),
+		CreatedAt:            url.CreatedAt,
+	}
+}
+
+// newURLStatusResponse reports paused URLs as paused, with the result of their last check
+func newURLStatusResponse(url *entity.URL) dto.URLStatusResponse {
+	status := url.Status
+	if status == nil {
+		state := entity.StateUnknown
+		if url.Paused {
+			state = entity.StatePaused
+		}
+		return dto.URLStatusResponse{State: string(state)}
+	}
+
+	state, duration := status.State, status.LastDuration.String()
+	if url.Paused {
+		state = entity.StatePaused
+	}
+
+	return dto.URLStatusResponse{
+		State:               string(state),
+		LastStatus:          &status.LastStatus,
+		LastCode:            &status.LastCode,
+		LastDuration:        &duration,
+		LastCheckedAt:       &status.LastCheckedAt,
+		StateSince:          &status.StateSince,
+		ConsecutiveFailures: status.ConsecutiveFailures,
+		Uptime:              &status.Uptime,
+	}
+}
+
 func (h *URLHandler) respondJSON(w http.ResponseWriter, data interface{}, status int) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
@@ -157,3 +437,38 @@ func (h *URLHandler) respondJSON(w http.ResponseWriter, data interface{}, status
 func (h *URLHandler) respondError(w http.ResponseWriter, message string, status int) {
 	h.respondJSON(w, dto.ErrorResponse{Error: message}, status)
 }
+
+func newTestCheckResponse(report *entity.CheckReport) dto.TestCheckResponse {
+	resp := dto.TestCheckResponse{
+		Check:      newCheckResponse(report.Check),
+		Passed:     report.Passed,
+		ErrorClass: report.ErrorClass,
+		Error:      report.Error,
+		Timings: dto.TimingsResponse{
+			DNS:       report.Timings.DNS.String(),
+			Connect:   report.Timings.Connect.String(),
+			TLS:       report.Timings.TLS.String(),
+			FirstByte: report.Timings.FirstByte.String(),
+			Total:     report.Timings.Total.String(),
+		},
+		Headers:       report.Headers,
+		FinalURL:      report.FinalURL,
+		Body:          report.Body,
+		BodyTruncated: report.BodyTruncated,
+		Assertions:    make([]dto.AssertionResultResponse, 0, len(report.Assertions)),
+	}
+
+	for _, a := range report.Assertions {
+		resp.Assertions = append(resp.Assertions, dto.AssertionResultResponse{
+			AssertionRequest: dto.AssertionRequest{
+				Type:     string(a.Assertion.Type),
+				Property: a.Assertion.Property,
+				Value:    a.Assertion.Value,
+			},
+			Passed: a.Passed,
+			Actual: a.Actual,
+		})
+	}
+
+	return resp
+}
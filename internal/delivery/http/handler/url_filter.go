@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strconv"
+	"time"
+
+	"url-sentinel/internal/domain/entity"
+	"url-sentinel/internal/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// defaultPageSize is the number of URLs returned in a page when no limit is given
+	defaultPageSize = 100
+	// maxPageSize limits the number of URLs returned in a single page
+	maxPageSize = 1000
+)
+
+// cursor is the JSON representation of an opaque pagination cursor
+type cursor struct {
+	Value string    `json:"v"`
+	ID    uuid.UUID `json:"id"`
+}
+
+// parseURLFilter builds a URL filter from the query parameters of GET /urls
+func parseURLFilter(query url.Values) (repository.URLFilter, error) {
+	var filter repository.URLFilter
+
+	selector, err := entity.ParseLabelSelector(query.Get("label"))
+	if err != nil {
+		return filter, err
+	}
+	filter.Labels = selector
+	filter.Search = query.Get("q")
+
+	switch state := entity.State(query.Get("state")); state {
+	case "", entity.StateUp, entity.StateDown, entity.StateUnknown, entity.StatePaused:
+		filter.State = state
+	default:
+		return filter, errors.New("state must be one of up, down, unknown, paused")
+	}
+
+	if value := query.Get("interval"); value != "" {
+		interval, err := time.ParseDuration(value)
+		if err != nil || interval <= 0 {
+			return filter, errors.New("invalid interval")
+		}
+		filter.Interval = interval
+	}
+
+	switch sort := repository.URLSort(query.Get("sort")); sort {
+	case "", repository.URLSortCreatedAt, repository.URLSortAddress, repository.URLSortLastChecked, repository.URLSortUptime:
+		filter.Sort = sort
+	default:
+		return filter, errors.New("sort must be one of created_at, address, last_checked, uptime")
+	}
+
+	switch query.Get("order") {
+	case "", "asc":
+	case "desc":
+		filter.Desc = true
+	default:
+		return filter, errors.New("order must be asc or desc")
+	}
+
+	filter.Limit = defaultPageSize
+	if value := query.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > maxPageSize {
+			return filter, errors.New("limit must be between 1 and " + strconv.Itoa(maxPageSize))
+		}
+		filter.Limit = limit
+	}
+
+	if value := query.Get("after"); value != "" {
+		after, err := decodeCursor(value)
+		if err != nil {
+			return filter, repository.ErrInvalidCursor
+		}
+		filter.After = after
+	}
+
+	return filter, nil
+}
+
+func encodeCursor(c *repository.URLCursor) string {
+	data, _ := json.Marshal(cursor{Value: c.Value, ID: c.ID})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(value string) (*repository.URLCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var c cursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, err
+	}
+
+	return &repository.URLCursor{Value: c.Value, ID: c.ID}, nil
+}
@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"url-sentinel/internal/delivery/http/dto"
+	"url-sentinel/internal/urlfile"
+	"url-sentinel/internal/usecase"
+)
+
+// maxImportSize limits the size of an uploaded import file
+const maxImportSize = 10 << 20
+
+// Import handles POST /urls/import?format=json|yaml|csv&on_conflict=skip|update&dry_run=true
+func (h *URLHandler) Import(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	format, err := importFormat(r)
+	if err != nil {
+		h.respondError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	mode := usecase.ImportSkip
+	if v := query.Get("on_conflict"); v != "" {
+		mode = usecase.ImportMode(v)
+	}
+
+	dryRun := false
+	if v := query.Get("dry_run"); v != "" {
+		if dryRun, err = strconv.ParseBool(v); err != nil {
+			h.respondError(w, "invalid dry_run", http.StatusBadRequest)
+			return
+		}
+	}
+
+	entries, err := urlfile.Decode(http.MaxBytesReader(w, r.Body, maxImportSize), format)
+	if err != nil {
+		h.logger.Info("invalid import file", slog.Any("error", err))
+		h.respondError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	rows := make([]usecase.ImportRow, 0, len(entries))
+	for _, e := range entries {
+		rows = append(rows, usecase.ImportRow{Row: e.Row, Definition: e.Definition, Err: e.Err})
+	}
+
+	report, err := h.urlUseCase.ImportURLs(r.Context(), projectID(r), rows, mode, dryRun)
+	if err != nil {
+		if errors.Is(err, usecase.ErrUnknownImportMode) {
+			h.respondError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		h.logger.Error("failed to import urls", slog.Any("error", err))
+		h.respondError(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	resp := dto.ImportResponse{
+		DryRun:  report.DryRun,
+		Created: report.Count(usecase.ImportCreated),
+		Updated: report.Count(usecase.ImportUpdated),
+		Skipped: report.Count(usecase.ImportSkipped),
+		Failed:  report.Count(usecase.ImportFailed),
+		Rows:    make([]dto.ImportRowResponse, 0, len(report.Rows)),
+	}
+	for _, row := range report.Rows {
+		rowResp := dto.ImportRowResponse{
+			Row:     row.Row,
+			Address: row.Address,
+			Action:  string(row.Action),
+		}
+		if row.Err != nil {
+			rowResp.Error = row.Err.Error()
+		}
+		if row.URL != nil && !report.DryRun {
+			rowResp.ID = &row.URL.ID
+		}
+		resp.Rows = append(resp.Rows, rowResp)
+	}
+
+	status := http.StatusOK
+	if resp.Failed > 0 {
+		status = http.StatusUnprocessableEntity
+	}
+
+	h.respondJSON(w, resp, status)
+}
+
+// Export handles GET /urls/export?format=json|yaml|csv
+func (h *URLHandler) Export(w http.ResponseWriter, r *http.Request) {
+	format := urlfile.FormatJSON
+	if v := r.URL.Query().Get("format"); v != "" {
+		var err error
+		if format, err = urlfile.ParseFormat(v); err != nil {
+			h.respondError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	defs, err := h.urlUseCase.ExportURLs(r.Context(), projectID(r))
+	if err != nil {
+		h.logger.Error("failed to export urls", slog.Any("error", err))
+		h.respondError(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="urls.%s"`, format))
+	if err := urlfile.Encode(w, format, defs); err != nil {
+		h.logger.Error("failed to encode export", slog.Any("error", err))
+	}
+}
+
+// importFormat takes the format from the query or, failing that, from the
+// Content-Type header and defaults to JSON
+func importFormat(r *http.Request) (urlfile.Format, error) {
+	if v := r.URL.Query().Get("format"); v != "" {
+		return urlfile.ParseFormat(v)
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch mediaType {
+	case "application/yaml", "application/x-yaml", "text/yaml":
+		return urlfile.FormatYAML, nil
+	case "text/csv":
+		return urlfile.FormatCSV, nil
+	default:
+		return urlfile.FormatJSON, nil
+	}
+}
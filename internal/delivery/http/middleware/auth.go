@@ -0,0 +1,95 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"url-sentinel/internal/delivery/http/dto"
+	"url-sentinel/internal/domain/entity"
+	"url-sentinel/internal/usecase"
+
+	"github.com/google/uuid"
+)
+
+type apiKeyCtxKey struct{}
+
+// Authenticator resolves a bearer token to an API key
+type Authenticator interface {
+	Authenticate(ctx context.Context, plaintext string) (*entity.APIKey, error)
+}
+
+// Auth is a middleware that requires a valid API key in the Authorization header
+func Auth(auth Authenticator, log *slog.Logger) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || token == "" {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				respondError(w, "missing api key", http.StatusUnauthorized)
+				return
+			}
+
+			key, err := auth.Authenticate(r.Context(), token)
+			if err != nil {
+				if errors.Is(err, usecase.ErrUnauthorized) {
+					w.Header().Set("WWW-Authenticate", "Bearer")
+					respondError(w, "invalid api key", http.StatusUnauthorized)
+					return
+				}
+				log.Error("failed to authenticate request", slog.Any("error", err))
+				respondError(w, "internal server error", http.StatusInternalServerError)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), apiKeyCtxKey{}, key)))
+		})
+	}
+}
+
+// RequireScope is a middleware that rejects API keys lacking the scope.
+// It must be used after Auth.
+func RequireScope(scope entity.Scope) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := APIKeyFromContext(r.Context())
+			if key == nil || !key.HasScope(scope) {
+				respondError(w, "missing scope "+string(scope), http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireProject is a middleware that rejects API keys of other projects.
+// It must be used after Auth.
+func RequireProject(projectID uuid.UUID) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := APIKeyFromContext(r.Context())
+			if key == nil || key.ProjectID != projectID {
+				respondError(w, "api key belongs to another project", http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// APIKeyFromContext returns the authenticated API key, nil if there is none
+func APIKeyFromContext(ctx context.Context) *entity.APIKey {
+	key, _ := ctx.Value(apiKeyCtxKey{}).(*entity.APIKey)
+	return key
+}
+
+func respondError(w http.ResponseWriter, message string, status int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(dto.ErrorResponse{Error: message})
+}
@@ -0,0 +1,190 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/textproto"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AssertionType is the kind of property an assertion verifies
+type AssertionType string
+
+const (
+	AssertStatusCode     AssertionType = "status_code"     // value is an exact code ("200") or a class ("2xx")
+	AssertBodyContains   AssertionType = "body_contains"   // value is a substring of the response body
+	AssertHeader         AssertionType = "header"          // property is the header name, value its expected value
+	AssertResponseTime   AssertionType = "response_time"   // value is the maximum duration, e.g. "500ms"
+	AssertFinalURL       AssertionType = "final_url"       // value is the URL the response must come from after redirects
+	AssertRedirectDomain AssertionType = "redirect_domain" // value is the domain, subdomains included, redirects must stay on
+)
+
+var (
+	ErrInvalidAssertion = errors.New("invalid assertion")
+)
+
+// Assertion is a condition a check response must satisfy
+type Assertion struct {
+	Type     AssertionType
+	Property string
+	Value    string
+}
+
+// AssertionResult is the outcome of evaluating an assertion
+type AssertionResult struct {
+	Assertion Assertion
+	Passed    bool
+	Actual    string
+}
+
+// Response is the part of an HTTP response assertions are evaluated against
+type Response struct {
+	Code      int
+	Headers   map[string][]string
+	Body      []byte
+	Duration  time.Duration
+	Redirects []Redirect
+	FinalURL  string
+}
+
+// Validate checks the correctness of the assertion
+func (a Assertion) Validate() error {
+	switch a.Type {
+	case AssertStatusCode:
+		if _, _, ok := parseStatusRange(a.Value); !ok {
+			return fmt.Errorf("%w: status code must look like 200 or 2xx", ErrInvalidAssertion)
+		}
+	case AssertBodyContains:
+		if a.Value == "" {
+			return fmt.Errorf("%w: body substring is required", ErrInvalidAssertion)
+		}
+	case AssertHeader:
+		if a.Property == "" {
+			return fmt.Errorf("%w: header name is required", ErrInvalidAssertion)
+		}
+	case AssertResponseTime:
+		if d, err := time.ParseDuration(a.Value); err != nil || d <= 0 {
+			return fmt.Errorf("%w: response time must be a positive duration", ErrInvalidAssertion)
+		}
+	case AssertFinalURL:
+		if _, err := url.ParseRequestURI(a.Value); err != nil {
+			return fmt.Errorf("%w: final url must be an absolute URL", ErrInvalidAssertion)
+		}
+	case AssertRedirectDomain:
+		if a.Value == "" || strings.ContainsAny(a.Value, "/:") {
+			return fmt.Errorf("%w: redirect domain must be a host name like example.com", ErrInvalidAssertion)
+		}
+	default:
+		return fmt.Errorf("%w: unknown type %q", ErrInvalidAssertion, a.Type)
+	}
+	return nil
+}
+
+// Evaluate checks the assertion against a response. The assertion must be valid.
+func (a Assertion) Evaluate(resp *Response) AssertionResult {
+	result := AssertionResult{Assertion: a}
+
+	switch a.Type {
+	case AssertStatusCode:
+		low, high, _ := parseStatusRange(a.Value)
+		result.Actual = strconv.Itoa(resp.Code)
+		result.Passed = resp.Code >= low && resp.Code <= high
+	case AssertBodyContains:
+		result.Passed = strings.Contains(string(resp.Body), a.Value)
+		result.Actual = strconv.FormatBool(result.Passed)
+	case AssertHeader:
+		values := resp.Headers[textproto.CanonicalMIMEHeaderKey(a.Property)]
+		result.Actual = strings.Join(values, ", ")
+		for _, v := range values {
+			if v == a.Value {
+				result.Passed = true
+			}
+		}
+	case AssertResponseTime:
+		limit, _ := time.ParseDuration(a.Value)
+		result.Actual = resp.Duration.String()
+		result.Passed = resp.Duration <= limit
+	case AssertFinalURL:
+		result.Actual = resp.FinalURL
+		result.Passed = sameURL(resp.FinalURL, a.Value)
+	case AssertRedirectDomain:
+		// The first location off the domain, the final URL when every redirect stays on it
+		result.Actual = resp.FinalURL
+		result.Passed = true
+		for _, r := range resp.Redirects {
+			if !inDomain(r.Location, a.Value) {
+				result.Actual = r.Location
+				result.Passed = false
+				break
+			}
+		}
+	}
+
+	return result
+}
+
+// parseStatusRange parses "200" or "2xx" into an inclusive range of codes
+func parseStatusRange(value string) (int, int, bool) {
+	if class, ok := strings.CutSuffix(strings.ToLower(value), "xx"); ok {
+		n, err := strconv.Atoi(class)
+		if err != nil || n < 1 || n > 5 {
+			return 0, 0, false
+		}
+		return n * 100, n*100 + 99, true
+	}
+
+	code, err := strconv.Atoi(value)
+	if err != nil || code < 100 || code > 599 {
+		return 0, 0, false
+	}
+	return code, code, true
+}
+
+// inDomain reports whether the host of the address is the domain or one of its subdomains
+func inDomain(address, domain string) bool {
+	u, err := url.Parse(address)
+	if err != nil {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	domain = strings.ToLower(strings.TrimSuffix(domain, "."))
+	return host == domain || strings.HasSuffix(host, "."+domain)
+}
+
+// defaultPorts are left out of URLs compared by sameURL
+var defaultPorts = map[string]string{"http": "80", "https": "443"}
+
+// sameURL reports whether both addresses are the same URL, regardless of the
+// case of the scheme and host, a default port and an empty path
+func sameURL(a, b string) bool {
+	ua, err := url.Parse(a)
+	if err != nil {
+		return false
+	}
+	ub, err := url.Parse(b)
+	if err != nil {
+		return false
+	}
+	return normalizeURL(ua) == normalizeURL(ub)
+}
+
+// normalizeURL formats the URL with a lower-case scheme and host, without a
+// default port and with "/" for an empty path
+func normalizeURL(u *url.URL) string {
+	n := *u
+	n.Scheme = strings.ToLower(n.Scheme)
+	n.Host = strings.ToLower(n.Hostname())
+	if port := u.Port(); port != "" && port != defaultPorts[n.Scheme] {
+		n.Host = net.JoinHostPort(n.Host, port)
+	} else if strings.Contains(n.Host, ":") {
+		n.Host = "[" + n.Host + "]" // IPv6 literal
+	}
+	if n.Path == "" && n.RawPath == "" {
+		n.Path = "/"
+	}
+	return n.String()
+}
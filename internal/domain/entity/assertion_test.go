@@ -0,0 +1,64 @@
+package entity
+
+import "testing"
+
+func TestAssertFinalURL(t *testing.T) {
+	tests := []struct {
+		final, want string
+		passed      bool
+	}{
+		{"https://www.example.com/", "https://www.example.com/", true},
+		{"https://www.example.com/", "https://www.example.com", true},
+		{"https://www.example.com:443/", "HTTPS://WWW.Example.com", true},
+		{"http://example.com:80/login", "http://example.com/login", true},
+		{"http://[::1]:80/", "http://[::1]", true},
+		{"https://example.com:8443/", "https://example.com/", false},
+		{"http://example.com/", "https://example.com/", false},
+		{"https://example.com/login", "https://example.com/Login", false},
+		{"https://example.com/?a=1", "https://example.com/", false},
+		{"", "https://example.com/", false},
+	}
+
+	for _, tt := range tests {
+		a := Assertion{Type: AssertFinalURL, Value: tt.want}
+		if got := a.Evaluate(&Response{FinalURL: tt.final}); got.Passed != tt.passed {
+			t.Errorf("final url %q against %q passed = %t, want %t", tt.final, tt.want, got.Passed, tt.passed)
+		}
+	}
+}
+
+func TestRedirectAssertions(t *testing.T) {
+	redirected := func(locations ...string) *Response {
+		resp := &Response{}
+		for _, location := range locations {
+			resp.Redirects = append(resp.Redirects, Redirect{Code: 301, Location: location})
+			resp.FinalURL = location
+		}
+		return resp
+	}
+
+	tests := []struct {
+		name   string
+		expect string
+		resp   *Response
+		passed bool
+	}{
+		{"no redirects", "example.com", redirected(), true},
+		{"to a subdomain", "example.com", redirected("https://example.com/", "https://login.example.com/"), true},
+		{"off the expected domain", "example.com", redirected("https://example.com/", "https://parking.example.net/"), false},
+		{"to any domain without expectation", "", redirected("https://login.example.org/", "https://parking.example.net/"), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			url := &URL{ExpectRedirectDomain: tt.expect}
+			passed := true
+			for _, a := range url.RedirectAssertions() {
+				passed = passed && a.Evaluate(tt.resp).Passed
+			}
+			if passed != tt.passed {
+				t.Errorf("passed = %t, want %t", passed, tt.passed)
+			}
+		})
+	}
+}
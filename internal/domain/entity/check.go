@@ -1,29 +1,91 @@
 package entity
 
 import (
+	"errors"
+	"net/http"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// Trigger tells what caused a check to run
+type Trigger string
+
+const (
+	TriggerScheduled Trigger = "scheduled"
+	TriggerManual    Trigger = "manual"
+	TriggerTest      Trigger = "test" // dry run, never stored
+)
+
+var (
+	ErrInvalidCheckMethod = errors.New("unsupported check method")
+)
+
 // Check represents the result of a single URL health check
 type Check struct {
 	ID        uuid.UUID
 	URLID     uuid.UUID
+	Location  string
+	Trigger   Trigger
 	Status    bool
 	Code      int
 	Duration  time.Duration
+	Redirects []Redirect // redirect responses in the order they were received
 	CheckedAt time.Time
 }
 
-// NewCheck creates a new check result entity
-func NewCheck(urlID uuid.UUID, status bool, code int, duration time.Duration) *Check {
+// NewCheck creates a new result entity of a scheduled check
+func NewCheck(urlID uuid.UUID, location string, status bool, code int, duration time.Duration) *Check {
 	return &Check{
 		ID:        uuid.New(),
 		URLID:     urlID,
+		Location:  location,
+		Trigger:   TriggerScheduled,
 		Status:    status,
 		Code:      code,
 		Duration:  duration,
 		CheckedAt: time.Now().UTC(),
 	}
 }
+
+// CheckRequest describes the HTTP request of a check beyond a plain GET
+type CheckRequest struct {
+	Method  string // defaults to GET
+	Headers map[string]string
+	Body    string
+}
+
+// Validate checks the correctness of the check request
+func (r CheckRequest) Validate() error {
+	switch r.Method {
+	case "", http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut,
+		http.MethodPatch, http.MethodDelete, http.MethodOptions:
+		return nil
+	default:
+		return ErrInvalidCheckMethod
+	}
+}
+
+// Timings break down the duration of a check into its phases,
+// phases that did not happen (e.g. TLS for plain HTTP) are zero
+type Timings struct {
+	DNS       time.Duration
+	Connect   time.Duration
+	TLS       time.Duration
+	FirstByte time.Duration // from sending the request until the first response byte
+	Total     time.Duration
+}
+
+// CheckReport is the detailed outcome of a one-off check that is not stored
+type CheckReport struct {
+	Check         *Check
+	ErrorClass    string
+	Error         string // transport error, empty when a response was received
+	Timings       Timings
+	Headers       map[string][]string
+	FinalURL      string // URL of the response after following redirects
+	Body          string // response body, truncated to a limit
+	BodyTruncated bool
+	Assertions    []AssertionResult
+	Passed        bool // check succeeded and all assertions passed
+}
@@ -0,0 +1,103 @@
+package entity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/andybalholm/cascadia"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidIgnoreSelector = errors.New("invalid content ignore selector")
+	ErrInvalidIgnorePattern  = errors.New("invalid content ignore pattern")
+)
+
+// ContentWatch enables change detection of a URL's response body. The body is
+// reduced to its text before it is compared, leaving out the HTML elements
+// matching IgnoreSelectors and the text matching IgnorePatterns, so volatile
+// parts like clocks, ads or CSRF tokens do not count as changes.
+type ContentWatch struct {
+	IgnoreSelectors []string // CSS selectors, e.g. "#clock" or "div.ad"
+	IgnorePatterns  []string // regular expressions, e.g. `\d{2}:\d{2}:\d{2}`
+	Alert           bool     // a change fails the check that detected it
+}
+
+// Validate checks that every selector and pattern compiles
+func (w *ContentWatch) Validate() error {
+	for _, selector := range w.IgnoreSelectors {
+		if _, err := cascadia.ParseGroup(selector); err != nil {
+			return fmt.Errorf("%w %q: %v", ErrInvalidIgnoreSelector, selector, err)
+		}
+	}
+	for _, pattern := range w.IgnorePatterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("%w %q: %v", ErrInvalidIgnorePattern, pattern, err)
+		}
+	}
+	return nil
+}
+
+// Equal reports whether both settings detect changes the same way, nil meaning disabled
+func (w *ContentWatch) Equal(other *ContentWatch) bool {
+	if w == nil || other == nil {
+		return w == other
+	}
+	return w.Alert == other.Alert && w.SameRules(other)
+}
+
+// SameRules reports whether both settings normalize a body the same way, nil
+// meaning disabled. A snapshot is a valid baseline only under the rules it was taken with.
+func (w *ContentWatch) SameRules(other *ContentWatch) bool {
+	if w == nil || other == nil {
+		return w == other
+	}
+	return slices.Equal(w.IgnoreSelectors, other.IgnoreSelectors) &&
+		slices.Equal(w.IgnorePatterns, other.IgnorePatterns)
+}
+
+// ContentSnapshot is the normalized text of a URL's response body at the time of a check
+type ContentSnapshot struct {
+	URLID      uuid.UUID
+	Hash       string // hex encoded SHA-256 of Text
+	Text       string
+	CapturedAt time.Time
+}
+
+// NewContentSnapshot creates a snapshot of the normalized text of a response
+func NewContentSnapshot(urlID uuid.UUID, text string) *ContentSnapshot {
+	sum := sha256.Sum256([]byte(text))
+	return &ContentSnapshot{
+		URLID:      urlID,
+		Hash:       hex.EncodeToString(sum[:]),
+		Text:       text,
+		CapturedAt: time.Now().UTC(),
+	}
+}
+
+// ContentChange records that the content of a URL differs from its previous snapshot
+type ContentChange struct {
+	ID           uuid.UUID
+	URLID        uuid.UUID
+	PreviousHash string
+	Hash         string
+	Diff         string // unified diff of the previous and the new text
+	DetectedAt   time.Time
+}
+
+// NewContentChange creates the change between two snapshots of a URL
+func NewContentChange(previous, current *ContentSnapshot, diff string) *ContentChange {
+	return &ContentChange{
+		ID:           uuid.New(),
+		URLID:        current.URLID,
+		PreviousHash: previous.Hash,
+		Hash:         current.Hash,
+		Diff:         diff,
+		DetectedAt:   current.CapturedAt,
+	}
+}
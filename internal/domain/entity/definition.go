@@ -0,0 +1,70 @@
+package entity
+
+import (
+	"maps"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// URLDefinition holds the user-managed settings of a URL, the part that is
+// imported, exported and declared in configuration
+type URLDefinition struct {
+	Address              string
+	CheckInterval        time.Duration
+	Quorum               int // zero means DefaultQuorum
+	Labels               Labels
+	ContentWatch         *ContentWatch
+	Redirects            RedirectPolicy
+	ExpectFinalURL       string
+	ExpectRedirectDomain string
+}
+
+// Definition returns the user-managed settings of the URL
+func (u *URL) Definition() URLDefinition {
+	return URLDefinition{
+		Address:              u.Address,
+		CheckInterval:        u.CheckInterval,
+		Quorum:               u.Quorum,
+		Labels:               u.Labels,
+		ContentWatch:         u.ContentWatch,
+		Redirects:            u.Redirects,
+		ExpectFinalURL:       u.ExpectFinalURL,
+		ExpectRedirectDomain: u.ExpectRedirectDomain,
+	}
+}
+
+// Equal reports whether both definitions hold the same settings
+func (d URLDefinition) Equal(other URLDefinition) bool {
+	return d.Address == other.Address &&
+		d.CheckInterval == other.CheckInterval &&
+		d.Quorum == other.Quorum &&
+		maps.Equal(d.Labels, other.Labels) &&
+		d.ContentWatch.Equal(other.ContentWatch) &&
+		d.Redirects == other.Redirects &&
+		d.ExpectFinalURL == other.ExpectFinalURL &&
+		d.ExpectRedirectDomain == other.ExpectRedirectDomain
+}
+
+// NewURLFromDefinition creates a new URL entity of the project with validation
+func NewURLFromDefinition(projectID uuid.UUID, def URLDefinition) (*URL, error) {
+	url, err := NewURL(projectID, def.Address, def.CheckInterval)
+	if err != nil {
+		return nil, err
+	}
+	if def.Quorum != 0 {
+		url.Quorum = def.Quorum
+	}
+	if def.Labels != nil {
+		url.Labels = def.Labels
+	}
+	url.ContentWatch = def.ContentWatch
+	url.Redirects = def.Redirects
+	url.ExpectFinalURL = def.ExpectFinalURL
+	url.ExpectRedirectDomain = def.ExpectRedirectDomain
+	if err := url.Validate(); err != nil {
+		return nil, err
+	}
+
+	return url, nil
+}
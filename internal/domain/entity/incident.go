@@ -0,0 +1,65 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LocationStatus is the latest result reported by a single location
+type LocationStatus struct {
+	Location  string
+	Status    bool
+	Code      int
+	CheckedAt time.Time
+}
+
+// Incident represents a period during which a URL was considered down
+type Incident struct {
+	ID         uuid.UUID
+	URLID      uuid.UUID
+	Locations  []LocationStatus // per-location breakdown at the moment the incident was opened
+	StartedAt  time.Time
+	ResolvedAt *time.Time
+}
+
+// NewIncident creates a new open incident
+func NewIncident(urlID uuid.UUID, locations []LocationStatus) *Incident {
+	return &Incident{
+		ID:        uuid.New(),
+		URLID:     urlID,
+		Locations: locations,
+		StartedAt: time.Now().UTC(),
+	}
+}
+
+// IsOpen reports whether the incident has not been resolved yet
+func (i *Incident) IsOpen() bool {
+	return i.ResolvedAt == nil
+}
+
+// EvaluateQuorum decides whether a URL is down based on the latest check from every location.
+// Checks older than window are ignored; the URL is down when at least quorum locations fail.
+func EvaluateQuorum(checks []*Check, quorum int, window time.Duration, now time.Time) (bool, []LocationStatus) {
+	locations := make([]LocationStatus, 0, len(checks))
+	failures := 0
+
+	for _, check := range checks {
+		if now.Sub(check.CheckedAt) > window {
+			continue
+		}
+
+		locations = append(locations, LocationStatus{
+			Location:  check.Location,
+			Status:    check.Status,
+			Code:      check.Code,
+			CheckedAt: check.CheckedAt,
+		})
+
+		if !check.Status {
+			failures++
+		}
+	}
+
+	return failures >= quorum, locations
+}
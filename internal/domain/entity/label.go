@@ -0,0 +1,74 @@
+package entity
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// maxLabels limits the number of labels attached to a single URL
+const maxLabels = 32
+
+// LabelManagedBy marks URLs owned by a declarative source, URLs declared in
+// the monitors file carry ManagedByConfig and are the only ones it changes
+const (
+	LabelManagedBy  = "managed_by"
+	ManagedByConfig = "config"
+)
+
+var (
+	ErrInvalidLabel         = errors.New("label keys must be 1-63 characters of letters, digits, '_', '-', '.' or '/' and values at most 255 characters")
+	ErrTooManyLabels        = errors.New("too many labels")
+	ErrReservedLabel        = errors.New("label " + LabelManagedBy + " is reserved for URLs declared in the monitors file")
+	ErrInvalidLabelSelector = errors.New("label selector must be a comma-separated list of key=value pairs")
+)
+
+var labelKeyRegexp = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.\-/]{0,62}$`)
+
+// Labels are key/value pairs grouping URLs, e.g. team=payments or env=prod
+type Labels map[string]string
+
+// Validate checks label keys and values
+func (l Labels) Validate() error {
+	if len(l) > maxLabels {
+		return ErrTooManyLabels
+	}
+	for key, value := range l {
+		if !labelKeyRegexp.MatchString(key) || len(value) > 255 {
+			return ErrInvalidLabel
+		}
+	}
+	return nil
+}
+
+// LabelSelector matches URLs carrying all of its key/value pairs
+type LabelSelector map[string]string
+
+// ParseLabelSelector parses a selector of the form "team=payments,env=prod".
+// An empty string yields an empty selector matching every URL.
+func ParseLabelSelector(s string) (LabelSelector, error) {
+	selector := LabelSelector{}
+	if s == "" {
+		return selector, nil
+	}
+
+	for _, pair := range strings.Split(s, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok || !labelKeyRegexp.MatchString(key) {
+			return nil, ErrInvalidLabelSelector
+		}
+		selector[key] = value
+	}
+
+	return selector, nil
+}
+
+// Matches reports whether the labels contain every pair of the selector
+func (s LabelSelector) Matches(labels Labels) bool {
+	for key, value := range s {
+		if v, ok := labels[key]; !ok || v != value {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,71 @@
+package entity
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultProjectID identifies the project that owns data created before projects existed
+var DefaultProjectID = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+
+var (
+	ErrProjectNameRequired  = errors.New("project name is required")
+	ErrInvalidQuota         = errors.New("project quotas must not be negative")
+	ErrURLQuotaExceeded     = errors.New("project url quota exceeded")
+	ErrIntervalBelowMinimum = errors.New("check interval is below the project minimum")
+)
+
+// Project represents a tenant owning its own URLs and API keys
+type Project struct {
+	ID          uuid.UUID
+	Name        string
+	MaxURLs     int           // 0 means unlimited
+	MinInterval time.Duration // 0 means no minimum
+	CreatedAt   time.Time
+}
+
+// NewProject creates a new project entity with validation
+func NewProject(name string, maxURLs int, minInterval time.Duration) (*Project, error) {
+	project := &Project{
+		ID:          uuid.New(),
+		Name:        name,
+		MaxURLs:     maxURLs,
+		MinInterval: minInterval,
+		CreatedAt:   time.Now().UTC(),
+	}
+
+	if err := project.Validate(); err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
+// Validate checks the correctness of the project entity
+func (p *Project) Validate() error {
+	if p.Name == "" {
+		return ErrProjectNameRequired
+	}
+	if p.MaxURLs < 0 || p.MinInterval < 0 {
+		return ErrInvalidQuota
+	}
+	return nil
+}
+
+// CheckQuota verifies that one more URL with the given interval fits into the project
+func (p *Project) CheckQuota(existingURLs int, interval time.Duration) error {
+	if p.MaxURLs > 0 && existingURLs >= p.MaxURLs {
+		return ErrURLQuotaExceeded
+	}
+	return p.CheckInterval(interval)
+}
+
+// CheckInterval verifies that the interval is not below the project minimum
+func (p *Project) CheckInterval(interval time.Duration) error {
+	if interval < p.MinInterval {
+		return ErrIntervalBelowMinimum
+	}
+	return nil
+}
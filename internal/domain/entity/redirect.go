@@ -0,0 +1,107 @@
+package entity
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DefaultMaxRedirects is how many redirects a check follows unless limited
+	DefaultMaxRedirects = 10
+	// MaxRedirectsLimit is the highest redirect limit a URL may set
+	MaxRedirectsLimit = 20
+)
+
+var (
+	ErrInvalidRedirectPolicy = errors.New("follow redirects must be on, off or a limit between 1 and 20")
+	ErrInvalidFinalURL       = errors.New("expected final url must be an absolute URL")
+	ErrInvalidRedirectDomain = errors.New("expected redirect domain must be a host name like example.com")
+)
+
+// RedirectPolicy tells how many redirects a check follows. The zero value
+// follows up to DefaultMaxRedirects, a positive value is a lower or higher limit.
+type RedirectPolicy int
+
+const (
+	FollowRedirects RedirectPolicy = 0  // follow up to DefaultMaxRedirects
+	NoRedirects     RedirectPolicy = -1 // the first response is the result
+)
+
+// ParseRedirectPolicy parses "on", "off" or a limit like "3", empty means "on"
+func ParseRedirectPolicy(value string) (RedirectPolicy, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "on":
+		return FollowRedirects, nil
+	case "off":
+		return NoRedirects, nil
+	}
+
+	limit, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || limit < 1 || limit > MaxRedirectsLimit {
+		return 0, ErrInvalidRedirectPolicy
+	}
+	return RedirectPolicy(limit), nil
+}
+
+// String formats the policy the way ParseRedirectPolicy reads it
+func (p RedirectPolicy) String() string {
+	switch p {
+	case FollowRedirects:
+		return "on"
+	case NoRedirects:
+		return "off"
+	default:
+		return strconv.Itoa(int(p))
+	}
+}
+
+// MaxRedirects returns how many redirects a check follows
+func (p RedirectPolicy) MaxRedirects() int {
+	switch p {
+	case FollowRedirects:
+		return DefaultMaxRedirects
+	case NoRedirects:
+		return 0
+	default:
+		return int(p)
+	}
+}
+
+// Validate checks the correctness of the redirect policy
+func (p RedirectPolicy) Validate() error {
+	if p < NoRedirects || p > MaxRedirectsLimit {
+		return ErrInvalidRedirectPolicy
+	}
+	return nil
+}
+
+// Redirect is a redirect response received by a check
+type Redirect struct {
+	Code     int    // e.g. 301 or 302
+	Location string // absolute URL the response redirected to
+}
+
+// RedirectAssertions returns the redirect expectations of the URL as assertions
+// evaluated against every check, none when it has no expectations
+func (u *URL) RedirectAssertions() []Assertion {
+	var assertions []Assertion
+	if u.ExpectFinalURL != "" {
+		assertions = append(assertions, Assertion{Type: AssertFinalURL, Value: u.ExpectFinalURL})
+	}
+	if u.ExpectRedirectDomain != "" {
+		assertions = append(assertions, Assertion{Type: AssertRedirectDomain, Value: u.ExpectRedirectDomain})
+	}
+	return assertions
+}
+
+// validateRedirectExpectations checks the expectations like the assertions they become
+func (u *URL) validateRedirectExpectations() error {
+	if u.ExpectFinalURL != "" && (Assertion{Type: AssertFinalURL, Value: u.ExpectFinalURL}).Validate() != nil {
+		return ErrInvalidFinalURL
+	}
+	if u.ExpectRedirectDomain != "" && (Assertion{Type: AssertRedirectDomain, Value: u.ExpectRedirectDomain}).Validate() != nil {
+		return ErrInvalidRedirectDomain
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// State is the current availability state of a URL
+type State string
+
+const (
+	StateUnknown State = "unknown"
+	StateUp      State = "up"
+	StateDown    State = "down"
+	StatePaused  State = "paused" // the URL is not checked, it has no status of its own
+)
+
+// StateChange is a transition of a URL from one state to another
+type StateChange struct {
+	URLID uuid.UUID
+	From  State
+	To    State
+	At    time.Time // time of the check causing the transition
+}
+
+// DailyUptime aggregates check results of a URL over a single UTC day
+type DailyUptime struct {
+	Day        time.Time
+	Total      int
+	Successful int
+}
+
+// Ratio returns the share of successful checks, -1 when there were no checks
+func (d *DailyUptime) Ratio() float64 {
+	if d.Total == 0 {
+		return -1
+	}
+	return float64(d.Successful) / float64(d.Total)
+}
+
+// CheckStats aggregates check results of a URL over a period
+type CheckStats struct {
+	Total       int
+	Successful  int
+	AvgDuration time.Duration
+}
+
+// Uptime returns the share of successful checks, -1 when there were no checks
+func (s *CheckStats) Uptime() float64 {
+	if s.Total == 0 {
+		return -1
+	}
+	return float64(s.Successful) / float64(s.Total)
+}
+
+// LabelGroupStats aggregates check results of all URLs sharing a label value
+type LabelGroupStats struct {
+	Value string
+	URLs  int
+	CheckStats
+}
+
+// URLStatus is the current state of a URL, kept up to date with every check
+type URLStatus struct {
+	State               State
+	LastStatus          bool
+	LastCode            int
+	LastDuration        time.Duration
+	LastCheckedAt       time.Time
+	StateSince          time.Time // when the URL entered its current state
+	ConsecutiveFailures int       // failed checks in a row across all locations
+	Uptime              float64   // share of successful checks in the 24 hours up to LastCheckedAt
+}
@@ -0,0 +1,63 @@
+package entity
+
+import (
+	"errors"
+	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidSlug            = errors.New("slug must contain only lowercase letters, digits and dashes")
+	ErrStatusPageTitleMissing = errors.New("status page title is required")
+)
+
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// StatusComponent groups URLs displayed together on a status page
+type StatusComponent struct {
+	Name   string
+	URLIDs []uuid.UUID
+}
+
+// StatusPage represents a public page displaying the state of selected URLs
+type StatusPage struct {
+	ID         uuid.UUID
+	ProjectID  uuid.UUID
+	Slug       string
+	Title      string
+	LogoText   string
+	Components []StatusComponent
+	CreatedAt  time.Time
+}
+
+// NewStatusPage creates a new status page entity with validation
+func NewStatusPage(projectID uuid.UUID, slug, title, logoText string, components []StatusComponent) (*StatusPage, error) {
+	page := &StatusPage{
+		ID:         uuid.New(),
+		ProjectID:  projectID,
+		Slug:       slug,
+		Title:      title,
+		LogoText:   logoText,
+		Components: components,
+		CreatedAt:  time.Now().UTC(),
+	}
+
+	if err := page.Validate(); err != nil {
+		return nil, err
+	}
+
+	return page, nil
+}
+
+// Validate checks the correctness of the status page entity
+func (p *StatusPage) Validate() error {
+	if !slugPattern.MatchString(p.Slug) {
+		return ErrInvalidSlug
+	}
+	if p.Title == "" {
+		return ErrStatusPageTitleMissing
+	}
+	return nil
+}
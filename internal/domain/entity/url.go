@@ -8,22 +8,36 @@ import (
 	"github.com/google/uuid"
 )
 
+// DefaultQuorum is the number of failing locations required to declare a URL down
+const DefaultQuorum = 1
+
 var (
-	ErrInvalidURLFormat       = errors.New("invalid URL format")
-	ErrInvalidCheckInterval   = errors.New("check interval must be positive")
-	ErrURLIDRequired          = errors.New("url id is required")
+	ErrInvalidURLFormat     = errors.New("invalid URL format")
+	ErrInvalidCheckInterval = errors.New("check interval must be positive")
+	ErrURLIDRequired        = errors.New("url id is required")
+	ErrInvalidQuorum        = errors.New("quorum must be at least 1")
 )
 
 // URL represents a monitored web address with its configuration
 type URL struct {
-	ID            uuid.UUID
-	Address       string
-	CheckInterval time.Duration
-	CreatedAt     time.Time
+	ID                   uuid.UUID
+	ProjectID            uuid.UUID
+	Address              string
+	CheckInterval        time.Duration
+	Quorum               int    // failing locations required to declare the URL down
+	PublicToken          string // unguessable token granting public access to badges
+	Labels               Labels
+	ContentWatch         *ContentWatch  // nil when changes of the response body are not detected
+	Redirects            RedirectPolicy // how many redirects a check follows
+	ExpectFinalURL       string         // checks fail unless the response comes from this URL after redirects, empty accepts any
+	ExpectRedirectDomain string         // checks fail when a redirect leaves this domain or its subdomains, empty accepts any
+	Paused               bool           // not checked until resumed
+	Status               *URLStatus     // nil until the URL is checked for the first time
+	CreatedAt            time.Time
 }
 
 // NewURL creates a new URL entity with validation
-func NewURL(address string, interval time.Duration) (*URL, error) {
+func NewURL(projectID uuid.UUID, address string, interval time.Duration) (*URL, error) {
 	if _, err := url.ParseRequestURI(address); err != nil {
 		return nil, ErrInvalidURLFormat
 	}
@@ -33,12 +47,26 @@ func NewURL(address string, interval time.Duration) (*URL, error) {
 
 	return &URL{
 		ID:            uuid.New(),
+		ProjectID:     projectID,
 		Address:       address,
 		CheckInterval: interval,
+		Quorum:        DefaultQuorum,
+		PublicToken:   newPublicToken(),
+		Labels:        Labels{},
 		CreatedAt:     time.Now().UTC(),
 	}, nil
 }
 
+// newPublicToken generates a random 128-bit hex token
+func newPublicToken() string {
+	return randomHex(16)
+}
+
+// QuorumWindow returns how recent a location's check must be to count towards the quorum
+func (u *URL) QuorumWindow() time.Duration {
+	return 2 * u.CheckInterval
+}
+
 // Validate checks the correctness of the URL entity
 func (u *URL) Validate() error {
 	if u.ID == uuid.Nil {
@@ -50,5 +78,19 @@ func (u *URL) Validate() error {
 	if u.CheckInterval <= 0 {
 		return ErrInvalidCheckInterval
 	}
-	return nil
+	if u.Quorum < 1 {
+		return ErrInvalidQuorum
+	}
+	if err := u.Redirects.Validate(); err != nil {
+		return err
+	}
+	if err := u.validateRedirectExpectations(); err != nil {
+		return err
+	}
+	if u.ContentWatch != nil {
+		if err := u.ContentWatch.Validate(); err != nil {
+			return err
+		}
+	}
+	return u.Labels.Validate()
 }
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"url-sentinel/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrAPIKeyNotFound = errors.New("api key not found")
+)
+
+// APIKeyRepository defines the interface for API key persistence operations
+type APIKeyRepository interface {
+	// Create saves a new API key to the repository
+	Create(ctx context.Context, key *entity.APIKey) error
+
+	// GetByPrefix retrieves an API key by its public prefix
+	GetByPrefix(ctx context.Context, prefix string) (*entity.APIKey, error)
+
+	// List retrieves all API keys from the repository
+	List(ctx context.Context) ([]*entity.APIKey, error)
+
+	// Delete removes an API key by its ID
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// TouchLastUsed records when an API key was last used
+	TouchLastUsed(ctx context.Context, id uuid.UUID, usedAt time.Time) error
+}
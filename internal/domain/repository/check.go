@@ -2,20 +2,47 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"url-sentinel/internal/domain/entity"
 
 	"github.com/google/uuid"
 )
 
-// CheckRepository defines the interface for check result persistence operations
+// CheckRepository defines the interface for check result persistence operations.
+// Methods taking a projectID only see checks of URLs in that project; uuid.Nil
+// disables scoping and is reserved for internal components.
 type CheckRepository interface {
 	// Create saves a new check result to the repository
 	Create(ctx context.Context, check *entity.Check) error
 
 	// ListByURLID retrieves all check results for a specific URL
-	ListByURLID(ctx context.Context, urlID uuid.UUID) ([]*entity.Check, error)
+	ListByURLID(ctx context.Context, projectID, urlID uuid.UUID) ([]*entity.Check, error)
 
 	// GetLatestByURLID retrieves the most recent check for a URL
-	GetLatestByURLID(ctx context.Context, urlID uuid.UUID) (*entity.Check, error)
+	GetLatestByURLID(ctx context.Context, projectID, urlID uuid.UUID) (*entity.Check, error)
+
+	// ListLatestByLocation retrieves the most recent check of a URL from every location
+	ListLatestByLocation(ctx context.Context, projectID, urlID uuid.UUID) ([]*entity.Check, error)
+
+	// DailyUptime aggregates check results of a URL per UTC day since the given time
+	DailyUptime(ctx context.Context, projectID, urlID uuid.UUID, since time.Time) ([]*entity.DailyUptime, error)
+
+	// Stats aggregates check results of a URL since the given time
+	Stats(ctx context.Context, projectID, urlID uuid.UUID, since time.Time) (*entity.CheckStats, error)
+
+	// StatsByLabel aggregates check results since the given time of the project's URLs
+	// matching the selector, grouped by the value of the label key
+	StatsByLabel(ctx context.Context, projectID uuid.UUID, key string, selector entity.LabelSelector, since time.Time) ([]*entity.LabelGroupStats, error)
+
+	// DeleteBefore removes checks older than the given time. Repositories keeping
+	// checks in time partitions may keep them until their whole partition expires.
+	DeleteBefore(ctx context.Context, before time.Time) error
+}
+
+// CheckPartitioner is implemented by check repositories keeping checks in time
+// partitions, which are created ahead of the checks they will hold
+type CheckPartitioner interface {
+	// EnsurePartitions creates missing partitions for checks from now on
+	EnsurePartitions(ctx context.Context, now time.Time) error
 }
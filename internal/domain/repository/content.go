@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+
+	"url-sentinel/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// ContentRepository defines the interface for persisting the content snapshots
+// of watched URLs and the changes detected between them
+type ContentRepository interface {
+	// GetSnapshot retrieves the latest content snapshot of a URL, nil if there is none
+	GetSnapshot(ctx context.Context, urlID uuid.UUID) (*entity.ContentSnapshot, error)
+
+	// SaveSnapshot replaces the snapshot of a URL and saves the change that led
+	// to it, if any, atomically
+	SaveSnapshot(ctx context.Context, snapshot *entity.ContentSnapshot, change *entity.ContentChange) error
+
+	// ListChanges retrieves all content changes of a URL, most recent first
+	ListChanges(ctx context.Context, urlID uuid.UUID) ([]*entity.ContentChange, error)
+}
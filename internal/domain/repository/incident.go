@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"url-sentinel/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrIncidentAlreadyOpen = errors.New("url already has an open incident")
+)
+
+// IncidentRepository defines the interface for incident persistence operations
+type IncidentRepository interface {
+	// Create saves a new open incident to the repository
+	Create(ctx context.Context, incident *entity.Incident) error
+
+	// GetOpenByURLID retrieves the open incident of a URL, nil if there is none
+	GetOpenByURLID(ctx context.Context, urlID uuid.UUID) (*entity.Incident, error)
+
+	// Resolve marks an incident as resolved at the given time
+	Resolve(ctx context.Context, id uuid.UUID, resolvedAt time.Time) error
+
+	// ListByURLID retrieves all incidents of a URL, most recent first
+	ListByURLID(ctx context.Context, urlID uuid.UUID) ([]*entity.Incident, error)
+}
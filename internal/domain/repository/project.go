@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"url-sentinel/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrProjectNotFound = errors.New("project not found")
+)
+
+// ProjectRepository defines the interface for project persistence operations
+type ProjectRepository interface {
+	// Create saves a new project to the repository
+	Create(ctx context.Context, project *entity.Project) error
+
+	// GetByID retrieves a project by its ID
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.Project, error)
+
+	// List retrieves all projects from the repository
+	List(ctx context.Context) ([]*entity.Project, error)
+
+	// Update saves changes of an existing project
+	Update(ctx context.Context, project *entity.Project) error
+
+	// Delete removes a project with all its URLs and API keys
+	Delete(ctx context.Context, id uuid.UUID) error
+}
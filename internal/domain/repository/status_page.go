@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"url-sentinel/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrStatusPageNotFound   = errors.New("status page not found")
+	ErrStatusPageSlugExists = errors.New("status page with this slug already exists")
+)
+
+// StatusPageRepository defines the interface for status page persistence operations.
+// Pages are managed within a project but served publicly by their global slug.
+type StatusPageRepository interface {
+	// Create saves a new status page to the repository
+	Create(ctx context.Context, page *entity.StatusPage) error
+
+	// GetByID retrieves a status page of the project by its ID
+	GetByID(ctx context.Context, projectID, id uuid.UUID) (*entity.StatusPage, error)
+
+	// GetBySlug retrieves a status page by its slug
+	GetBySlug(ctx context.Context, slug string) (*entity.StatusPage, error)
+
+	// List retrieves all status pages of the project
+	List(ctx context.Context, projectID uuid.UUID) ([]*entity.StatusPage, error)
+
+	// Update saves changes of an existing status page
+	Update(ctx context.Context, page *entity.StatusPage) error
+
+	// Delete removes a status page of the project by its ID
+	Delete(ctx context.Context, projectID, id uuid.UUID) error
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"errors"
+	"time"
 
 	"url-sentinel/internal/domain/entity"
 
@@ -13,22 +14,75 @@ var (
 	ErrURLNotFound      = errors.New("url not found")
 	ErrURLAlreadyExists = errors.New("url already exists")
 	ErrURLAddressExists = errors.New("url with this address already exists")
+	ErrInvalidCursor    = errors.New("invalid pagination cursor")
 )
 
-// URLRepository defines the interface for URL persistence operations
+// URLSort is a field URLs can be ordered by
+type URLSort string
+
+const (
+	URLSortCreatedAt   URLSort = "created_at"
+	URLSortAddress     URLSort = "address"
+	URLSortLastChecked URLSort = "last_checked" // URLs never checked come first
+	URLSortUptime      URLSort = "uptime"       // as of the last check, URLs without checks come first
+)
+
+// URLCursor marks the position after which the next page of URLs starts
+type URLCursor struct {
+	Value string    // sort key of the last URL of the previous page
+	ID    uuid.UUID // breaks ties between equal sort keys
+}
+
+// URLFilter narrows down, orders and paginates the URLs returned by List
+type URLFilter struct {
+	Labels   entity.LabelSelector // URLs must carry all of these labels
+	Search   string               // case-insensitive substring of the address
+	State    entity.State         // empty matches every state
+	Interval time.Duration        // zero matches every interval
+	Sort     URLSort              // defaults to URLSortCreatedAt
+	Desc     bool
+	Limit    int // zero returns all matching URLs
+	After    *URLCursor
+}
+
+// URLPage is a page of URLs with the cursor of the next page, nil on the last page
+type URLPage struct {
+	URLs []*entity.URL
+	Next *URLCursor
+}
+
+// URLRepository defines the interface for URL persistence operations.
+// Methods taking a projectID only see URLs of that project; uuid.Nil
+// disables scoping and is reserved for internal components such as the monitor.
 type URLRepository interface {
 	// Create saves a new URL to the repository
 	Create(ctx context.Context, url *entity.URL) error
 
 	// GetByID retrieves a URL by its ID
-	GetByID(ctx context.Context, id uuid.UUID) (*entity.URL, error)
+	GetByID(ctx context.Context, projectID, id uuid.UUID) (*entity.URL, error)
+
+	// List retrieves a page of URLs matching the filter
+	List(ctx context.Context, projectID uuid.UUID, filter URLFilter) (*URLPage, error)
 
-	// List retrieves all URLs from the repository
-	List(ctx context.Context) ([]*entity.URL, error)
+	// Update saves the settings of an existing URL of its project; the address,
+	// public token and creation time are left unchanged
+	Update(ctx context.Context, url *entity.URL) error
 
 	// Delete removes a URL by its ID
-	Delete(ctx context.Context, id uuid.UUID) error
+	Delete(ctx context.Context, projectID, id uuid.UUID) error
+
+	// ExistsByAddress checks if a URL with the given address already exists in the project
+	ExistsByAddress(ctx context.Context, projectID uuid.UUID, address string) (bool, error)
+
+	// Import creates and updates URLs of a project in a single transaction. URLs to
+	// update are matched by address and receive the stored ID, public token and creation time.
+	Import(ctx context.Context, projectID uuid.UUID, create, update []*entity.URL) error
+
+	// Count returns the number of URLs in the project
+	Count(ctx context.Context, projectID uuid.UUID) (int, error)
 
-	// ExistsByAddress checks if a URL with the given address already exists
-	ExistsByAddress(ctx context.Context, address string) (bool, error)
+	// RecordStatus updates the current status of a URL with a new check result
+	// and the state evaluated after it; results older than the last recorded one are ignored.
+	// It returns the state change, nil when the state did not change or the result was ignored.
+	RecordStatus(ctx context.Context, check *entity.Check, state entity.State) (*entity.StateChange, error)
 }
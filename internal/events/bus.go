@@ -0,0 +1,295 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+)
+
+const (
+	// DefaultBuffer is the queue size of a subscriber that sets none
+	DefaultBuffer = 256
+)
+
+// Handler handles an event delivered to a subscriber
+type Handler func(ctx context.Context, e Event) error
+
+// Route binds a handler to the events of one name
+type Route struct {
+	name   string
+	handle Handler
+}
+
+// On routes the events of type E to fn
+func On[E Event](fn func(ctx context.Context, e E) error) Route {
+	var zero E
+	return Route{
+		name: zero.EventName(),
+		handle: func(ctx context.Context, e Event) error {
+			return fn(ctx, e.(E))
+		},
+	}
+}
+
+// SubscribeOptions tune the delivery to a subscriber
+type SubscribeOptions struct {
+	// Buffer is how many events may wait for the subscriber, DefaultBuffer if zero
+	Buffer int
+
+	// Workers is how many events are handled concurrently, one if zero. Events
+	// are handled in publishing order only with a single worker or OrderBy.
+	Workers int
+
+	// OrderBy keys the events, events of the same key are handled by the same
+	// worker in publishing order, e.g. ByURL. The buffer is split among the workers.
+	OrderBy func(e Event) string
+
+	// DropWhenFull drops events for a full queue instead of making the
+	// publisher wait, for subscribers that may miss events
+	DropWhenFull bool
+}
+
+// SubscriberStats describes the delivery to a subscriber
+type SubscriberStats struct {
+	Name      string
+	Queued    int
+	Delivered uint64
+	Failed    uint64
+	Dropped   uint64
+}
+
+// delivery is an event waiting in the queue of a subscriber
+type delivery struct {
+	ctx   context.Context
+	event Event
+}
+
+// subscriber owns the queues of events drained by its workers, a single
+// queue shared by all workers unless events are ordered by key
+type subscriber struct {
+	name   string
+	routes map[string]Handler
+	opts   SubscribeOptions
+	queues []chan delivery
+	wg     sync.WaitGroup
+
+	delivered atomic.Uint64
+	failed    atomic.Uint64
+	dropped   atomic.Uint64
+}
+
+// Bus delivers published events to the subscribers of their names. Every
+// subscriber has its own buffered queue, so a slow subscriber only delays
+// the publisher once its queue is full and never delays other subscribers.
+type Bus struct {
+	logger *slog.Logger
+
+	mu          sync.RWMutex
+	subscribers []*subscriber
+	published   map[string]*atomic.Uint64
+	closed      bool
+	inFlight    sync.WaitGroup // publishers delivering to queues
+}
+
+// NewBus creates a new event bus
+func NewBus(logger *slog.Logger) *Bus {
+	return &Bus{
+		logger:    logger,
+		published: make(map[string]*atomic.Uint64),
+	}
+}
+
+// Subscribe registers a subscriber handling the events of its routes until the
+// bus is closed. A handler must not publish events routed to its own
+// subscriber, it could wait for its own full queue forever.
+func (b *Bus) Subscribe(name string, opts SubscribeOptions, routes ...Route) {
+	if opts.Buffer <= 0 {
+		opts.Buffer = DefaultBuffer
+	}
+	if opts.Workers <= 0 {
+		opts.Workers = 1
+	}
+
+	s := &subscriber{
+		name:   name,
+		routes: make(map[string]Handler, len(routes)),
+		opts:   opts,
+	}
+	if opts.OrderBy == nil {
+		s.queues = []chan delivery{make(chan delivery, opts.Buffer)}
+	} else {
+		for range opts.Workers {
+			s.queues = append(s.queues, make(chan delivery, max(opts.Buffer/opts.Workers, 1)))
+		}
+	}
+	for _, r := range routes {
+		s.routes[r.name] = r.handle
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return
+	}
+	b.subscribers = append(b.subscribers, s)
+
+	for i := range opts.Workers {
+		s.wg.Add(1)
+		go b.work(s, s.queues[i%len(s.queues)])
+	}
+}
+
+// queue returns the queue of the worker handling the event
+func (s *subscriber) queue(e Event) chan delivery {
+	if len(s.queues) == 1 {
+		return s.queues[0]
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(s.opts.OrderBy(e)))
+	return s.queues[h.Sum32()%uint32(len(s.queues))]
+}
+
+// Publish queues the event for every subscriber of its name. Handlers get a
+// context carrying the values of ctx, such as the trace span, but not its
+// cancellation. Events published after Close are discarded.
+func (b *Bus) Publish(ctx context.Context, e Event) {
+	name := e.EventName()
+
+	b.mu.RLock()
+	if b.closed {
+		b.mu.RUnlock()
+		return
+	}
+	subscribers := b.subscribers
+	b.inFlight.Add(1)
+	b.mu.RUnlock()
+	defer b.inFlight.Done()
+
+	b.counter(name).Add(1)
+
+	d := delivery{ctx: context.WithoutCancel(ctx), event: e}
+	for _, s := range subscribers {
+		if _, ok := s.routes[name]; !ok {
+			continue
+		}
+
+		queue := s.queue(e)
+		if !s.opts.DropWhenFull {
+			queue <- d
+			continue
+		}
+		select {
+		case queue <- d:
+		default:
+			s.dropped.Add(1)
+		}
+	}
+}
+
+// Close stops accepting events and waits until every queued event is handled
+func (b *Bus) Close() {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return
+	}
+	b.closed = true
+	b.mu.Unlock()
+
+	b.inFlight.Wait()
+	for _, s := range b.subscribers {
+		for _, queue := range s.queues {
+			close(queue)
+		}
+	}
+	for _, s := range b.subscribers {
+		s.wg.Wait()
+	}
+}
+
+// Published returns the number of events published by name
+func (b *Bus) Published() map[string]uint64 {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	published := make(map[string]uint64, len(b.published))
+	for name, n := range b.published {
+		published[name] = n.Load()
+	}
+	return published
+}
+
+// Stats returns the delivery statistics of every subscriber
+func (b *Bus) Stats() []SubscriberStats {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	stats := make([]SubscriberStats, 0, len(b.subscribers))
+	for _, s := range b.subscribers {
+		queued := 0
+		for _, queue := range s.queues {
+			queued += len(queue)
+		}
+		stats = append(stats, SubscriberStats{
+			Name:      s.name,
+			Queued:    queued,
+			Delivered: s.delivered.Load(),
+			Failed:    s.failed.Load(),
+			Dropped:   s.dropped.Load(),
+		})
+	}
+	return stats
+}
+
+func (b *Bus) counter(name string) *atomic.Uint64 {
+	b.mu.RLock()
+	n, ok := b.published[name]
+	b.mu.RUnlock()
+	if ok {
+		return n
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if n, ok = b.published[name]; !ok {
+		n = new(atomic.Uint64)
+		b.published[name] = n
+	}
+	return n
+}
+
+// work handles the events of a queue of the subscriber until it is closed
+func (b *Bus) work(s *subscriber, queue chan delivery) {
+	defer s.wg.Done()
+
+	for d := range queue {
+		if err := b.handle(s, d); err != nil {
+			s.failed.Add(1)
+			b.logger.Error("failed to handle event",
+				slog.String("subscriber", s.name),
+				slog.String("event", d.event.EventName()),
+				slog.Any("error", err),
+			)
+			continue
+		}
+		s.delivered.Add(1)
+	}
+}
+
+// handle runs the handler of the event, turning a panic into an error so a
+// faulty handler does not take its subscriber down
+func (b *Bus) handle(s *subscriber, d delivery) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("handler panicked: %v", r)
+		}
+	}()
+
+	return s.routes[d.event.EventName()](d.ctx, d.event)
+}
@@ -0,0 +1,129 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+)
+
+var discard = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+// testEvent is the seq-th event published for key
+type testEvent struct {
+	key string
+	seq int
+}
+
+func (testEvent) EventName() string { return "test" }
+
+func byKey(e Event) string { return e.(testEvent).key }
+
+func TestOrderBy(t *testing.T) {
+	bus := NewBus(discard)
+
+	var mu sync.Mutex
+	handled := make(map[string][]int)
+	bus.Subscribe("ordered", SubscribeOptions{Buffer: 16, Workers: 4, OrderBy: byKey},
+		On(func(_ context.Context, e testEvent) error {
+			// Uneven handling times reorder events handled concurrently
+			time.Sleep(time.Duration(e.seq%3) * 100 * time.Microsecond)
+			mu.Lock()
+			defer mu.Unlock()
+			handled[e.key] = append(handled[e.key], e.seq)
+			return nil
+		}),
+	)
+
+	const keys, perKey = 8, 50
+	for seq := range perKey {
+		for k := range keys {
+			bus.Publish(context.Background(), testEvent{key: fmt.Sprintf("url-%d", k), seq: seq})
+		}
+	}
+	bus.Close()
+
+	for k := range keys {
+		key := fmt.Sprintf("url-%d", k)
+		seqs := handled[key]
+		if len(seqs) != perKey {
+			t.Fatalf("%s: handled %d events, want %d", key, len(seqs), perKey)
+		}
+		for i, seq := range seqs {
+			if seq != i {
+				t.Fatalf("%s: handled %v, want publishing order", key, seqs)
+			}
+		}
+	}
+}
+
+func TestDropWhenFull(t *testing.T) {
+	bus := NewBus(discard)
+
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	bus.Subscribe("lossy", SubscribeOptions{Buffer: 1, DropWhenFull: true},
+		On(func(_ context.Context, e testEvent) error {
+			started <- struct{}{}
+			<-release
+			return nil
+		}),
+	)
+
+	publish := func(seq int) { bus.Publish(context.Background(), testEvent{seq: seq}) }
+
+	// The first event is being handled, the second waits and the rest are dropped
+	publish(1)
+	<-started
+	publish(2)
+	publish(3)
+	publish(4)
+
+	close(release)
+	bus.Close()
+
+	stats := bus.Stats()[0]
+	if stats.Delivered != 2 || stats.Dropped != 2 {
+		t.Errorf("stats = %+v, want 2 delivered and 2 dropped", stats)
+	}
+	if got := bus.Published()["test"]; got != 4 {
+		t.Errorf("published = %d, want 4", got)
+	}
+}
+
+func TestCloseDrains(t *testing.T) {
+	bus := NewBus(discard)
+
+	var mu sync.Mutex
+	handled := 0
+	bus.Subscribe("slow", SubscribeOptions{Buffer: 32, Workers: 2},
+		On(func(_ context.Context, e testEvent) error {
+			time.Sleep(time.Millisecond)
+			mu.Lock()
+			defer mu.Unlock()
+			handled++
+			return nil
+		}),
+	)
+
+	for seq := range 20 {
+		bus.Publish(context.Background(), testEvent{seq: seq})
+	}
+	bus.Close()
+
+	if handled != 20 {
+		t.Errorf("handled %d events before Close returned, want 20", handled)
+	}
+
+	// Events published after Close are discarded
+	bus.Publish(context.Background(), testEvent{seq: 20})
+	if got := bus.Published()["test"]; got != 20 {
+		t.Errorf("published = %d after Close, want 20", got)
+	}
+	if stats := bus.Stats()[0]; stats.Delivered != 20 || stats.Queued != 0 {
+		t.Errorf("stats = %+v, want 20 delivered and none queued", stats)
+	}
+}
@@ -0,0 +1,109 @@
+// Package events is the in-process event bus that lets independent subscribers
+// observe checks, URL state and the URL lifecycle
+package events
+
+import (
+	"context"
+	"time"
+
+	"url-sentinel/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// Event names
+const (
+	NameCheckCompleted  = "check_completed"
+	NameURLStateChanged = "url_state_changed"
+	NameURLCreated      = "url_created"
+	NameURLDeleted      = "url_deleted"
+	NameIncidentOpened  = "incident_opened"
+	NameContentChanged  = "content_changed"
+)
+
+// Event is anything published on the bus, its name routes it to subscribers
+type Event interface {
+	EventName() string
+}
+
+// Publisher publishes events to the bus
+type Publisher interface {
+	Publish(ctx context.Context, e Event)
+}
+
+// CheckCompleted is published for every check performed by a monitor or
+// reported by an agent, before it is stored
+type CheckCompleted struct {
+	URL           *entity.URL
+	Check         *entity.Check
+	ErrorClass    string    // why the check failed, empty on success
+	CertExpiresAt time.Time // zero when unknown
+	Recorded      bool      // already stored by the publisher, the storage subscriber skips it
+}
+
+// EventName implements Event
+func (CheckCompleted) EventName() string { return NameCheckCompleted }
+
+// URLStateChanged is published when a stored check changes the state of a URL
+type URLStateChanged struct {
+	URL    *entity.URL
+	Change *entity.StateChange
+}
+
+// EventName implements Event
+func (URLStateChanged) EventName() string { return NameURLStateChanged }
+
+// URLCreated is published when a URL is added to a project
+type URLCreated struct {
+	URL *entity.URL
+}
+
+// EventName implements Event
+func (URLCreated) EventName() string { return NameURLCreated }
+
+// URLDeleted is published when a URL is deleted, alone or with its project
+type URLDeleted struct {
+	ProjectID uuid.UUID
+	URLID     uuid.UUID
+}
+
+// EventName implements Event
+func (URLDeleted) EventName() string { return NameURLDeleted }
+
+// IncidentOpened is published when a URL goes down by quorum
+type IncidentOpened struct {
+	URL      *entity.URL
+	Incident *entity.Incident
+}
+
+// EventName implements Event
+func (IncidentOpened) EventName() string { return NameIncidentOpened }
+
+// ContentChanged is published when the content of a watched URL differs from
+// its previous snapshot
+type ContentChanged struct {
+	URL    *entity.URL
+	Change *entity.ContentChange
+}
+
+// EventName implements Event
+func (ContentChanged) EventName() string { return NameContentChanged }
+
+// ByURL keys events by the ID of their URL, for SubscribeOptions.OrderBy
+func ByURL(e Event) string {
+	switch e := e.(type) {
+	case CheckCompleted:
+		return e.Check.URLID.String()
+	case URLStateChanged:
+		return e.Change.URLID.String()
+	case URLCreated:
+		return e.URL.ID.String()
+	case URLDeleted:
+		return e.URLID.String()
+	case IncidentOpened:
+		return e.URL.ID.String()
+	case ContentChanged:
+		return e.URL.ID.String()
+	}
+	return ""
+}
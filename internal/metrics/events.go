@@ -0,0 +1,79 @@
+package metrics
+
+import (
+	"url-sentinel/internal/events"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// BusStats exposes the delivery statistics of an event bus
+type BusStats interface {
+	Published() map[string]uint64
+	Stats() []events.SubscriberStats
+}
+
+// busCollector reads the statistics of the event bus on every scrape
+type busCollector struct {
+	bus BusStats
+
+	published *prometheus.Desc
+	delivered *prometheus.Desc
+	failed    *prometheus.Desc
+	dropped   *prometheus.Desc
+	queued    *prometheus.Desc
+}
+
+// RegisterBus exports the delivery statistics of the event bus
+func (m *Metrics) RegisterBus(bus BusStats) {
+	m.registry.MustRegister(&busCollector{
+		bus: bus,
+		published: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "events", "published_total"),
+			"Total number of events published on the bus.",
+			[]string{"event"}, nil,
+		),
+		delivered: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "events", "delivered_total"),
+			"Total number of events handled by a subscriber.",
+			[]string{"subscriber"}, nil,
+		),
+		failed: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "events", "failed_total"),
+			"Total number of events a subscriber failed to handle.",
+			[]string{"subscriber"}, nil,
+		),
+		dropped: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "events", "dropped_total"),
+			"Total number of events dropped because the queue of a subscriber was full.",
+			[]string{"subscriber"}, nil,
+		),
+		queued: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "events", "queued"),
+			"Number of events waiting for a subscriber.",
+			[]string{"subscriber"}, nil,
+		),
+	})
+}
+
+// Describe implements prometheus.Collector
+func (c *busCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.published
+	ch <- c.delivered
+	ch <- c.failed
+	ch <- c.dropped
+	ch <- c.queued
+}
+
+// Collect implements prometheus.Collector
+func (c *busCollector) Collect(ch chan<- prometheus.Metric) {
+	for name, n := range c.bus.Published() {
+		ch <- prometheus.MustNewConstMetric(c.published, prometheus.CounterValue, float64(n), name)
+	}
+
+	for _, s := range c.bus.Stats() {
+		ch <- prometheus.MustNewConstMetric(c.delivered, prometheus.CounterValue, float64(s.Delivered), s.Name)
+		ch <- prometheus.MustNewConstMetric(c.failed, prometheus.CounterValue, float64(s.Failed), s.Name)
+		ch <- prometheus.MustNewConstMetric(c.dropped, prometheus.CounterValue, float64(s.Dropped), s.Name)
+		ch <- prometheus.MustNewConstMetric(c.queued, prometheus.GaugeValue, float64(s.Queued), s.Name)
+	}
+}
@@ -0,0 +1,224 @@
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"url-sentinel/internal/events"
+	"url-sentinel/internal/monitor"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	namespace = "url_sentinel"
+
+	// errorClassUnknown labels failures reported without an error class
+	errorClassUnknown = "unknown"
+)
+
+var urlLabels = []string{"url_id", "address", "location"}
+
+// MonitorStats exposes internals of a running monitor
+type MonitorStats interface {
+	ActiveWatchers() int
+	InFlight() int64
+}
+
+// Metrics exports monitor results and service internals to Prometheus
+type Metrics struct {
+	registry *prometheus.Registry
+
+	up            *prometheus.GaugeVec
+	statusCode    *prometheus.GaugeVec
+	lastDuration  *prometheus.GaugeVec
+	certExpiry    *prometheus.GaugeVec
+	checkDuration *prometheus.HistogramVec
+	checks        *prometheus.CounterVec
+	failures      *prometheus.CounterVec
+	urlLabel      *prometheus.GaugeVec
+	changes       *prometheus.CounterVec
+
+	requestDuration *prometheus.HistogramVec
+}
+
+// New creates and registers all collectors, database pool statistics only
+// when db is not nil
+func New(db *sql.DB) *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		up: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "url_up",
+			Help:      "Whether the last check of the URL succeeded (1) or failed (0).",
+		}, urlLabels),
+		statusCode: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "url_last_status_code",
+			Help:      "HTTP status code of the last check, 0 when no response was received.",
+		}, urlLabels),
+		lastDuration: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "url_last_check_duration_seconds",
+			Help:      "Duration of the last check.",
+		}, urlLabels),
+		certExpiry: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "url_cert_expiry_timestamp_seconds",
+			Help:      "Expiry of the URL's TLS certificate as a Unix timestamp.",
+		}, urlLabels),
+		checkDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "check_duration_seconds",
+			Help:      "Distribution of check durations.",
+			Buckets:   []float64{.05, .1, .25, .5, 1, 2.5, 5, 10},
+		}, urlLabels),
+		checks: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "checks_total",
+			Help:      "Total number of checks performed.",
+		}, urlLabels),
+		failures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "check_failures_total",
+			Help:      "Total number of failed checks by error class.",
+		}, append(urlLabels, "error_class")),
+		urlLabel: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "url_label",
+			Help:      "Labels of the URL, always 1. Join on url_id to group other series by label.",
+		}, []string{"url_id", "key", "value"}),
+		changes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "content_changes_total",
+			Help:      "Total number of content changes detected on watched URLs.",
+		}, []string{"url_id", "address"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Latency of API requests.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+	}
+
+	m.registry.MustRegister(
+		m.up,
+		m.statusCode,
+		m.lastDuration,
+		m.certExpiry,
+		m.checkDuration,
+		m.checks,
+		m.failures,
+		m.urlLabel,
+		m.changes,
+		m.requestDuration,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+
+	if db != nil {
+		m.registry.MustRegister(collectors.NewDBStatsCollector(db, "url_sentinel"))
+	}
+
+	return m
+}
+
+// RegisterMonitor exports internals of the running monitor
+func (m *Metrics) RegisterMonitor(mon MonitorStats) {
+	m.registry.MustRegister(
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "monitor_active_watchers",
+			Help:      "Number of URLs currently being monitored.",
+		}, func() float64 { return float64(mon.ActiveWatchers()) }),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "monitor_checks_in_flight",
+			Help:      "Number of checks currently in progress.",
+		}, func() float64 { return float64(mon.InFlight()) }),
+	)
+}
+
+// Handler returns the HTTP handler serving the metrics endpoint
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// CheckCompleted records the result of a check
+func (m *Metrics) CheckCompleted(_ context.Context, e events.CheckCompleted) error {
+	url, check := e.URL, e.Check
+	labels := prometheus.Labels{
+		"url_id":   url.ID.String(),
+		"address":  url.Address,
+		"location": check.Location,
+	}
+	seconds := check.Duration.Seconds()
+
+	up := 0.0
+	if check.Status {
+		up = 1
+	}
+
+	m.up.With(labels).Set(up)
+	m.statusCode.With(labels).Set(float64(check.Code))
+	m.lastDuration.With(labels).Set(seconds)
+	m.checkDuration.With(labels).Observe(seconds)
+	m.checks.With(labels).Inc()
+
+	m.urlLabel.DeletePartialMatch(prometheus.Labels{"url_id": url.ID.String()})
+	for key, value := range url.Labels {
+		m.urlLabel.WithLabelValues(url.ID.String(), key, value).Set(1)
+	}
+
+	if !e.CertExpiresAt.IsZero() {
+		m.certExpiry.With(labels).Set(float64(e.CertExpiresAt.Unix()))
+	}
+
+	// Checks reported without an error class, e.g. by older agents
+	errorClass := e.ErrorClass
+	if errorClass == monitor.ErrorClassNone && !check.Status {
+		errorClass = errorClassUnknown
+	}
+	if errorClass != monitor.ErrorClassNone {
+		failureLabels := prometheus.Labels{"error_class": errorClass}
+		for k, v := range labels {
+			failureLabels[k] = v
+		}
+		m.failures.With(failureLabels).Inc()
+	}
+
+	return nil
+}
+
+// URLDeleted drops all series of a URL that is no longer monitored
+func (m *Metrics) URLDeleted(_ context.Context, e events.URLDeleted) error {
+	labels := prometheus.Labels{"url_id": e.URLID.String()}
+
+	m.up.DeletePartialMatch(labels)
+	m.statusCode.DeletePartialMatch(labels)
+	m.lastDuration.DeletePartialMatch(labels)
+	m.certExpiry.DeletePartialMatch(labels)
+	m.checkDuration.DeletePartialMatch(labels)
+	m.checks.DeletePartialMatch(labels)
+	m.failures.DeletePartialMatch(labels)
+	m.urlLabel.DeletePartialMatch(labels)
+	m.changes.DeletePartialMatch(labels)
+
+	return nil
+}
+
+// ContentChanged counts a content change of a watched URL
+func (m *Metrics) ContentChanged(_ context.Context, e events.ContentChanged) error {
+	m.changes.WithLabelValues(e.URL.ID.String(), e.URL.Address).Inc()
+	return nil
+}
+
+// ObserveRequest records the latency of an API request
+func (m *Metrics) ObserveRequest(method, route string, status int, duration time.Duration) {
+	m.requestDuration.WithLabelValues(method, route, strconv.Itoa(status)).Observe(duration.Seconds())
+}
@@ -0,0 +1,372 @@
+package monitor
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"net/netip"
+	"strings"
+	"syscall"
+	"time"
+
+	"url-sentinel/internal/domain/entity"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+// Error classes reported for failed checks
+const (
+	ErrorClassNone              = ""
+	ErrorClassTimeout           = "timeout"
+	ErrorClassDNS               = "dns"
+	ErrorClassConnectionRefused = "connection_refused"
+	ErrorClassTLS               = "tls"
+	ErrorClassConnection        = "connection"
+	ErrorClassBlocked           = "blocked" // the dry run was refused to connect to an internal address
+	ErrorClassHTTPStatus        = "http_status"
+	ErrorClassRedirect          = "redirect"        // a redirect was not followed or the redirects broke the URL's expectations
+	ErrorClassContentChanged    = "content_changed" // the watched content changed and the URL alerts on change
+)
+
+// Result holds the outcome of a single check
+type Result struct {
+	Check         *entity.Check
+	Err           error                   // transport error, nil when a response was received
+	ErrorClass    string                  // why the check failed, empty on success
+	CertExpiresAt time.Time               // expiry of the leaf TLS certificate, zero for plain HTTP
+	Content       *entity.ContentSnapshot // normalized body of a successful check of a watched URL
+}
+
+// errBlockedAddress is returned when a dry run would connect to an internal address
+var errBlockedAddress = errors.New("connecting to loopback, private or link-local addresses is not allowed")
+
+// Checker performs HTTP health checks on behalf of a single location
+type Checker struct {
+	client     *http.Client
+	testClient *http.Client // dry runs, restricted to public addresses
+	location   string
+}
+
+// NewChecker creates a new checker tagging its results with the given location.
+// Outbound requests carry the traceparent header of the current span.
+func NewChecker(location string, timeout time.Duration) *Checker {
+	// Dry runs return the response to the API client, so they must not reach
+	// the network of the monitoring host. The address is checked after DNS
+	// resolution and proxies are not used, so the dialed address is the target.
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = (&net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   denyInternalAddress,
+	}).DialContext
+
+	return &Checker{
+		client: &http.Client{
+			Timeout:   timeout,
+			Transport: otelhttp.NewTransport(http.DefaultTransport),
+		},
+		testClient: &http.Client{
+			Timeout:   timeout,
+			Transport: otelhttp.NewTransport(transport),
+		},
+		location: location,
+	}
+}
+
+// deniedPrefixes are the address ranges test checks may not connect to
+var deniedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),      // this network
+	netip.MustParsePrefix("10.0.0.0/8"),     // private
+	netip.MustParsePrefix("100.64.0.0/10"),  // carrier-grade NAT
+	netip.MustParsePrefix("127.0.0.0/8"),    // loopback
+	netip.MustParsePrefix("169.254.0.0/16"), // link-local, including the cloud metadata endpoint
+	netip.MustParsePrefix("172.16.0.0/12"),  // private
+	netip.MustParsePrefix("192.0.0.0/24"),   // IETF protocol assignments
+	netip.MustParsePrefix("192.168.0.0/16"), // private
+	netip.MustParsePrefix("198.18.0.0/15"),  // benchmarking
+	netip.MustParsePrefix("224.0.0.0/4"),    // multicast
+	netip.MustParsePrefix("240.0.0.0/4"),    // reserved, including broadcast
+	netip.MustParsePrefix("::/128"),         // unspecified
+	netip.MustParsePrefix("::1/128"),        // loopback
+	netip.MustParsePrefix("64:ff9b:1::/48"), // local-use NAT64
+	netip.MustParsePrefix("fc00::/7"),       // unique local
+	netip.MustParsePrefix("fe80::/10"),      // link-local
+	netip.MustParsePrefix("ff00::/8"),       // multicast
+}
+
+var (
+	nat64Prefix     = netip.MustParsePrefix("64:ff9b::/96")
+	sixToFourPrefix = netip.MustParsePrefix("2002::/16")
+)
+
+// denyInternalAddress refuses connections to addresses in deniedPrefixes,
+// also when they are reached through an IPv4-mapped, NAT64 or 6to4 address
+func denyInternalAddress(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return errBlockedAddress
+	}
+
+	// Prefixes never contain addresses with a zone
+	ip := addrPort.Addr().WithZone("").Unmap()
+	if b := ip.As16(); nat64Prefix.Contains(ip) {
+		ip = netip.AddrFrom4([4]byte(b[12:16]))
+	} else if sixToFourPrefix.Contains(ip) {
+		ip = netip.AddrFrom4([4]byte(b[2:6]))
+	}
+
+	for _, prefix := range deniedPrefixes {
+		if prefix.Contains(ip) {
+			return errBlockedAddress
+		}
+	}
+	return nil
+}
+
+// Location returns the location this checker reports from
+func (c *Checker) Location() string {
+	return c.location
+}
+
+// maxReportBody is how much of the response body a check report keeps
+const maxReportBody = 64 << 10
+
+// Check executes a single health check against the URL
+func (c *Checker) Check(ctx context.Context, url *entity.URL) (*Result, error) {
+	res, _, err := c.do(ctx, url, entity.CheckRequest{}, false)
+	return res, err
+}
+
+// Test executes a one-off check with a custom request and evaluates the
+// assertions against the response. Nothing is recorded. Internal addresses
+// are refused with ErrorClassBlocked.
+func (c *Checker) Test(
+	ctx context.Context,
+	url *entity.URL,
+	request entity.CheckRequest,
+	assertions []entity.Assertion,
+) (*entity.CheckReport, error) {
+	res, resp, err := c.do(ctx, url, request, true)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &entity.CheckReport{
+		Check:         res.Check,
+		ErrorClass:    res.ErrorClass,
+		Timings:       resp.timings,
+		Headers:       resp.headers,
+		FinalURL:      resp.finalURL,
+		Body:          string(resp.body),
+		BodyTruncated: resp.truncated,
+		Passed:        res.Check.Status,
+	}
+	if res.Err != nil {
+		report.Error = res.Err.Error()
+		return report, nil
+	}
+
+	snapshot := &entity.Response{
+		Code:      res.Check.Code,
+		Headers:   resp.headers,
+		Body:      resp.body,
+		Duration:  res.Check.Duration,
+		Redirects: res.Check.Redirects,
+		FinalURL:  resp.finalURL,
+	}
+	for _, a := range assertions {
+		result := a.Evaluate(snapshot)
+		report.Passed = report.Passed && result.Passed
+		report.Assertions = append(report.Assertions, result)
+	}
+
+	return report, nil
+}
+
+// response holds the details of a response captured for a check report
+type response struct {
+	timings   entity.Timings
+	headers   map[string][]string
+	finalURL  string
+	body      []byte
+	truncated bool
+}
+
+// do sends the request and builds the result, capturing timings, headers
+// and the beginning of the body when capture is set. The content of watched
+// URLs is snapshotted otherwise. Redirects are followed as the URL's policy
+// allows, recorded on the check and held against the URL's expectations.
+func (c *Checker) do(
+	ctx context.Context,
+	url *entity.URL,
+	request entity.CheckRequest,
+	capture bool,
+) (*Result, *response, error) {
+	method := request.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	var body io.Reader
+	if request.Body != "" {
+		body = strings.NewReader(request.Body)
+	}
+
+	captured := &response{}
+	if capture {
+		ctx = httptrace.WithClientTrace(ctx, newTimingTrace(&captured.timings))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url.Address, body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	for name, value := range request.Headers {
+		req.Header.Set(name, value)
+	}
+
+	// The client is copied to record the redirects of this request only
+	var redirects []entity.Redirect
+	limit := url.Redirects.MaxRedirects()
+	client := *c.client
+	if capture {
+		client = *c.testClient
+	}
+	client.CheckRedirect = func(next *http.Request, via []*http.Request) error {
+		if len(via) > limit {
+			return http.ErrUseLastResponse
+		}
+		redirects = append(redirects, entity.Redirect{Code: next.Response.StatusCode, Location: next.URL.String()})
+		return nil
+	}
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	// The response time ends with the response headers, so reading the body of
+	// content-watched URLs or dry runs does not count towards it
+	duration := time.Since(start)
+
+	res := &Result{Err: err}
+	status := false
+	code := 0
+
+	if err != nil {
+		res.ErrorClass = classifyError(err)
+	} else {
+		defer resp.Body.Close()
+		code = resp.StatusCode
+		status = resp.StatusCode >= 200 && resp.StatusCode < 300
+		captured.finalURL = resp.Request.URL.String()
+		if location, err := resp.Location(); err == nil && resp.StatusCode >= 300 && resp.StatusCode < 400 {
+			// Stopped by the redirect policy
+			redirects = append(redirects, entity.Redirect{Code: resp.StatusCode, Location: location.String()})
+			res.ErrorClass = ErrorClassRedirect
+		} else if !status {
+			res.ErrorClass = ErrorClassHTTPStatus
+		} else if !redirectsExpected(url, redirects, captured.finalURL) {
+			status = false
+			res.ErrorClass = ErrorClassRedirect
+		}
+		if resp.TLS != nil && len(resp.TLS.PeerCertificates) > 0 {
+			res.CertExpiresAt = resp.TLS.PeerCertificates[0].NotAfter
+		}
+		if capture {
+			captured.headers = resp.Header
+			captured.body, err = io.ReadAll(io.LimitReader(resp.Body, maxReportBody+1))
+			if err != nil {
+				res.Err = err
+				res.ErrorClass = classifyError(err)
+				status = false
+			}
+			if len(captured.body) > maxReportBody {
+				captured.body = captured.body[:maxReportBody]
+				captured.truncated = true
+			}
+		} else if status && url.ContentWatch != nil {
+			res.Content, err = readContent(resp, url)
+			if err != nil {
+				res.Err = err
+				res.ErrorClass = classifyError(err)
+				status = false
+			}
+		}
+	}
+
+	captured.timings.Total = time.Since(start)
+
+	res.Check = entity.NewCheck(url.ID, c.location, status, code, duration)
+	res.Check.Redirects = redirects
+
+	return res, captured, nil
+}
+
+// redirectsExpected reports whether a response reached after the redirects
+// satisfies the final URL and redirect domain the URL expects
+func redirectsExpected(url *entity.URL, redirects []entity.Redirect, finalURL string) bool {
+	resp := &entity.Response{Redirects: redirects, FinalURL: finalURL}
+	for _, a := range url.RedirectAssertions() {
+		if !a.Evaluate(resp).Passed {
+			return false
+		}
+	}
+	return true
+}
+
+// newTimingTrace records the duration of each request phase into t
+func newTimingTrace(t *entity.Timings) *httptrace.ClientTrace {
+	var dnsStart, connectStart, tlsStart, wroteRequest time.Time
+
+	return &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) { dnsStart = time.Now() },
+		DNSDone:  func(httptrace.DNSDoneInfo) { t.DNS = time.Since(dnsStart) },
+		ConnectStart: func(string, string) {
+			if connectStart.IsZero() {
+				connectStart = time.Now()
+			}
+		},
+		ConnectDone: func(_, _ string, err error) {
+			if err == nil && t.Connect == 0 {
+				t.Connect = time.Since(connectStart)
+			}
+		},
+		TLSHandshakeStart:    func() { tlsStart = time.Now() },
+		TLSHandshakeDone:     func(tls.ConnectionState, error) { t.TLS = time.Since(tlsStart) },
+		WroteRequest:         func(httptrace.WroteRequestInfo) { wroteRequest = time.Now() },
+		GotFirstResponseByte: func() { t.FirstByte = time.Since(wroteRequest) },
+	}
+}
+
+// classifyError maps a transport error to one of the error classes
+func classifyError(err error) string {
+	var dnsErr *net.DNSError
+	var certErr *tls.CertificateVerificationError
+	var unknownAuthErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var invalidErr x509.CertificateInvalidError
+	var netErr net.Error
+
+	switch {
+	case errors.Is(err, errBlockedAddress):
+		return ErrorClassBlocked
+	case errors.As(err, &dnsErr):
+		return ErrorClassDNS
+	case errors.As(err, &certErr),
+		errors.As(err, &unknownAuthErr),
+		errors.As(err, &hostnameErr),
+		errors.As(err, &invalidErr):
+		return ErrorClassTLS
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return ErrorClassConnectionRefused
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		return ErrorClassTimeout
+	default:
+		return ErrorClassConnection
+	}
+}
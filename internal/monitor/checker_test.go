@@ -0,0 +1,146 @@
+package monitor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"url-sentinel/internal/domain/entity"
+)
+
+func TestDenyInternalAddress(t *testing.T) {
+	tests := []struct {
+		address string
+		blocked bool
+	}{
+		{"93.184.215.14:443", false},
+		{"[2606:2800:21f:cb07:6820:80da:af6b:8b2c]:443", false},
+		{"0.0.0.0:80", true},
+		{"0.1.2.3:80", true},
+		{"10.1.2.3:80", true},
+		{"100.64.0.1:80", true},
+		{"100.127.255.254:80", true},
+		{"100.128.0.1:80", false},
+		{"127.0.0.1:80", true},
+		{"169.254.169.254:80", true},
+		{"172.16.0.1:80", true},
+		{"172.32.0.1:80", false},
+		{"192.0.0.170:80", true},
+		{"192.168.1.1:80", true},
+		{"198.18.0.1:80", true},
+		{"198.19.255.255:80", true},
+		{"198.20.0.1:80", false},
+		{"224.0.0.1:80", true},
+		{"239.255.255.250:1900", true},
+		{"255.255.255.255:80", true},
+		{"[::]:80", true},
+		{"[::1]:80", true},
+		{"[::ffff:127.0.0.1]:80", true},
+		{"[::ffff:8.8.8.8]:80", false},
+		{"[fd00::1]:80", true},
+		{"[fe80::1%eth0]:80", true},
+		{"[ff02::1]:80", true},
+		{"[64:ff9b::a00:1]:80", true},     // NAT64 of 10.0.0.1
+		{"[64:ff9b::a9fe:a9fe]:80", true}, // NAT64 of 169.254.169.254
+		{"[64:ff9b::808:808]:80", false},  // NAT64 of 8.8.8.8
+		{"[64:ff9b:1::1]:80", true},
+		{"[2002:c0a8:101::1]:80", true}, // 6to4 of 192.168.1.1
+		{"[2002:7f00:1::1]:80", true},   // 6to4 of 127.0.0.1
+		{"[2002:808:808::1]:80", false}, // 6to4 of 8.8.8.8
+		{"not an address", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			err := denyInternalAddress("tcp", tt.address, nil)
+			if blocked := errors.Is(err, errBlockedAddress); blocked != tt.blocked {
+				t.Errorf("denyInternalAddress(%q) = %v, want blocked %t", tt.address, err, tt.blocked)
+			}
+		})
+	}
+}
+
+// newRedirectServer serves /hops/N redirecting N times before answering 200
+func newRedirectServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hops, err := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/hops/"))
+		if err != nil || hops == 0 {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		http.Redirect(w, r, fmt.Sprintf("/hops/%d", hops-1), http.StatusFound)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestCheckRedirectLimit(t *testing.T) {
+	server := newRedirectServer(t)
+	checker := NewChecker("test", time.Second)
+
+	tests := []struct {
+		name      string
+		hops      int
+		policy    entity.RedirectPolicy
+		code      int
+		redirects int // recorded, including the one a check stopped at
+	}{
+		{"within limit", 3, 3, http.StatusOK, 3},
+		{"above limit", 5, 3, http.StatusFound, 4},
+		{"off", 2, entity.NoRedirects, http.StatusFound, 1},
+		{"default limit", entity.DefaultMaxRedirects, entity.FollowRedirects, http.StatusOK, entity.DefaultMaxRedirects},
+		{"above default limit", entity.DefaultMaxRedirects + 1, entity.FollowRedirects, http.StatusFound, entity.DefaultMaxRedirects + 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			url := newTestURL(t, fmt.Sprintf("%s/hops/%d", server.URL, tt.hops), time.Minute)
+			url.Redirects = tt.policy
+
+			res, err := checker.Check(context.Background(), url)
+			if err != nil {
+				t.Fatalf("Check: %v", err)
+			}
+			if res.Check.Code != tt.code || len(res.Check.Redirects) != tt.redirects {
+				t.Errorf("code %d after %d redirects, want %d after %d", res.Check.Code, len(res.Check.Redirects), tt.code, tt.redirects)
+			}
+			if stopped := tt.code != http.StatusOK; stopped != (res.ErrorClass == ErrorClassRedirect) {
+				t.Errorf("error class = %q, stopped by the policy: %t", res.ErrorClass, stopped)
+			}
+		})
+	}
+}
+
+func TestCheckDurationExcludesContent(t *testing.T) {
+	const bodyDelay = 300 * time.Millisecond
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(http.StatusOK)
+		w.(http.Flusher).Flush()
+		time.Sleep(bodyDelay)
+		fmt.Fprint(w, "slow body")
+	}))
+	t.Cleanup(server.Close)
+
+	url := newTestURL(t, server.URL, time.Minute)
+	url.ContentWatch = &entity.ContentWatch{}
+
+	res, err := NewChecker("test", time.Second).Check(context.Background(), url)
+	if err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	if res.Content == nil {
+		t.Fatal("content of the watched URL was not read")
+	}
+	if res.Check.Duration >= bodyDelay {
+		t.Errorf("duration = %s, want the time until the response headers, below %s", res.Check.Duration, bodyDelay)
+	}
+}
@@ -0,0 +1,139 @@
+package monitor
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"url-sentinel/internal/domain/entity"
+
+	"github.com/andybalholm/cascadia"
+	"golang.org/x/net/html"
+)
+
+// maxContentBody is how much of the response body is compared for content changes
+const maxContentBody = 1 << 20
+
+// hiddenElements never contribute to the text of an HTML page
+var hiddenElements = map[string]bool{
+	"script": true, "style": true, "noscript": true, "template": true,
+}
+
+// blockElements start a new line of text
+var blockElements = map[string]bool{
+	"address": true, "article": true, "aside": true, "blockquote": true, "br": true,
+	"dd": true, "div": true, "dl": true, "dt": true, "footer": true, "form": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"header": true, "hr": true, "li": true, "main": true, "nav": true, "ol": true,
+	"p": true, "pre": true, "section": true, "table": true, "td": true, "th": true,
+	"tr": true, "ul": true,
+}
+
+// readContent reads the response body of a watched URL and snapshots its normalized text
+func readContent(resp *http.Response, url *entity.URL) (*entity.ContentSnapshot, error) {
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxContentBody))
+	if err != nil {
+		return nil, err
+	}
+
+	text := normalizeContent(body, resp.Header.Get("Content-Type"), url.ContentWatch)
+
+	return entity.NewContentSnapshot(url.ID, text), nil
+}
+
+// normalizeContent reduces a response body to the text compared between
+// checks. HTML is reduced to its visible text without the ignored elements,
+// every line has its whitespace collapsed, text matching the ignore patterns
+// is removed and empty lines are dropped. Rules that do not compile are
+// skipped, they are rejected when the URL is saved.
+func normalizeContent(body []byte, contentType string, watch *entity.ContentWatch) string {
+	text := string(body)
+	if isHTML(body, contentType) {
+		text = htmlText(body, watch.IgnoreSelectors)
+	}
+	// Keep the text storable whatever the encoding of the body
+	text = strings.ReplaceAll(strings.ToValidUTF8(text, "\uFFFD"), "\x00", "")
+
+	var patterns []*regexp.Regexp
+	for _, pattern := range watch.IgnorePatterns {
+		if re, err := regexp.Compile(pattern); err == nil {
+			patterns = append(patterns, re)
+		}
+	}
+
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		for _, re := range patterns {
+			line = re.ReplaceAllString(line, "")
+		}
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// isHTML reports whether the body is an HTML document, sniffing it when the
+// response has no content type
+func isHTML(body []byte, contentType string) bool {
+	if contentType == "" {
+		contentType = http.DetectContentType(body)
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "text/html" || mediaType == "application/xhtml+xml"
+}
+
+// htmlText extracts the visible text of an HTML document leaving out the
+// elements matching the selectors, block elements start a new line
+func htmlText(body []byte, selectors []string) string {
+	doc, err := html.Parse(bytes.NewReader(body))
+	if err != nil {
+		return string(body)
+	}
+
+	ignored := make(map[*html.Node]bool)
+	for _, selector := range selectors {
+		group, err := cascadia.ParseGroup(selector)
+		if err != nil {
+			continue
+		}
+		for _, node := range cascadia.QueryAll(doc, group) {
+			ignored[node] = true
+		}
+	}
+
+	var b strings.Builder
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		switch n.Type {
+		case html.TextNode:
+			b.WriteString(n.Data)
+			return
+		case html.ElementNode:
+			if ignored[n] || hiddenElements[n.Data] {
+				return
+			}
+		}
+
+		block := n.Type == html.ElementNode && blockElements[n.Data]
+		if block {
+			b.WriteByte('\n')
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+		if block {
+			b.WriteByte('\n')
+		}
+	}
+	walk(doc)
+
+	return b.String()
+}
@@ -3,58 +3,117 @@ package monitor
 import (
 	"context"
 	"log/slog"
-	"net/http"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"url-sentinel/internal/domain/entity"
-	"url-sentinel/internal/domain/repository"
+	"url-sentinel/internal/events"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
-// Monitor periodically checks URLs and records results
+var tracer = otel.Tracer("url-sentinel/monitor")
+
+// URLSource provides the set of URLs to monitor
+type URLSource interface {
+	List(ctx context.Context) ([]*entity.URL, error)
+}
+
+// URLSourceFunc adapts a function to the URLSource interface
+type URLSourceFunc func(ctx context.Context) ([]*entity.URL, error)
+
+// List calls f(ctx)
+func (f URLSourceFunc) List(ctx context.Context) ([]*entity.URL, error) {
+	return f(ctx)
+}
+
+// ContentTracker compares the content snapshot of a check with the previous
+// one of the URL and returns the change it detected, nil when there is none
+type ContentTracker interface {
+	TrackContent(ctx context.Context, url *entity.URL, snapshot *entity.ContentSnapshot) (*entity.ContentChange, error)
+}
+
+// Monitor periodically checks URLs and publishes the results, storing and
+// observing them is left to the subscribers of the bus
 type Monitor struct {
-	urlRepo   repository.URLRepository
-	checkRepo repository.CheckRepository
-	client    *http.Client
-	logger    *slog.Logger
+	urls    URLSource
+	checker *Checker
+	content ContentTracker
+	events  events.Publisher
+	logger  *slog.Logger
 
 	mu       sync.RWMutex
-	watchers map[string]context.CancelFunc // urlID -> cancel function
+	ctx      context.Context     // parent of the watchers, set by Start
+	watchers map[string]*watcher // urlID -> running watcher
+	inFlight atomic.Int64
+}
+
+// watcher controls the goroutine checking a single URL
+type watcher struct {
+	url    *entity.URL // settings the URL is checked with
+	cancel context.CancelFunc
+	reset  chan struct{} // restarts the ticker after a manual check
 }
 
-// NewMonitor creates a new monitor instance
-func NewMonitor(
-	urlRepo repository.URLRepository,
-	checkRepo repository.CheckRepository,
-	logger *slog.Logger,
-) *Monitor {
+// NewMonitor creates a new monitor instance publishing CheckCompleted events.
+// Content changes of watched URLs are detected only when content is not nil.
+func NewMonitor(urls URLSource, checker *Checker, content ContentTracker, publisher events.Publisher, logger *slog.Logger) *Monitor {
 	return &Monitor{
-		urlRepo:   urlRepo,
-		checkRepo: checkRepo,
-		client:    &http.Client{Timeout: 10 * time.Second},
-		logger:    logger,
-		watchers:  make(map[string]context.CancelFunc),
+		urls:     urls,
+		checker:  checker,
+		content:  content,
+		events:   publisher,
+		logger:   logger,
+		ctx:      context.Background(),
+		watchers: make(map[string]*watcher),
 	}
 }
 
-// Start initializes monitoring for all URLs in the database
+// ActiveWatchers returns the number of URLs currently being monitored
+func (m *Monitor) ActiveWatchers() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return len(m.watchers)
+}
+
+// InFlight returns the number of checks currently in progress
+func (m *Monitor) InFlight() int64 {
+	return m.inFlight.Load()
+}
+
+// Start initializes monitoring for all URLs in the database. Watchers run
+// until ctx is cancelled, including the ones added later.
 func (m *Monitor) Start(ctx context.Context) error {
-	urls, err := m.urlRepo.List(ctx)
+	m.mu.Lock()
+	m.ctx = ctx
+	m.mu.Unlock()
+
+	urls, err := m.urls.List(ctx)
 	if err != nil {
 		m.logger.Error("failed to list urls for monitoring", slog.Any("error", err))
 		return err
 	}
 
 	for _, url := range urls {
-		m.AddURL(ctx, url)
+		m.AddURL(url)
 	}
 
 	m.logger.Info("monitor started", slog.Int("urls", len(urls)))
 	return nil
 }
 
-// AddURL adds a new URL to monitoring
-func (m *Monitor) AddURL(parentCtx context.Context, url *entity.URL) {
+// AddURL adds a new URL to monitoring, paused URLs are ignored
+func (m *Monitor) AddURL(url *entity.URL) {
+	if url.Paused {
+		return
+	}
+
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
@@ -67,11 +126,12 @@ func (m *Monitor) AddURL(parentCtx context.Context, url *entity.URL) {
 	}
 
 	// Create cancellable context for this URL
-	ctx, cancel := context.WithCancel(parentCtx)
-	m.watchers[urlIDStr] = cancel
+	ctx, cancel := context.WithCancel(m.ctx)
+	w := &watcher{url: url, cancel: cancel, reset: make(chan struct{}, 1)}
+	m.watchers[urlIDStr] = w
 
 	// Start monitoring in a goroutine
-	go m.watchURL(ctx, url)
+	go m.watchURL(ctx, url, w.reset)
 
 	m.logger.Info("started monitoring url",
 		slog.String("url_id", urlIDStr),
@@ -80,13 +140,66 @@ func (m *Monitor) AddURL(parentCtx context.Context, url *entity.URL) {
 	)
 }
 
+// Sync reconciles running watchers with the current set of URLs. A URL whose
+// settings changed is restarted with the new ones, a paused URL is stopped.
+func (m *Monitor) Sync(ctx context.Context) error {
+	urls, err := m.urls.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	wanted := make(map[string]struct{}, len(urls))
+	for _, url := range urls {
+		if url.Paused {
+			continue
+		}
+		urlID := url.ID.String()
+		wanted[urlID] = struct{}{}
+
+		watched := m.watched(urlID)
+		switch {
+		case watched == nil:
+			m.AddURL(url)
+		case !watched.Definition().Equal(url.Definition()):
+			m.RemoveURL(urlID)
+			m.AddURL(url)
+		}
+	}
+
+	m.mu.RLock()
+	var stale []string
+	for urlID := range m.watchers {
+		if _, ok := wanted[urlID]; !ok {
+			stale = append(stale, urlID)
+		}
+	}
+	m.mu.RUnlock()
+
+	for _, urlID := range stale {
+		m.RemoveURL(urlID)
+	}
+
+	return nil
+}
+
+// watched returns the URL as it is being checked, nil when it is not monitored
+func (m *Monitor) watched(urlID string) *entity.URL {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if w, exists := m.watchers[urlID]; exists {
+		return w.url
+	}
+	return nil
+}
+
 // RemoveURL stops monitoring a URL
 func (m *Monitor) RemoveURL(urlID string) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	if cancel, exists := m.watchers[urlID]; exists {
-		cancel()
+	if w, exists := m.watchers[urlID]; exists {
+		w.cancel()
 		delete(m.watchers, urlID)
 		m.logger.Info("stopped monitoring url", slog.String("url_id", urlID))
 	}
@@ -97,76 +210,184 @@ func (m *Monitor) Stop() {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	for urlID, cancel := range m.watchers {
-		cancel()
+	for urlID, w := range m.watchers {
+		w.cancel()
 		m.logger.Info("stopped monitoring url", slog.String("url_id", urlID))
 	}
 
-	m.watchers = make(map[string]context.CancelFunc)
+	m.watchers = make(map[string]*watcher)
 	m.logger.Info("monitor stopped")
 }
 
+// CheckNow checks a URL immediately and publishes the result as a manual check.
+// When record is set, it stores the result and publishes it instead, so the
+// check is persisted before it is returned. If the URL is being monitored, its
+// next scheduled check is postponed by a full interval so that it does not
+// fire right after.
+func (m *Monitor) CheckNow(
+	ctx context.Context,
+	url *entity.URL,
+	record func(context.Context, events.CheckCompleted) error,
+) (*entity.Check, error) {
+	m.mu.RLock()
+	if w, exists := m.watchers[url.ID.String()]; exists {
+		select {
+		case w.reset <- struct{}{}:
+		default: // a reset is already pending
+		}
+	}
+	m.mu.RUnlock()
+
+	return m.performCheck(ctx, url, entity.TriggerManual, record)
+}
+
+// TestCheck checks a URL once with a custom request and evaluates the
+// assertions against the response. The result is not published.
+func (m *Monitor) TestCheck(
+	ctx context.Context,
+	url *entity.URL,
+	request entity.CheckRequest,
+	assertions []entity.Assertion,
+) (*entity.CheckReport, error) {
+	ctx, span := tracer.Start(ctx, "check", trace.WithAttributes(
+		attribute.String("url_id", url.ID.String()),
+		attribute.String("trigger", string(entity.TriggerTest)),
+		semconv.URLFull(url.Address),
+	))
+	defer span.End()
+
+	report, err := m.checker.Test(ctx, url, request, assertions)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	report.Check.Trigger = entity.TriggerTest
+
+	span.SetAttributes(
+		semconv.HTTPResponseStatusCode(report.Check.Code),
+		attribute.String("error_class", report.ErrorClass),
+		attribute.Bool("passed", report.Passed),
+	)
+
+	return report, nil
+}
+
 // watchURL performs periodic checks on a single URL
-func (m *Monitor) watchURL(ctx context.Context, url *entity.URL) {
+func (m *Monitor) watchURL(ctx context.Context, url *entity.URL, reset <-chan struct{}) {
 	ticker := time.NewTicker(url.CheckInterval)
 	defer ticker.Stop()
 
 	// Perform initial check immediately
-	m.performCheck(ctx, url)
+	_, _ = m.performCheck(ctx, url, entity.TriggerScheduled, nil)
 
 	for {
 		select {
 		case <-ctx.Done():
 			return
+		case <-reset:
+			ticker.Reset(url.CheckInterval)
 		case <-ticker.C:
-			m.performCheck(ctx, url)
+			_, _ = m.performCheck(ctx, url, entity.TriggerScheduled, nil)
 		}
 	}
 }
 
-// performCheck executes a single health check
-func (m *Monitor) performCheck(ctx context.Context, url *entity.URL) {
-	start := time.Now()
+// performCheck executes a single health check and publishes its result, or
+// hands it to record when set. Failures are logged, so scheduled checks may
+// ignore the returned error.
+func (m *Monitor) performCheck(
+	ctx context.Context,
+	url *entity.URL,
+	trigger entity.Trigger,
+	record func(context.Context, events.CheckCompleted) error,
+) (*entity.Check, error) {
+	m.inFlight.Add(1)
+	defer m.inFlight.Add(-1)
+
+	ctx, span := tracer.Start(ctx, "check", trace.WithAttributes(
+		attribute.String("url_id", url.ID.String()),
+		attribute.String("trigger", string(trigger)),
+		semconv.URLFull(url.Address),
+	))
+	defer span.End()
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url.Address, nil)
+	res, err := m.checker.Check(ctx, url)
 	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
 		m.logger.Error("failed to create request",
 			slog.String("url", url.Address),
 			slog.Any("error", err),
 		)
-		return
+		return nil, err
 	}
+	res.Check.Trigger = trigger
 
-	resp, err := m.client.Do(req)
-	duration := time.Since(start)
-
-	status := false
-	code := 0
+	if res.Content != nil && m.content != nil {
+		m.trackContent(ctx, url, res)
+	}
 
-	if err != nil {
+	if res.Err != nil {
 		m.logger.Debug("check failed",
 			slog.String("url", url.Address),
-			slog.Any("error", err),
+			slog.String("error_class", res.ErrorClass),
+			slog.Any("error", res.Err),
 		)
+	}
+
+	span.SetAttributes(
+		semconv.HTTPResponseStatusCode(res.Check.Code),
+		attribute.String("error_class", res.ErrorClass),
+		attribute.String("location", res.Check.Location),
+	)
+	if res.ErrorClass != ErrorClassNone {
+		span.SetStatus(codes.Error, res.ErrorClass)
+	}
+
+	check := res.Check
+	completed := events.CheckCompleted{
+		URL:           url,
+		Check:         check,
+		ErrorClass:    res.ErrorClass,
+		CertExpiresAt: res.CertExpiresAt,
+	}
+	if record != nil {
+		if err := record(ctx, completed); err != nil {
+			m.logger.Error("failed to record check",
+				slog.String("url", url.Address),
+				slog.Any("error", err),
+			)
+			return nil, err
+		}
 	} else {
-		defer resp.Body.Close()
-		code = resp.StatusCode
-		status = resp.StatusCode >= 200 && resp.StatusCode < 300
+		m.events.Publish(ctx, completed)
 	}
 
-	// Save check result
-	check := entity.NewCheck(url.ID, status, code, duration)
-	if err := m.checkRepo.Create(ctx, check); err != nil {
-		m.logger.Error("failed to save check result",
+	m.logger.Debug("check completed",
+		slog.String("url", url.Address),
+		slog.String("location", check.Location),
+		slog.String("trigger", string(check.Trigger)),
+		slog.Int("code", check.Code),
+		slog.Bool("status", check.Status),
+		slog.Duration("duration", check.Duration),
+	)
+
+	return check, nil
+}
+
+// trackContent compares the content of a check with the previous snapshot,
+// failing the check when the content changed and the URL alerts on change
+func (m *Monitor) trackContent(ctx context.Context, url *entity.URL, res *Result) {
+	change, err := m.content.TrackContent(ctx, url, res.Content)
+	if err != nil {
+		m.logger.Error("failed to track content",
 			slog.String("url", url.Address),
 			slog.Any("error", err),
 		)
-	} else {
-		m.logger.Debug("check completed",
-			slog.String("url", url.Address),
-			slog.Int("code", code),
-			slog.Bool("status", status),
-			slog.Duration("duration", duration),
-		)
+		return
+	}
+
+	if change != nil && url.ContentWatch.Alert {
+		res.Check.Status = false
+		res.ErrorClass = ErrorClassContentChanged
 	}
 }
@@ -0,0 +1,192 @@
+package monitor
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"url-sentinel/internal/domain/entity"
+	"url-sentinel/internal/events"
+)
+
+var discard = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newTestURL(t *testing.T, address string, interval time.Duration) *entity.URL {
+	t.Helper()
+
+	url, err := entity.NewURL(entity.DefaultProjectID, address, interval)
+	if err != nil {
+		t.Fatalf("NewURL: %v", err)
+	}
+	return url
+}
+
+// urlList is a URLSource whose URLs can be replaced between syncs
+type urlList struct {
+	mu   sync.Mutex
+	urls []*entity.URL
+}
+
+func (l *urlList) set(urls ...*entity.URL) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.urls = urls
+}
+
+func (l *urlList) List(ctx context.Context) ([]*entity.URL, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.urls, nil
+}
+
+func TestSync(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	server := newTestServer(t)
+	bus := events.NewBus(discard)
+	defer bus.Close()
+
+	source := &urlList{}
+	m := NewMonitor(source, NewChecker("test", time.Second), nil, bus, discard)
+	defer m.Stop()
+
+	kept := newTestURL(t, server.URL+"/kept", time.Hour)
+	changed := newTestURL(t, server.URL+"/changed", time.Hour)
+	removed := newTestURL(t, server.URL+"/removed", time.Hour)
+	source.set(kept, changed, removed)
+	if err := m.Sync(ctx); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	if got := m.ActiveWatchers(); got != 3 {
+		t.Fatalf("active watchers = %d, want 3", got)
+	}
+	keptWatcher := m.watchers[kept.ID.String()]
+
+	// The server changed the interval of one URL and deleted another
+	update := *changed
+	update.CheckInterval = 30 * time.Minute
+	source.set(kept, &update)
+	if err := m.Sync(ctx); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+
+	if got := m.ActiveWatchers(); got != 2 {
+		t.Errorf("active watchers = %d, want 2", got)
+	}
+	if m.watched(removed.ID.String()) != nil {
+		t.Errorf("removed url is still watched")
+	}
+	if got := m.watched(changed.ID.String()); got == nil || got.CheckInterval != 30*time.Minute {
+		t.Errorf("changed url watched with %+v, want interval 30m", got)
+	}
+	if m.watchers[kept.ID.String()] != keptWatcher {
+		t.Errorf("unchanged url was restarted")
+	}
+}
+
+func TestSyncPaused(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	server := newTestServer(t)
+	bus := events.NewBus(discard)
+	defer bus.Close()
+
+	source := &urlList{}
+	m := NewMonitor(source, NewChecker("test", time.Second), nil, bus, discard)
+	defer m.Stop()
+
+	url := newTestURL(t, server.URL, time.Hour)
+	source.set(url)
+	if err := m.Sync(ctx); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+
+	paused := *url
+	paused.Paused = true
+	source.set(&paused)
+	if err := m.Sync(ctx); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	if got := m.ActiveWatchers(); got != 0 {
+		t.Errorf("active watchers after pausing = %d, want 0", got)
+	}
+
+	m.AddURL(&paused)
+	if got := m.ActiveWatchers(); got != 0 {
+		t.Errorf("active watchers after adding a paused url = %d, want 0", got)
+	}
+
+	source.set(url)
+	if err := m.Sync(ctx); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	if got := m.ActiveWatchers(); got != 1 {
+		t.Errorf("active watchers after resuming = %d, want 1", got)
+	}
+}
+
+func TestCheckNowResetsTicker(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	server := newTestServer(t)
+	bus := events.NewBus(discard)
+	defer bus.Close()
+
+	scheduled := make(chan *entity.Check, 8)
+	bus.Subscribe("test", events.SubscribeOptions{}, events.On(func(_ context.Context, e events.CheckCompleted) error {
+		scheduled <- e.Check
+		return nil
+	}))
+
+	m := NewMonitor(&urlList{}, NewChecker("test", time.Second), nil, bus, discard)
+	defer m.Stop()
+
+	const interval = 300 * time.Millisecond
+	url := newTestURL(t, server.URL, interval)
+	m.AddURL(url)
+
+	next := func() *entity.Check {
+		t.Helper()
+		select {
+		case check := <-scheduled:
+			return check
+		case <-time.After(5 * interval):
+			t.Fatal("no scheduled check")
+			return nil
+		}
+	}
+	next() // checked right away
+
+	time.Sleep(interval / 2)
+	manual, err := m.CheckNow(ctx, url, func(context.Context, events.CheckCompleted) error { return nil })
+	if err != nil {
+		t.Fatalf("CheckNow: %v", err)
+	}
+	if manual.Trigger != entity.TriggerManual {
+		t.Errorf("trigger = %q, want %q", manual.Trigger, entity.TriggerManual)
+	}
+
+	// Without the reset the ticker fires half an interval after the manual check
+	check := next()
+	if gap := check.CheckedAt.Sub(manual.CheckedAt); gap < interval*3/4 {
+		t.Errorf("scheduled check %s after the manual one, want a full interval of %s", gap, interval)
+	}
+}
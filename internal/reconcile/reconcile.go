@@ -0,0 +1,245 @@
+// Package reconcile keeps the URLs of a project in line with a declarative monitors file
+package reconcile
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"url-sentinel/internal/domain/entity"
+	"url-sentinel/internal/urlfile"
+	"url-sentinel/internal/usecase"
+
+	"github.com/google/uuid"
+)
+
+// Reconciler applies a monitors file to a project
+type Reconciler struct {
+	file      string
+	projectID uuid.UUID
+	prune     bool
+	urls      *usecase.URLUseCase
+	logger    *slog.Logger
+
+	applied [sha256.Size]byte // digest of the file content last applied
+}
+
+// NewReconciler creates a new reconciler of the file. When prune is set, URLs
+// managed by config that are no longer declared in the file are deleted.
+func NewReconciler(file string, projectID uuid.UUID, prune bool, urls *usecase.URLUseCase, logger *slog.Logger) *Reconciler {
+	return &Reconciler{
+		file:      file,
+		projectID: projectID,
+		prune:     prune,
+		urls:      urls,
+		logger:    logger,
+	}
+}
+
+// Plan reads the file and computes the changes without applying them
+func (r *Reconciler) Plan(ctx context.Context) (*usecase.ReconcilePlan, error) {
+	data, err := os.ReadFile(r.file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read monitors file: %w", err)
+	}
+
+	return r.plan(ctx, data)
+}
+
+// Reconcile reads the file and applies the changes
+func (r *Reconciler) Reconcile(ctx context.Context) error {
+	data, err := os.ReadFile(r.file)
+	if err != nil {
+		return fmt.Errorf("failed to read monitors file: %w", err)
+	}
+
+	plan, err := r.plan(ctx, data)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range plan.Changes {
+		if c.Action == usecase.ReconcileConflict {
+			r.logger.Warn("monitor is declared in the monitors file but not managed by config, skipping",
+				slog.String("address", c.Address),
+			)
+		}
+	}
+
+	if err := r.urls.ApplyReconcile(ctx, plan); err != nil {
+		return err
+	}
+	r.applied = sha256.Sum256(data)
+
+	r.logger.Info("monitors file reconciled",
+		slog.String("file", r.file),
+		slog.Int("created", plan.Count(usecase.ReconcileCreate)),
+		slog.Int("updated", plan.Count(usecase.ReconcileUpdate)),
+		slog.Int("deleted", plan.Count(usecase.ReconcileDelete)),
+		slog.Int("conflicts", plan.Count(usecase.ReconcileConflict)),
+	)
+
+	return nil
+}
+
+// Run reconciles whenever the process receives SIGHUP or the file content
+// changes, which is checked every pollInterval, until ctx is cancelled
+func (r *Reconciler) Run(ctx context.Context, pollInterval time.Duration) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			r.logger.Info("SIGHUP received, reconciling monitors file")
+		case <-ticker.C:
+			data, err := os.ReadFile(r.file)
+			if err != nil || sha256.Sum256(data) == r.applied {
+				continue
+			}
+			r.logger.Info("monitors file changed, reconciling")
+		}
+
+		if err := r.Reconcile(ctx); err != nil {
+			r.logger.Error("failed to reconcile monitors file", slog.Any("error", err))
+		}
+	}
+}
+
+// plan decodes the file content and computes the changes, any invalid entry fails the whole file
+func (r *Reconciler) plan(ctx context.Context, data []byte) (*usecase.ReconcilePlan, error) {
+	format, err := urlfile.ParseFormat(strings.TrimPrefix(filepath.Ext(r.file), "."))
+	if err != nil {
+		format = urlfile.FormatYAML
+	}
+
+	entries, err := urlfile.Decode(bytes.NewReader(data), format)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode monitors file: %w", err)
+	}
+
+	defs := make([]entity.URLDefinition, 0, len(entries))
+	for _, e := range entries {
+		if e.Err != nil {
+			return nil, fmt.Errorf("monitors file entry %d: %w", e.Row, e.Err)
+		}
+		defs = append(defs, e.Definition)
+	}
+
+	plan, err := r.urls.PlanReconcile(ctx, r.projectID, defs, r.prune)
+	if err != nil {
+		return nil, fmt.Errorf("failed to plan monitors file: %w", err)
+	}
+
+	return plan, nil
+}
+
+// WritePlan prints the plan as a human-readable diff
+func WritePlan(w io.Writer, plan *usecase.ReconcilePlan) error {
+	var b strings.Builder
+
+	for _, c := range plan.Changes {
+		switch c.Action {
+		case usecase.ReconcileCreate:
+			fmt.Fprintf(&b, "+ %s\n", c.Address)
+			writeDefinition(&b, "    ", c.Desired.Definition())
+		case usecase.ReconcileUpdate:
+			fmt.Fprintf(&b, "~ %s\n", c.Address)
+			writeDiff(&b, c.Current.Definition(), c.Desired.Definition())
+		case usecase.ReconcileDelete:
+			fmt.Fprintf(&b, "- %s\n", c.Address)
+		case usecase.ReconcileConflict:
+			fmt.Fprintf(&b, "! %s: exists but is not managed by config, left untouched\n", c.Address)
+		}
+	}
+
+	fmt.Fprintf(&b, "\nPlan: %d to create, %d to update, %d to delete, %d conflicting.\n",
+		plan.Count(usecase.ReconcileCreate),
+		plan.Count(usecase.ReconcileUpdate),
+		plan.Count(usecase.ReconcileDelete),
+		plan.Count(usecase.ReconcileConflict),
+	)
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeDefinition(b *strings.Builder, indent string, def entity.URLDefinition) {
+	fmt.Fprintf(b, "%scheck_interval: %s\n", indent, def.CheckInterval)
+	fmt.Fprintf(b, "%squorum: %d\n", indent, def.Quorum)
+	fmt.Fprintf(b, "%slabels: %s\n", indent, formatLabels(def.Labels))
+	fmt.Fprintf(b, "%scontent_watch: %s\n", indent, formatContentWatch(def.ContentWatch))
+	fmt.Fprintf(b, "%sfollow_redirects: %s\n", indent, def.Redirects)
+	if def.ExpectFinalURL != "" {
+		fmt.Fprintf(b, "%sexpect_final_url: %s\n", indent, def.ExpectFinalURL)
+	}
+	if def.ExpectRedirectDomain != "" {
+		fmt.Fprintf(b, "%sexpect_redirect_domain: %s\n", indent, def.ExpectRedirectDomain)
+	}
+}
+
+func writeDiff(b *strings.Builder, current, desired entity.URLDefinition) {
+	if current.CheckInterval != desired.CheckInterval {
+		fmt.Fprintf(b, "    check_interval: %s -> %s\n", current.CheckInterval, desired.CheckInterval)
+	}
+	if current.Quorum != desired.Quorum {
+		fmt.Fprintf(b, "    quorum: %d -> %d\n", current.Quorum, desired.Quorum)
+	}
+	if before, after := formatLabels(current.Labels), formatLabels(desired.Labels); before != after {
+		fmt.Fprintf(b, "    labels: %s -> %s\n", before, after)
+	}
+	if !current.ContentWatch.Equal(desired.ContentWatch) {
+		fmt.Fprintf(b, "    content_watch: %s -> %s\n", formatContentWatch(current.ContentWatch), formatContentWatch(desired.ContentWatch))
+	}
+	if current.Redirects != desired.Redirects {
+		fmt.Fprintf(b, "    follow_redirects: %s -> %s\n", current.Redirects, desired.Redirects)
+	}
+	if current.ExpectFinalURL != desired.ExpectFinalURL {
+		fmt.Fprintf(b, "    expect_final_url: %s -> %s\n", orNone(current.ExpectFinalURL), orNone(desired.ExpectFinalURL))
+	}
+	if current.ExpectRedirectDomain != desired.ExpectRedirectDomain {
+		fmt.Fprintf(b, "    expect_redirect_domain: %s -> %s\n", orNone(current.ExpectRedirectDomain), orNone(desired.ExpectRedirectDomain))
+	}
+}
+
+// orNone renders an unset expectation as "none"
+func orNone(value string) string {
+	if value == "" {
+		return "none"
+	}
+	return value
+}
+
+// formatLabels renders labels in selector syntax with sorted keys
+func formatLabels(labels entity.Labels) string {
+	pairs := make([]string, 0, len(labels))
+	for key, value := range labels {
+		pairs = append(pairs, key+"="+value)
+	}
+	slices.Sort(pairs)
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+// formatContentWatch renders content watch settings, "off" when disabled
+func formatContentWatch(watch *entity.ContentWatch) string {
+	if watch == nil {
+		return "off"
+	}
+	return fmt.Sprintf("{alert=%t,ignore_selectors=%q,ignore_patterns=%q}", watch.Alert, watch.IgnoreSelectors, watch.IgnorePatterns)
+}
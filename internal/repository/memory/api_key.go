@@ -0,0 +1,98 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"url-sentinel/internal/domain/entity"
+	"url-sentinel/internal/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+type apiKeyRepository struct {
+	store *Store
+}
+
+// NewAPIKeyRepository creates a new in-memory API key repository
+func NewAPIKeyRepository(store *Store) repository.APIKeyRepository {
+	return &apiKeyRepository{store: store}
+}
+
+func (r *apiKeyRepository) Create(ctx context.Context, key *entity.APIKey) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.projects[key.ProjectID]; !ok {
+		return fmt.Errorf("failed to create api key: %w", repository.ErrProjectNotFound)
+	}
+	if r.byPrefix(key.Prefix) != nil {
+		return errors.New("failed to create api key: prefix already exists")
+	}
+	r.store.apiKeys[key.ID] = cloneAPIKey(key)
+
+	return nil
+}
+
+// byPrefix returns the stored key with the prefix, the caller holds the lock
+func (r *apiKeyRepository) byPrefix(prefix string) *entity.APIKey {
+	for _, key := range r.store.apiKeys {
+		if key.Prefix == prefix {
+			return key
+		}
+	}
+	return nil
+}
+
+func (r *apiKeyRepository) GetByPrefix(ctx context.Context, prefix string) (*entity.APIKey, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	key := r.byPrefix(prefix)
+	if key == nil {
+		return nil, repository.ErrAPIKeyNotFound
+	}
+
+	return cloneAPIKey(key), nil
+}
+
+func (r *apiKeyRepository) List(ctx context.Context) ([]*entity.APIKey, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var keys []*entity.APIKey
+	for _, key := range r.store.apiKeys {
+		keys = append(keys, cloneAPIKey(key))
+	}
+	slices.SortFunc(keys, func(a, b *entity.APIKey) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+
+	return keys, nil
+}
+
+func (r *apiKeyRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.apiKeys[id]; !ok {
+		return repository.ErrAPIKeyNotFound
+	}
+	delete(r.store.apiKeys, id)
+
+	return nil
+}
+
+func (r *apiKeyRepository) TouchLastUsed(ctx context.Context, id uuid.UUID, usedAt time.Time) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if key, ok := r.store.apiKeys[id]; ok {
+		key.LastUsedAt = &usedAt
+	}
+
+	return nil
+}
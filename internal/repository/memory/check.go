@@ -0,0 +1,222 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"url-sentinel/internal/domain/entity"
+	"url-sentinel/internal/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+type checkRepository struct {
+	store *Store
+}
+
+// NewCheckRepository creates a new in-memory check repository
+func NewCheckRepository(store *Store) repository.CheckRepository {
+	return &checkRepository{store: store}
+}
+
+func (r *checkRepository) Create(ctx context.Context, check *entity.Check) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.urls[check.URLID]; !ok {
+		return repository.ErrURLNotFound
+	}
+	r.store.checks[check.URLID] = append(r.store.checks[check.URLID], cloneCheck(check))
+
+	return nil
+}
+
+// newestFirst returns copies of the checks of a URL in the project, most
+// recent first. The caller holds the lock.
+func (r *checkRepository) newestFirst(projectID, urlID uuid.UUID) []*entity.Check {
+	if !r.store.inScope(projectID, urlID) {
+		return nil
+	}
+
+	checks := make([]*entity.Check, 0, len(r.store.checks[urlID]))
+	for _, check := range r.store.checks[urlID] {
+		checks = append(checks, cloneCheck(check))
+	}
+	slices.SortStableFunc(checks, func(a, b *entity.Check) int {
+		return b.CheckedAt.Compare(a.CheckedAt)
+	})
+
+	return checks
+}
+
+// since returns the checks of a URL in the project since the given time, the caller holds the lock
+func (r *checkRepository) since(projectID, urlID uuid.UUID, since time.Time) []*entity.Check {
+	if !r.store.inScope(projectID, urlID) {
+		return nil
+	}
+
+	var checks []*entity.Check
+	for _, check := range r.store.checks[urlID] {
+		if !check.CheckedAt.Before(since) {
+			checks = append(checks, check)
+		}
+	}
+
+	return checks
+}
+
+func (r *checkRepository) ListByURLID(ctx context.Context, projectID, urlID uuid.UUID) ([]*entity.Check, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	checks := r.newestFirst(projectID, urlID)
+	if len(checks) == 0 {
+		return nil, nil
+	}
+
+	return checks, nil
+}
+
+func (r *checkRepository) GetLatestByURLID(ctx context.Context, projectID, urlID uuid.UUID) (*entity.Check, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	checks := r.newestFirst(projectID, urlID)
+	if len(checks) == 0 {
+		return nil, nil // No checks yet
+	}
+
+	return checks[0], nil
+}
+
+func (r *checkRepository) ListLatestByLocation(ctx context.Context, projectID, urlID uuid.UUID) ([]*entity.Check, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var checks []*entity.Check
+	seen := make(map[string]bool)
+	for _, check := range r.newestFirst(projectID, urlID) {
+		if !seen[check.Location] {
+			seen[check.Location] = true
+			checks = append(checks, check)
+		}
+	}
+	slices.SortFunc(checks, func(a, b *entity.Check) int {
+		return cmp.Compare(a.Location, b.Location)
+	})
+
+	return checks, nil
+}
+
+func (r *checkRepository) DailyUptime(ctx context.Context, projectID, urlID uuid.UUID, since time.Time) ([]*entity.DailyUptime, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	byDay := make(map[time.Time]*entity.DailyUptime)
+	var days []*entity.DailyUptime
+	for _, check := range r.since(projectID, urlID, since) {
+		day := check.CheckedAt.UTC().Truncate(24 * time.Hour)
+		uptime, ok := byDay[day]
+		if !ok {
+			uptime = &entity.DailyUptime{Day: day}
+			byDay[day] = uptime
+			days = append(days, uptime)
+		}
+		uptime.Total++
+		if check.Status {
+			uptime.Successful++
+		}
+	}
+	slices.SortFunc(days, func(a, b *entity.DailyUptime) int {
+		return a.Day.Compare(b.Day)
+	})
+
+	return days, nil
+}
+
+func (r *checkRepository) Stats(ctx context.Context, projectID, urlID uuid.UUID, since time.Time) (*entity.CheckStats, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var stats entity.CheckStats
+	var total time.Duration
+	for _, check := range r.since(projectID, urlID, since) {
+		addCheck(&stats, &total, check)
+	}
+	averageDuration(&stats, total)
+
+	return &stats, nil
+}
+
+func (r *checkRepository) StatsByLabel(
+	ctx context.Context,
+	projectID uuid.UUID,
+	key string,
+	selector entity.LabelSelector,
+	since time.Time,
+) ([]*entity.LabelGroupStats, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	byValue := make(map[string]*entity.LabelGroupStats)
+	totals := make(map[string]time.Duration)
+	var groups []*entity.LabelGroupStats
+	for _, url := range r.store.urls {
+		value, ok := url.Labels[key]
+		if url.ProjectID != projectID || !ok || !selector.Matches(url.Labels) {
+			continue
+		}
+
+		group, ok := byValue[value]
+		if !ok {
+			group = &entity.LabelGroupStats{Value: value}
+			byValue[value] = group
+			groups = append(groups, group)
+		}
+		group.URLs++
+
+		total := totals[value]
+		for _, check := range r.since(uuid.Nil, url.ID, since) {
+			addCheck(&group.CheckStats, &total, check)
+		}
+		totals[value] = total
+	}
+
+	for _, group := range groups {
+		averageDuration(&group.CheckStats, totals[group.Value])
+	}
+	slices.SortFunc(groups, func(a, b *entity.LabelGroupStats) int {
+		return cmp.Compare(a.Value, b.Value)
+	})
+
+	return groups, nil
+}
+
+func (r *checkRepository) DeleteBefore(ctx context.Context, before time.Time) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for urlID, checks := range r.store.checks {
+		r.store.checks[urlID] = slices.DeleteFunc(checks, func(check *entity.Check) bool {
+			return check.CheckedAt.Before(before)
+		})
+	}
+
+	return nil
+}
+
+func addCheck(stats *entity.CheckStats, total *time.Duration, check *entity.Check) {
+	stats.Total++
+	if check.Status {
+		stats.Successful++
+	}
+	*total += check.Duration
+}
+
+func averageDuration(stats *entity.CheckStats, total time.Duration) {
+	if stats.Total > 0 {
+		stats.AvgDuration = total / time.Duration(stats.Total)
+	}
+}
@@ -0,0 +1,62 @@
+package memory
+
+import (
+	"context"
+	"slices"
+
+	"url-sentinel/internal/domain/entity"
+	"url-sentinel/internal/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+type contentRepository struct {
+	store *Store
+}
+
+// NewContentRepository creates a new in-memory content repository
+func NewContentRepository(store *Store) repository.ContentRepository {
+	return &contentRepository{store: store}
+}
+
+func (r *contentRepository) GetSnapshot(ctx context.Context, urlID uuid.UUID) (*entity.ContentSnapshot, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	snapshot, ok := r.store.snapshots[urlID]
+	if !ok {
+		return nil, nil // Not captured yet
+	}
+
+	return cloneSnapshot(snapshot), nil
+}
+
+func (r *contentRepository) SaveSnapshot(ctx context.Context, snapshot *entity.ContentSnapshot, change *entity.ContentChange) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.urls[snapshot.URLID]; !ok {
+		return repository.ErrURLNotFound
+	}
+	r.store.snapshots[snapshot.URLID] = cloneSnapshot(snapshot)
+	if change != nil {
+		r.store.changes[change.URLID] = append(r.store.changes[change.URLID], cloneChange(change))
+	}
+
+	return nil
+}
+
+func (r *contentRepository) ListChanges(ctx context.Context, urlID uuid.UUID) ([]*entity.ContentChange, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var changes []*entity.ContentChange
+	for _, change := range r.store.changes[urlID] {
+		changes = append(changes, cloneChange(change))
+	}
+	slices.SortStableFunc(changes, func(a, b *entity.ContentChange) int {
+		return b.DetectedAt.Compare(a.DetectedAt)
+	})
+
+	return changes, nil
+}
@@ -0,0 +1,87 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"url-sentinel/internal/domain/entity"
+	"url-sentinel/internal/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+type incidentRepository struct {
+	store *Store
+}
+
+// NewIncidentRepository creates a new in-memory incident repository
+func NewIncidentRepository(store *Store) repository.IncidentRepository {
+	return &incidentRepository{store: store}
+}
+
+func (r *incidentRepository) Create(ctx context.Context, incident *entity.Incident) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.urls[incident.URLID]; !ok {
+		return fmt.Errorf("failed to create incident: %w", repository.ErrURLNotFound)
+	}
+	if incident.ResolvedAt == nil && r.open(incident.URLID) != nil {
+		return repository.ErrIncidentAlreadyOpen
+	}
+	r.store.incidents[incident.ID] = cloneIncident(incident)
+
+	return nil
+}
+
+// open returns the open incident of a URL, the caller holds the lock
+func (r *incidentRepository) open(urlID uuid.UUID) *entity.Incident {
+	for _, incident := range r.store.incidents {
+		if incident.URLID == urlID && incident.ResolvedAt == nil {
+			return incident
+		}
+	}
+	return nil
+}
+
+func (r *incidentRepository) GetOpenByURLID(ctx context.Context, urlID uuid.UUID) (*entity.Incident, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	incident := r.open(urlID)
+	if incident == nil {
+		return nil, nil // No open incident
+	}
+
+	return cloneIncident(incident), nil
+}
+
+func (r *incidentRepository) Resolve(ctx context.Context, id uuid.UUID, resolvedAt time.Time) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if incident, ok := r.store.incidents[id]; ok && incident.ResolvedAt == nil {
+		incident.ResolvedAt = &resolvedAt
+	}
+
+	return nil
+}
+
+func (r *incidentRepository) ListByURLID(ctx context.Context, urlID uuid.UUID) ([]*entity.Incident, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var incidents []*entity.Incident
+	for _, incident := range r.store.incidents {
+		if incident.URLID == urlID {
+			incidents = append(incidents, cloneIncident(incident))
+		}
+	}
+	slices.SortFunc(incidents, func(a, b *entity.Incident) int {
+		return b.StartedAt.Compare(a.StartedAt)
+	})
+
+	return incidents, nil
+}
@@ -0,0 +1,152 @@
+// Package memory implements the repositories in process memory. Data is lost
+// on restart, which suits local runs, demos and tests.
+package memory
+
+import (
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"url-sentinel/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// Store holds the data of all repositories, so deletes cascade the way
+// foreign keys do in the SQL databases
+type Store struct {
+	mu sync.RWMutex
+
+	projects    map[uuid.UUID]*entity.Project
+	urls        map[uuid.UUID]*entity.URL // without status
+	statuses    map[uuid.UUID]*entity.URLStatus
+	checks      map[uuid.UUID][]*entity.Check         // by URL ID, in insertion order
+	snapshots   map[uuid.UUID]*entity.ContentSnapshot // by URL ID
+	changes     map[uuid.UUID][]*entity.ContentChange // by URL ID, in insertion order
+	incidents   map[uuid.UUID]*entity.Incident
+	statusPages map[uuid.UUID]*entity.StatusPage
+	apiKeys     map[uuid.UUID]*entity.APIKey
+}
+
+// New creates an empty store holding only the default project
+func New() *Store {
+	return &Store{
+		projects: map[uuid.UUID]*entity.Project{
+			entity.DefaultProjectID: {
+				ID:        entity.DefaultProjectID,
+				Name:      "default",
+				CreatedAt: time.Now().UTC(),
+			},
+		},
+		urls:        make(map[uuid.UUID]*entity.URL),
+		statuses:    make(map[uuid.UUID]*entity.URLStatus),
+		checks:      make(map[uuid.UUID][]*entity.Check),
+		snapshots:   make(map[uuid.UUID]*entity.ContentSnapshot),
+		changes:     make(map[uuid.UUID][]*entity.ContentChange),
+		incidents:   make(map[uuid.UUID]*entity.Incident),
+		statusPages: make(map[uuid.UUID]*entity.StatusPage),
+		apiKeys:     make(map[uuid.UUID]*entity.APIKey),
+	}
+}
+
+// deleteURL removes a URL with its status, checks, content and incidents, the caller holds the lock
+func (s *Store) deleteURL(id uuid.UUID) {
+	delete(s.urls, id)
+	delete(s.statuses, id)
+	delete(s.checks, id)
+	delete(s.snapshots, id)
+	delete(s.changes, id)
+	for incidentID, incident := range s.incidents {
+		if incident.URLID == id {
+			delete(s.incidents, incidentID)
+		}
+	}
+}
+
+// inScope reports whether the URL exists and belongs to the project, uuid.Nil
+// matching every project. The caller holds the lock.
+func (s *Store) inScope(projectID, urlID uuid.UUID) bool {
+	url, ok := s.urls[urlID]
+	return ok && (projectID == uuid.Nil || url.ProjectID == projectID)
+}
+
+// Stored values are copied on the way in and out, so callers never share
+// memory with the store
+
+func cloneURL(url *entity.URL, status *entity.URLStatus) *entity.URL {
+	c := *url
+	c.Labels = maps.Clone(url.Labels)
+	if c.Labels == nil {
+		c.Labels = entity.Labels{}
+	}
+	if url.ContentWatch != nil {
+		w := *url.ContentWatch
+		w.IgnoreSelectors = slices.Clone(w.IgnoreSelectors)
+		w.IgnorePatterns = slices.Clone(w.IgnorePatterns)
+		c.ContentWatch = &w
+	}
+	c.Status = nil
+	if status != nil {
+		st := *status
+		c.Status = &st
+	}
+	return &c
+}
+
+func cloneCheck(check *entity.Check) *entity.Check {
+	c := *check
+	c.Redirects = slices.Clone(check.Redirects)
+	return &c
+}
+
+func cloneSnapshot(snapshot *entity.ContentSnapshot) *entity.ContentSnapshot {
+	c := *snapshot
+	return &c
+}
+
+func cloneChange(change *entity.ContentChange) *entity.ContentChange {
+	c := *change
+	return &c
+}
+
+func cloneIncident(incident *entity.Incident) *entity.Incident {
+	c := *incident
+	c.Locations = slices.Clone(incident.Locations)
+	if incident.ResolvedAt != nil {
+		t := *incident.ResolvedAt
+		c.ResolvedAt = &t
+	}
+	return &c
+}
+
+func cloneStatusPage(page *entity.StatusPage) *entity.StatusPage {
+	c := *page
+	c.Components = make([]entity.StatusComponent, 0, len(page.Components))
+	for _, component := range page.Components {
+		c.Components = append(c.Components, entity.StatusComponent{
+			Name:   component.Name,
+			URLIDs: slices.Clone(component.URLIDs),
+		})
+	}
+	return &c
+}
+
+func cloneAPIKey(key *entity.APIKey) *entity.APIKey {
+	c := *key
+	c.Scopes = slices.Clone(key.Scopes)
+	if key.ExpiresAt != nil {
+		t := *key.ExpiresAt
+		c.ExpiresAt = &t
+	}
+	if key.LastUsedAt != nil {
+		t := *key.LastUsedAt
+		c.LastUsedAt = &t
+	}
+	return &c
+}
+
+func cloneProject(project *entity.Project) *entity.Project {
+	c := *project
+	return &c
+}
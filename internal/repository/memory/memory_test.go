@@ -0,0 +1,19 @@
+package memory_test
+
+import (
+	"testing"
+
+	"url-sentinel/internal/repository/memory"
+	"url-sentinel/internal/repository/repotest"
+)
+
+func TestRepositories(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) repotest.Repositories {
+		store := memory.New()
+		return repotest.Repositories{
+			URLs:     memory.NewURLRepository(store),
+			Checks:   memory.NewCheckRepository(store),
+			Contents: memory.NewContentRepository(store),
+		}
+	})
+}
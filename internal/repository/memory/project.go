@@ -0,0 +1,103 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"url-sentinel/internal/domain/entity"
+	"url-sentinel/internal/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+type projectRepository struct {
+	store *Store
+}
+
+// NewProjectRepository creates a new in-memory project repository
+func NewProjectRepository(store *Store) repository.ProjectRepository {
+	return &projectRepository{store: store}
+}
+
+func (r *projectRepository) Create(ctx context.Context, project *entity.Project) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.projects[project.ID]; ok {
+		return errors.New("failed to create project: project already exists")
+	}
+	r.store.projects[project.ID] = cloneProject(project)
+
+	return nil
+}
+
+func (r *projectRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Project, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	project, ok := r.store.projects[id]
+	if !ok {
+		return nil, repository.ErrProjectNotFound
+	}
+
+	return cloneProject(project), nil
+}
+
+func (r *projectRepository) List(ctx context.Context) ([]*entity.Project, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var projects []*entity.Project
+	for _, project := range r.store.projects {
+		projects = append(projects, cloneProject(project))
+	}
+	slices.SortFunc(projects, func(a, b *entity.Project) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+
+	return projects, nil
+}
+
+func (r *projectRepository) Update(ctx context.Context, project *entity.Project) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored, ok := r.store.projects[project.ID]
+	if !ok {
+		return repository.ErrProjectNotFound
+	}
+	stored.Name = project.Name
+	stored.MaxURLs = project.MaxURLs
+	stored.MinInterval = project.MinInterval
+
+	return nil
+}
+
+func (r *projectRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.projects[id]; !ok {
+		return repository.ErrProjectNotFound
+	}
+	delete(r.store.projects, id)
+
+	for urlID, url := range r.store.urls {
+		if url.ProjectID == id {
+			r.store.deleteURL(urlID)
+		}
+	}
+	for pageID, page := range r.store.statusPages {
+		if page.ProjectID == id {
+			delete(r.store.statusPages, pageID)
+		}
+	}
+	for keyID, key := range r.store.apiKeys {
+		if key.ProjectID == id {
+			delete(r.store.apiKeys, keyID)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,119 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"url-sentinel/internal/domain/entity"
+	"url-sentinel/internal/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+type statusPageRepository struct {
+	store *Store
+}
+
+// NewStatusPageRepository creates a new in-memory status page repository
+func NewStatusPageRepository(store *Store) repository.StatusPageRepository {
+	return &statusPageRepository{store: store}
+}
+
+func (r *statusPageRepository) Create(ctx context.Context, page *entity.StatusPage) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.projects[page.ProjectID]; !ok {
+		return fmt.Errorf("failed to create status page: %w", repository.ErrProjectNotFound)
+	}
+	if r.bySlug(page.Slug) != nil {
+		return repository.ErrStatusPageSlugExists
+	}
+	r.store.statusPages[page.ID] = cloneStatusPage(page)
+
+	return nil
+}
+
+// bySlug returns the stored page with the slug, the caller holds the lock
+func (r *statusPageRepository) bySlug(slug string) *entity.StatusPage {
+	for _, page := range r.store.statusPages {
+		if page.Slug == slug {
+			return page
+		}
+	}
+	return nil
+}
+
+func (r *statusPageRepository) GetByID(ctx context.Context, projectID, id uuid.UUID) (*entity.StatusPage, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	page, ok := r.store.statusPages[id]
+	if !ok || page.ProjectID != projectID {
+		return nil, repository.ErrStatusPageNotFound
+	}
+
+	return cloneStatusPage(page), nil
+}
+
+func (r *statusPageRepository) GetBySlug(ctx context.Context, slug string) (*entity.StatusPage, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	page := r.bySlug(slug)
+	if page == nil {
+		return nil, repository.ErrStatusPageNotFound
+	}
+
+	return cloneStatusPage(page), nil
+}
+
+func (r *statusPageRepository) List(ctx context.Context, projectID uuid.UUID) ([]*entity.StatusPage, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var pages []*entity.StatusPage
+	for _, page := range r.store.statusPages {
+		if page.ProjectID == projectID {
+			pages = append(pages, cloneStatusPage(page))
+		}
+	}
+	slices.SortFunc(pages, func(a, b *entity.StatusPage) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+
+	return pages, nil
+}
+
+func (r *statusPageRepository) Update(ctx context.Context, page *entity.StatusPage) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored, ok := r.store.statusPages[page.ID]
+	if !ok || stored.ProjectID != page.ProjectID {
+		return repository.ErrStatusPageNotFound
+	}
+	if other := r.bySlug(page.Slug); other != nil && other.ID != page.ID {
+		return repository.ErrStatusPageSlugExists
+	}
+
+	updated := cloneStatusPage(page)
+	updated.CreatedAt = stored.CreatedAt
+	r.store.statusPages[page.ID] = updated
+
+	return nil
+}
+
+func (r *statusPageRepository) Delete(ctx context.Context, projectID, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	page, ok := r.store.statusPages[id]
+	if !ok || page.ProjectID != projectID {
+		return repository.ErrStatusPageNotFound
+	}
+	delete(r.store.statusPages, id)
+
+	return nil
+}
@@ -0,0 +1,407 @@
+package memory
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"url-sentinel/internal/domain/entity"
+	"url-sentinel/internal/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+// urlSort describes how URLs are ordered by a field and how its values
+// are carried in pagination cursors
+type urlSort struct {
+	key    func(s *Store, url *entity.URL) any
+	parse  func(value string) (any, error)
+	format func(value any) string
+}
+
+var urlSorts = map[repository.URLSort]urlSort{
+	repository.URLSortCreatedAt: {
+		key:    func(_ *Store, url *entity.URL) any { return url.CreatedAt },
+		parse:  parseTimeCursor,
+		format: formatTimeCursor,
+	},
+	repository.URLSortAddress: {
+		key:    func(_ *Store, url *entity.URL) any { return url.Address },
+		parse:  func(value string) (any, error) { return value, nil },
+		format: func(value any) string { return fmt.Sprint(value) },
+	},
+	repository.URLSortLastChecked: {
+		key: func(s *Store, url *entity.URL) any {
+			if status, ok := s.statuses[url.ID]; ok {
+				return status.LastCheckedAt
+			}
+			return time.Unix(0, 0).UTC()
+		},
+		parse:  parseTimeCursor,
+		format: formatTimeCursor,
+	},
+	repository.URLSortUptime: {
+		key: func(s *Store, url *entity.URL) any {
+			if status, ok := s.statuses[url.ID]; ok {
+				return status.Uptime
+			}
+			return -1.0
+		},
+		parse: func(value string) (any, error) {
+			return strconv.ParseFloat(value, 64)
+		},
+		format: func(value any) string {
+			f, _ := value.(float64)
+			return strconv.FormatFloat(f, 'g', -1, 64)
+		},
+	},
+}
+
+func parseTimeCursor(value string) (any, error) {
+	return time.Parse(time.RFC3339Nano, value)
+}
+
+func formatTimeCursor(value any) string {
+	t, _ := value.(time.Time)
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// compareKeys orders two sort keys of the same field
+func compareKeys(a, b any) int {
+	switch a := a.(type) {
+	case time.Time:
+		return a.Compare(b.(time.Time))
+	case string:
+		return strings.Compare(a, b.(string))
+	case float64:
+		return cmp.Compare(a, b.(float64))
+	}
+	return 0
+}
+
+type urlRepository struct {
+	store *Store
+}
+
+// NewURLRepository creates a new in-memory URL repository
+func NewURLRepository(store *Store) repository.URLRepository {
+	return &urlRepository{store: store}
+}
+
+func (r *urlRepository) Create(ctx context.Context, url *entity.URL) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if err := r.checkCreate(url); err != nil {
+		return err
+	}
+	r.store.urls[url.ID] = cloneURL(url, nil)
+
+	return nil
+}
+
+// checkCreate validates a new URL against the stored ones, the caller holds the lock
+func (r *urlRepository) checkCreate(url *entity.URL) error {
+	if _, ok := r.store.projects[url.ProjectID]; !ok {
+		return fmt.Errorf("failed to create url: %w", repository.ErrProjectNotFound)
+	}
+	if _, ok := r.store.urls[url.ID]; ok {
+		return repository.ErrURLAlreadyExists
+	}
+	if r.findByAddress(url.ProjectID, url.Address) != nil {
+		return repository.ErrURLAddressExists
+	}
+	return nil
+}
+
+// findByAddress returns the stored URL of the project with the address, the caller holds the lock
+func (r *urlRepository) findByAddress(projectID uuid.UUID, address string) *entity.URL {
+	for _, url := range r.store.urls {
+		if url.ProjectID == projectID && url.Address == address {
+			return url
+		}
+	}
+	return nil
+}
+
+func (r *urlRepository) GetByID(ctx context.Context, projectID, id uuid.UUID) (*entity.URL, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	if !r.store.inScope(projectID, id) {
+		return nil, repository.ErrURLNotFound
+	}
+
+	return cloneURL(r.store.urls[id], r.store.statuses[id]), nil
+}
+
+func (r *urlRepository) List(ctx context.Context, projectID uuid.UUID, filter repository.URLFilter) (*repository.URLPage, error) {
+	sort, ok := urlSorts[filter.Sort]
+	if !ok {
+		sort = urlSorts[repository.URLSortCreatedAt]
+	}
+
+	var after any
+	if filter.After != nil {
+		var err error
+		if after, err = sort.parse(filter.After.Value); err != nil {
+			return nil, repository.ErrInvalidCursor
+		}
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	type entry struct {
+		url *entity.URL
+		key any
+	}
+
+	search := strings.ToLower(filter.Search)
+	var entries []entry
+	for _, url := range r.store.urls {
+		if projectID != uuid.Nil && url.ProjectID != projectID {
+			continue
+		}
+		if !filter.Labels.Matches(url.Labels) {
+			continue
+		}
+		if search != "" && !strings.Contains(strings.ToLower(url.Address), search) {
+			continue
+		}
+		if filter.Interval > 0 && url.CheckInterval != filter.Interval {
+			continue
+		}
+		if filter.State != "" && urlState(url, r.store.statuses[url.ID]) != filter.State {
+			continue
+		}
+		entries = append(entries, entry{url: url, key: sort.key(r.store, url)})
+	}
+
+	compare := func(a, b entry) int {
+		if c := compareKeys(a.key, b.key); c != 0 {
+			return c
+		}
+		return bytes.Compare(a.url.ID[:], b.url.ID[:])
+	}
+	if filter.Desc {
+		slices.SortFunc(entries, func(a, b entry) int { return compare(b, a) })
+	} else {
+		slices.SortFunc(entries, compare)
+	}
+
+	if filter.After != nil {
+		cursor := entry{url: &entity.URL{ID: filter.After.ID}, key: after}
+		entries = slices.DeleteFunc(entries, func(e entry) bool {
+			c := compare(e, cursor)
+			return c == 0 || (c < 0) != filter.Desc
+		})
+	}
+
+	page := &repository.URLPage{}
+	for _, e := range entries {
+		if filter.Limit > 0 && len(page.URLs) == filter.Limit {
+			last := page.URLs[filter.Limit-1]
+			page.Next = &repository.URLCursor{
+				Value: sort.format(sort.key(r.store, last)),
+				ID:    last.ID,
+			}
+			break
+		}
+		page.URLs = append(page.URLs, cloneURL(e.url, r.store.statuses[e.url.ID]))
+	}
+
+	return page, nil
+}
+
+func (r *urlRepository) Update(ctx context.Context, url *entity.URL) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	existing, ok := r.store.urls[url.ID]
+	if !ok || existing.ProjectID != url.ProjectID {
+		return repository.ErrURLNotFound
+	}
+
+	r.updateURL(existing, url)
+	existing.Paused = url.Paused
+
+	return nil
+}
+
+// updateURL copies the settings of url to the stored URL and, when the content
+// watch rules changed, deletes its content snapshot so that the next check stores
+// a new baseline instead of reporting what the new rules remove or keep as a change
+func (r *urlRepository) updateURL(existing, url *entity.URL) {
+	if !existing.ContentWatch.SameRules(url.ContentWatch) {
+		delete(r.store.snapshots, existing.ID)
+	}
+
+	clone := cloneURL(url, nil)
+	existing.CheckInterval = url.CheckInterval
+	existing.Quorum = url.Quorum
+	existing.Labels = clone.Labels
+	existing.ContentWatch = clone.ContentWatch
+	existing.Redirects = url.Redirects
+	existing.ExpectFinalURL = url.ExpectFinalURL
+	existing.ExpectRedirectDomain = url.ExpectRedirectDomain
+}
+
+func (r *urlRepository) Delete(ctx context.Context, projectID, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if !r.store.inScope(projectID, id) {
+		return repository.ErrURLNotFound
+	}
+	r.store.deleteURL(id)
+
+	return nil
+}
+
+func (r *urlRepository) ExistsByAddress(ctx context.Context, projectID uuid.UUID, address string) (bool, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	return r.findByAddress(projectID, address) != nil, nil
+}
+
+func (r *urlRepository) Import(ctx context.Context, projectID uuid.UUID, create, update []*entity.URL) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	// Validate everything first, the import is applied entirely or not at all
+	addresses := make(map[string]bool, len(create))
+	for _, url := range create {
+		url.ProjectID = projectID
+		if err := r.checkCreate(url); err != nil {
+			return fmt.Errorf("%w: %s", err, url.Address)
+		}
+		if addresses[url.Address] {
+			return fmt.Errorf("%w: %s", repository.ErrURLAddressExists, url.Address)
+		}
+		addresses[url.Address] = true
+	}
+	stored := make([]*entity.URL, 0, len(update))
+	for _, url := range update {
+		existing := r.findByAddress(projectID, url.Address)
+		if existing == nil {
+			return fmt.Errorf("%w: %s", repository.ErrURLNotFound, url.Address)
+		}
+		stored = append(stored, existing)
+	}
+
+	for _, url := range create {
+		r.store.urls[url.ID] = cloneURL(url, nil)
+	}
+	for i, url := range update {
+		existing := stored[i]
+		r.updateURL(existing, url)
+
+		url.ID = existing.ID
+		url.PublicToken = existing.PublicToken
+		url.CreatedAt = existing.CreatedAt
+	}
+
+	return nil
+}
+
+func (r *urlRepository) Count(ctx context.Context, projectID uuid.UUID) (int, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	count := 0
+	for _, url := range r.store.urls {
+		if url.ProjectID == projectID {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func (r *urlRepository) RecordStatus(ctx context.Context, check *entity.Check, state entity.State) (*entity.StateChange, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.urls[check.URLID]; !ok {
+		return nil, repository.ErrURLNotFound
+	}
+
+	previous := entity.StateUnknown
+	status, ok := r.store.statuses[check.URLID]
+	if ok {
+		if status.LastCheckedAt.After(check.CheckedAt) {
+			return nil, nil // older than the recorded result
+		}
+		previous = status.State
+	} else {
+		status = &entity.URLStatus{State: state, StateSince: check.CheckedAt}
+		r.store.statuses[check.URLID] = status
+	}
+
+	if status.State != state {
+		status.State = state
+		status.StateSince = check.CheckedAt
+	}
+	status.LastStatus = check.Status
+	status.LastCode = check.Code
+	status.LastDuration = check.Duration
+	status.LastCheckedAt = check.CheckedAt
+	if check.Status {
+		status.ConsecutiveFailures = 0
+	} else {
+		status.ConsecutiveFailures++
+	}
+	status.Uptime = r.store.uptime(check)
+
+	if previous == state {
+		return nil, nil
+	}
+
+	return &entity.StateChange{
+		URLID: check.URLID,
+		From:  previous,
+		To:    state,
+		At:    check.CheckedAt,
+	}, nil
+}
+
+// uptime returns the share of successful checks of the URL in the 24 hours up
+// to the check, or the result of the check itself when none are stored. The
+// caller holds the lock.
+func (s *Store) uptime(check *entity.Check) float64 {
+	from := check.CheckedAt.Add(-24 * time.Hour)
+	var total, successful int
+	for _, c := range s.checks[check.URLID] {
+		if c.CheckedAt.After(from) && !c.CheckedAt.After(check.CheckedAt) {
+			total++
+			if c.Status {
+				successful++
+			}
+		}
+	}
+	if total == 0 {
+		if check.Status {
+			return 1
+		}
+		return 0
+	}
+	return float64(successful) / float64(total)
+}
+
+// urlState returns the state a URL is listed with, the status may be nil
+func urlState(url *entity.URL, status *entity.URLStatus) entity.State {
+	switch {
+	case url.Paused:
+		return entity.StatePaused
+	case status != nil:
+		return status.State
+	}
+	return entity.StateUnknown
+}
@@ -0,0 +1,315 @@
+// Package migrate applies versioned schema migrations and records them in a
+// schema_migrations table, so each one runs exactly once per database.
+//
+// Migrations are pairs of files named NNN_name.up.sql and NNN_name.down.sql,
+// usually embedded in the driver package. The version NNN orders them.
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrNoDownMigration is returned when reverting a migration without a down file
+var ErrNoDownMigration = errors.New("migration has no down file")
+
+// Migration is one versioned schema change
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string // empty when the migration cannot be reverted
+}
+
+// Status describes a migration and whether it is applied
+type Status struct {
+	Version   int
+	Name      string
+	AppliedAt *time.Time // nil while pending
+	Unknown   bool       // applied to the database but missing from this build
+}
+
+// Dialect adapts the migrator to a database
+type Dialect struct {
+	// Lock keeps other processes from migrating until the returned release is
+	// called. Nil when the database serializes migrations itself.
+	Lock func(ctx context.Context, conn *sql.Conn) (release func() error, err error)
+
+	// Placeholder returns the bind parameter for the nth argument of a query
+	Placeholder func(n int) string
+}
+
+// applied is a row of schema_migrations
+type applied struct {
+	name      string
+	appliedAt time.Time
+}
+
+// Migrator applies migrations to a database
+type Migrator struct {
+	db         *sql.DB
+	dialect    Dialect
+	migrations []Migration
+}
+
+// New creates a migrator for the migrations, which must be sorted by version
+func New(db *sql.DB, dialect Dialect, migrations []Migration) *Migrator {
+	return &Migrator{db: db, dialect: dialect, migrations: migrations}
+}
+
+// Load reads the migrations in dir of fsys, sorted by version
+func Load(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		file := entry.Name()
+
+		base, direction, ok := cutDirection(file)
+		if !ok {
+			return nil, fmt.Errorf("migration %s: name must end in .up.sql or .down.sql", file)
+		}
+		prefix, name, ok := strings.Cut(base, "_")
+		if !ok {
+			return nil, fmt.Errorf("migration %s: name must start with a version and an underscore", file)
+		}
+		version, err := strconv.Atoi(prefix)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("migration %s: invalid version %q", file, prefix)
+		}
+
+		data, err := fs.ReadFile(fsys, path.Join(dir, file))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", file, err)
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: name}
+			byVersion[version] = m
+		}
+		if m.Name != name {
+			return nil, fmt.Errorf("migration %s: version %d is also named %s", file, version, m.Name)
+		}
+		if direction == "up" {
+			m.Up = string(data)
+		} else {
+			m.Down = string(data)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("migration %03d_%s has no up file", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	slices.SortFunc(migrations, func(a, b Migration) int { return a.Version - b.Version })
+
+	return migrations, nil
+}
+
+func cutDirection(file string) (base, direction string, ok bool) {
+	if base, ok := strings.CutSuffix(file, ".up.sql"); ok {
+		return base, "up", true
+	}
+	if base, ok := strings.CutSuffix(file, ".down.sql"); ok {
+		return base, "down", true
+	}
+	return "", "", false
+}
+
+// Up applies all pending migrations in version order and returns them
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var done []Migration
+
+	err := m.locked(ctx, func(conn *sql.Conn, versions map[int]applied) error {
+		for _, migration := range m.migrations {
+			if _, ok := versions[migration.Version]; ok {
+				continue
+			}
+
+			insert := fmt.Sprintf(
+				`INSERT INTO schema_migrations (version, name, applied_at) VALUES (%s, %s, %s)`,
+				m.dialect.Placeholder(1), m.dialect.Placeholder(2), m.dialect.Placeholder(3),
+			)
+			if err := apply(ctx, conn, migration.Up, insert, migration.Version, migration.Name, time.Now().UnixNano()); err != nil {
+				return fmt.Errorf("failed to apply migration %03d_%s: %w", migration.Version, migration.Name, err)
+			}
+			done = append(done, migration)
+		}
+		return nil
+	})
+
+	return done, err
+}
+
+// Down reverts the latest steps applied migrations, newest first, and returns them
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var reverted []Migration
+
+	err := m.locked(ctx, func(conn *sql.Conn, versions map[int]applied) error {
+		for i := len(m.migrations) - 1; i >= 0 && len(reverted) < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := versions[migration.Version]; !ok {
+				continue
+			}
+			if migration.Down == "" {
+				return fmt.Errorf("failed to revert migration %03d_%s: %w", migration.Version, migration.Name, ErrNoDownMigration)
+			}
+
+			remove := `DELETE FROM schema_migrations WHERE version = ` + m.dialect.Placeholder(1)
+			if err := apply(ctx, conn, migration.Down, remove, migration.Version); err != nil {
+				return fmt.Errorf("failed to revert migration %03d_%s: %w", migration.Version, migration.Name, err)
+			}
+			reverted = append(reverted, migration)
+		}
+		return nil
+	})
+
+	return reverted, err
+}
+
+// Status lists every known migration with its state, followed by applied
+// migrations this build does not know, e.g. after a rollback of the binary
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	var statuses []Status
+
+	err := m.locked(ctx, func(conn *sql.Conn, versions map[int]applied) error {
+		known := make(map[int]bool, len(m.migrations))
+		for _, migration := range m.migrations {
+			known[migration.Version] = true
+			status := Status{Version: migration.Version, Name: migration.Name}
+			if a, ok := versions[migration.Version]; ok {
+				status.AppliedAt = &a.appliedAt
+			}
+			statuses = append(statuses, status)
+		}
+
+		for version, a := range versions {
+			if !known[version] {
+				statuses = append(statuses, Status{Version: version, Name: a.name, AppliedAt: &a.appliedAt, Unknown: true})
+			}
+		}
+		slices.SortFunc(statuses, func(a, b Status) int { return a.Version - b.Version })
+
+		return nil
+	})
+
+	return statuses, err
+}
+
+// Pending returns the migrations that are not applied yet
+func (m *Migrator) Pending(ctx context.Context) ([]Migration, error) {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []Migration
+	for _, status := range statuses {
+		if status.AppliedAt == nil {
+			i := slices.IndexFunc(m.migrations, func(migration Migration) bool { return migration.Version == status.Version })
+			pending = append(pending, m.migrations[i])
+		}
+	}
+
+	return pending, nil
+}
+
+// locked runs fn on a single connection holding the migration lock, with the
+// applied migrations by version
+func (m *Migrator) locked(ctx context.Context, fn func(conn *sql.Conn, versions map[int]applied) error) (err error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get connection: %w", err)
+	}
+	defer conn.Close()
+
+	if m.dialect.Lock != nil {
+		release, err := m.dialect.Lock(ctx, conn)
+		if err != nil {
+			return fmt.Errorf("failed to acquire migration lock: %w", err)
+		}
+		defer func() {
+			if releaseErr := release(); releaseErr != nil && err == nil {
+				err = fmt.Errorf("failed to release migration lock: %w", releaseErr)
+			}
+		}()
+	}
+
+	// applied_at holds Unix nanoseconds, which every database stores alike
+	query := `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version BIGINT PRIMARY KEY,
+			name TEXT NOT NULL,
+			applied_at BIGINT NOT NULL
+		)
+	`
+	if _, err := conn.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+
+	versions, err := readApplied(ctx, conn)
+	if err != nil {
+		return err
+	}
+
+	return fn(conn, versions)
+}
+
+func readApplied(ctx context.Context, conn *sql.Conn) (map[int]applied, error) {
+	rows, err := conn.QueryContext(ctx, `SELECT version, name, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+	defer rows.Close()
+
+	versions := make(map[int]applied)
+	for rows.Next() {
+		var version int
+		var a applied
+		var appliedAt int64
+		if err := rows.Scan(&version, &a.name, &appliedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan schema_migrations: %w", err)
+		}
+		a.appliedAt = time.Unix(0, appliedAt).UTC()
+		versions[version] = a
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return versions, nil
+}
+
+// apply runs a migration script and its bookkeeping statement in one transaction
+func apply(ctx context.Context, conn *sql.Conn, script, record string, args ...any) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, record, args...); err != nil {
+		return fmt.Errorf("failed to record migration: %w", err)
+	}
+
+	return tx.Commit()
+}
@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
@@ -11,6 +12,7 @@ import (
 	"url-sentinel/internal/domain/repository"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 type checkRepository struct {
@@ -24,39 +26,49 @@ func NewCheckRepository(db *sql.DB) repository.CheckRepository {
 
 func (r *checkRepository) Create(ctx context.Context, check *entity.Check) error {
 	query := `
-		INSERT INTO checks (id, url_id, status, code, duration, checked_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO checks (id, url_id, location, trigger, status, code, duration_ns, redirects, checked_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 	`
 
-	_, err := r.db.ExecContext(
+	redirects, err := encodeRedirects(check.Redirects)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(
 		ctx,
 		query,
 		check.ID,
 		check.URLID,
+		check.Location,
+		check.Trigger,
 		check.Status,
 		check.Code,
-		check.Duration,
+		int64(check.Duration),
+		redirects,
 		check.CheckedAt,
 	)
 
 	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23503" { // foreign_key_violation
+			return repository.ErrURLNotFound
+		}
 		return fmt.Errorf("failed to create check: %w", err)
 	}
 
 	return nil
 }
 
-func (r *checkRepository) ListByURLID(ctx context.Context, urlID uuid.UUID) ([]*entity.Check, error) {
+func (r *checkRepository) ListByURLID(ctx context.Context, projectID, urlID uuid.UUID) ([]*entity.Check, error) {
 	query := `
-		SELECT id, url_id, status, code,
-			EXTRACT(EPOCH FROM duration)::BIGINT * 1000000000 AS duration_ns,
-			checked_at
+		SELECT id, url_id, location, trigger, status, code, duration_ns, redirects, checked_at
 		FROM checks
-		WHERE url_id = $1
+		WHERE url_id = $1 AND ($2::uuid IS NULL OR url_id IN (SELECT id FROM urls WHERE project_id = $2))
 		ORDER BY checked_at DESC
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, urlID)
+	rows, err := r.db.QueryContext(ctx, query, urlID, projectScope(projectID))
 	if err != nil {
 		return nil, fmt.Errorf("failed to list checks: %w", err)
 	}
@@ -66,19 +78,26 @@ func (r *checkRepository) ListByURLID(ctx context.Context, urlID uuid.UUID) ([]*
 	for rows.Next() {
 		var check entity.Check
 		var durationNs int64
+		var redirects []byte
 
 		if err := rows.Scan(
 			&check.ID,
 			&check.URLID,
+			&check.Location,
+			&check.Trigger,
 			&check.Status,
 			&check.Code,
 			&durationNs,
+			&redirects,
 			&check.CheckedAt,
 		); err != nil {
 			return nil, fmt.Errorf("failed to scan check: %w", err)
 		}
 
 		check.Duration = time.Duration(durationNs)
+		if check.Redirects, err = decodeRedirects(redirects); err != nil {
+			return nil, err
+		}
 		checks = append(checks, &check)
 	}
 
@@ -89,26 +108,28 @@ func (r *checkRepository) ListByURLID(ctx context.Context, urlID uuid.UUID) ([]*
 	return checks, nil
 }
 
-func (r *checkRepository) GetLatestByURLID(ctx context.Context, urlID uuid.UUID) (*entity.Check, error) {
+func (r *checkRepository) GetLatestByURLID(ctx context.Context, projectID, urlID uuid.UUID) (*entity.Check, error) {
 	query := `
-		SELECT id, url_id, status, code,
-			EXTRACT(EPOCH FROM duration)::BIGINT * 1000000000 AS duration_ns,
-			checked_at
+		SELECT id, url_id, location, trigger, status, code, duration_ns, redirects, checked_at
 		FROM checks
-		WHERE url_id = $1
+		WHERE url_id = $1 AND ($2::uuid IS NULL OR url_id IN (SELECT id FROM urls WHERE project_id = $2))
 		ORDER BY checked_at DESC
 		LIMIT 1
 	`
 
 	var check entity.Check
 	var durationNs int64
+	var redirects []byte
 
-	err := r.db.QueryRowContext(ctx, query, urlID).Scan(
+	err := r.db.QueryRowContext(ctx, query, urlID, projectScope(projectID)).Scan(
 		&check.ID,
 		&check.URLID,
+		&check.Location,
+		&check.Trigger,
 		&check.Status,
 		&check.Code,
 		&durationNs,
+		&redirects,
 		&check.CheckedAt,
 	)
 
@@ -120,6 +141,219 @@ func (r *checkRepository) GetLatestByURLID(ctx context.Context, urlID uuid.UUID)
 	}
 
 	check.Duration = time.Duration(durationNs)
+	if check.Redirects, err = decodeRedirects(redirects); err != nil {
+		return nil, err
+	}
 
 	return &check, nil
 }
+
+func (r *checkRepository) ListLatestByLocation(ctx context.Context, projectID, urlID uuid.UUID) ([]*entity.Check, error) {
+	query := `
+		SELECT DISTINCT ON (location) id, url_id, location, trigger, status, code, duration_ns, redirects, checked_at
+		FROM checks
+		WHERE url_id = $1 AND ($2::uuid IS NULL OR url_id IN (SELECT id FROM urls WHERE project_id = $2))
+		ORDER BY location, checked_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, urlID, projectScope(projectID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list latest checks by location: %w", err)
+	}
+	defer rows.Close()
+
+	var checks []*entity.Check
+	for rows.Next() {
+		var check entity.Check
+		var durationNs int64
+		var redirects []byte
+
+		if err := rows.Scan(
+			&check.ID,
+			&check.URLID,
+			&check.Location,
+			&check.Trigger,
+			&check.Status,
+			&check.Code,
+			&durationNs,
+			&redirects,
+			&check.CheckedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan check: %w", err)
+		}
+
+		check.Duration = time.Duration(durationNs)
+		if check.Redirects, err = decodeRedirects(redirects); err != nil {
+			return nil, err
+		}
+		checks = append(checks, &check)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return checks, nil
+}
+
+func (r *checkRepository) DailyUptime(ctx context.Context, projectID, urlID uuid.UUID, since time.Time) ([]*entity.DailyUptime, error) {
+	query := `
+		SELECT date_trunc('day', checked_at, 'UTC') AS day,
+			COUNT(*) AS total,
+			COUNT(*) FILTER (WHERE status) AS successful
+		FROM checks
+		WHERE url_id = $1 AND checked_at >= $3 AND ($2::uuid IS NULL OR url_id IN (SELECT id FROM urls WHERE project_id = $2))
+		GROUP BY day
+		ORDER BY day ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, urlID, projectScope(projectID), since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate daily uptime: %w", err)
+	}
+	defer rows.Close()
+
+	var days []*entity.DailyUptime
+	for rows.Next() {
+		var day entity.DailyUptime
+
+		if err := rows.Scan(&day.Day, &day.Total, &day.Successful); err != nil {
+			return nil, fmt.Errorf("failed to scan daily uptime: %w", err)
+		}
+
+		day.Day = day.Day.UTC()
+		days = append(days, &day)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return days, nil
+}
+
+func (r *checkRepository) Stats(ctx context.Context, projectID, urlID uuid.UUID, since time.Time) (*entity.CheckStats, error) {
+	query := `
+		SELECT COUNT(*) AS total,
+			COUNT(*) FILTER (WHERE status) AS successful,
+			COALESCE(AVG(duration_ns), 0) AS avg_duration_ns
+		FROM checks
+		WHERE url_id = $1 AND checked_at >= $3 AND ($2::uuid IS NULL OR url_id IN (SELECT id FROM urls WHERE project_id = $2))
+	`
+
+	var stats entity.CheckStats
+	var avgNs float64
+
+	err := r.db.QueryRowContext(ctx, query, urlID, projectScope(projectID), since).Scan(
+		&stats.Total,
+		&stats.Successful,
+		&avgNs,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate check stats: %w", err)
+	}
+
+	stats.AvgDuration = time.Duration(avgNs)
+
+	return &stats, nil
+}
+
+func (r *checkRepository) StatsByLabel(
+	ctx context.Context,
+	projectID uuid.UUID,
+	key string,
+	selector entity.LabelSelector,
+	since time.Time,
+) ([]*entity.LabelGroupStats, error) {
+	query := `
+		SELECT u.labels->>$2 AS value,
+			COUNT(DISTINCT u.id) AS urls,
+			COUNT(c.id) AS total,
+			COUNT(c.id) FILTER (WHERE c.status) AS successful,
+			COALESCE(AVG(c.duration_ns), 0) AS avg_duration_ns
+		FROM urls u
+		LEFT JOIN checks c ON c.url_id = u.id AND c.checked_at >= $4
+		WHERE u.project_id = $1 AND u.labels ? $2 AND u.labels @> $3
+		GROUP BY value
+		ORDER BY value ASC
+	`
+
+	labels, err := encodeLabels(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, projectID, key, labels, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate check stats by label: %w", err)
+	}
+	defer rows.Close()
+
+	var groups []*entity.LabelGroupStats
+	for rows.Next() {
+		var group entity.LabelGroupStats
+		var avgNs float64
+
+		if err := rows.Scan(
+			&group.Value,
+			&group.URLs,
+			&group.Total,
+			&group.Successful,
+			&avgNs,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan label stats: %w", err)
+		}
+
+		group.AvgDuration = time.Duration(avgNs)
+		groups = append(groups, &group)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return groups, nil
+}
+
+// redirect is the JSONB representation of entity.Redirect
+type redirect struct {
+	Code     int    `json:"code"`
+	Location string `json:"location"`
+}
+
+// encodeRedirects converts the redirect chain of a check into a JSONB array, NULL when empty
+func encodeRedirects(redirects []entity.Redirect) (sql.NullString, error) {
+	if len(redirects) == 0 {
+		return sql.NullString{}, nil
+	}
+
+	chain := make([]redirect, 0, len(redirects))
+	for _, r := range redirects {
+		chain = append(chain, redirect(r))
+	}
+	data, err := json.Marshal(chain)
+	if err != nil {
+		return sql.NullString{}, fmt.Errorf("failed to encode check redirects: %w", err)
+	}
+
+	return sql.NullString{String: string(data), Valid: true}, nil
+}
+
+// decodeRedirects converts a JSONB array into the redirect chain of a check, nil for NULL
+func decodeRedirects(data []byte) ([]entity.Redirect, error) {
+	if data == nil {
+		return nil, nil
+	}
+
+	var chain []redirect
+	if err := json.Unmarshal(data, &chain); err != nil {
+		return nil, fmt.Errorf("failed to decode check redirects: %w", err)
+	}
+
+	redirects := make([]entity.Redirect, 0, len(chain))
+	for _, r := range chain {
+		redirects = append(redirects, entity.Redirect(r))
+	}
+
+	return redirects, nil
+}
@@ -0,0 +1,136 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"url-sentinel/internal/domain/entity"
+	"url-sentinel/internal/domain/repository"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type contentRepository struct {
+	db *sql.DB
+}
+
+// NewContentRepository creates a new PostgreSQL content repository
+func NewContentRepository(db *sql.DB) repository.ContentRepository {
+	return &contentRepository{db: db}
+}
+
+func (r *contentRepository) GetSnapshot(ctx context.Context, urlID uuid.UUID) (*entity.ContentSnapshot, error) {
+	query := `
+		SELECT url_id, hash, text, captured_at
+		FROM content_snapshots
+		WHERE url_id = $1
+	`
+
+	var snapshot entity.ContentSnapshot
+	err := r.db.QueryRowContext(ctx, query, urlID).Scan(
+		&snapshot.URLID,
+		&snapshot.Hash,
+		&snapshot.Text,
+		&snapshot.CapturedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil // Not captured yet
+		}
+		return nil, fmt.Errorf("failed to get content snapshot: %w", err)
+	}
+
+	return &snapshot, nil
+}
+
+func (r *contentRepository) SaveSnapshot(ctx context.Context, snapshot *entity.ContentSnapshot, change *entity.ContentChange) error {
+	snapshotQuery := `
+		INSERT INTO content_snapshots (url_id, hash, text, captured_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (url_id) DO UPDATE SET
+			hash = EXCLUDED.hash,
+			text = EXCLUDED.text,
+			captured_at = EXCLUDED.captured_at
+	`
+	changeQuery := `
+		INSERT INTO content_changes (id, url_id, previous_hash, hash, diff, detected_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin saving content snapshot: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, snapshotQuery, snapshot.URLID, snapshot.Hash, snapshot.Text, snapshot.CapturedAt)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23503" { // foreign_key_violation
+			return repository.ErrURLNotFound
+		}
+		return fmt.Errorf("failed to save content snapshot: %w", err)
+	}
+
+	if change != nil {
+		_, err = tx.ExecContext(
+			ctx,
+			changeQuery,
+			change.ID,
+			change.URLID,
+			change.PreviousHash,
+			change.Hash,
+			change.Diff,
+			change.DetectedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create content change: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit content snapshot: %w", err)
+	}
+
+	return nil
+}
+
+func (r *contentRepository) ListChanges(ctx context.Context, urlID uuid.UUID) ([]*entity.ContentChange, error) {
+	query := `
+		SELECT id, url_id, previous_hash, hash, diff, detected_at
+		FROM content_changes
+		WHERE url_id = $1
+		ORDER BY detected_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, urlID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list content changes: %w", err)
+	}
+	defer rows.Close()
+
+	var changes []*entity.ContentChange
+	for rows.Next() {
+		var change entity.ContentChange
+		if err := rows.Scan(
+			&change.ID,
+			&change.URLID,
+			&change.PreviousHash,
+			&change.Hash,
+			&change.Diff,
+			&change.DetectedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan content change: %w", err)
+		}
+		changes = append(changes, &change)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return changes, nil
+}
@@ -0,0 +1,162 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"url-sentinel/internal/domain/entity"
+	"url-sentinel/internal/domain/repository"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type incidentRepository struct {
+	db *sql.DB
+}
+
+// NewIncidentRepository creates a new PostgreSQL incident repository
+func NewIncidentRepository(db *sql.DB) repository.IncidentRepository {
+	return &incidentRepository{db: db}
+}
+
+// locationStatus is the JSONB representation of entity.LocationStatus
+type locationStatus struct {
+	Location  string    `json:"location"`
+	Status    bool      `json:"status"`
+	Code      int       `json:"code"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+func (r *incidentRepository) Create(ctx context.Context, incident *entity.Incident) error {
+	query := `
+		INSERT INTO incidents (id, url_id, locations, started_at, resolved_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	locations := make([]locationStatus, 0, len(incident.Locations))
+	for _, l := range incident.Locations {
+		locations = append(locations, locationStatus(l))
+	}
+
+	data, err := json.Marshal(locations)
+	if err != nil {
+		return fmt.Errorf("failed to encode incident locations: %w", err)
+	}
+
+	_, err = r.db.ExecContext(
+		ctx,
+		query,
+		incident.ID,
+		incident.URLID,
+		data,
+		incident.StartedAt,
+		incident.ResolvedAt,
+	)
+
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" { // unique_violation
+			return repository.ErrIncidentAlreadyOpen
+		}
+		return fmt.Errorf("failed to create incident: %w", err)
+	}
+
+	return nil
+}
+
+func (r *incidentRepository) GetOpenByURLID(ctx context.Context, urlID uuid.UUID) (*entity.Incident, error) {
+	query := `
+		SELECT id, url_id, locations, started_at, resolved_at
+		FROM incidents
+		WHERE url_id = $1 AND resolved_at IS NULL
+	`
+
+	incident, err := scanIncident(r.db.QueryRowContext(ctx, query, urlID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil // No open incident
+		}
+		return nil, fmt.Errorf("failed to get open incident: %w", err)
+	}
+
+	return incident, nil
+}
+
+func (r *incidentRepository) Resolve(ctx context.Context, id uuid.UUID, resolvedAt time.Time) error {
+	query := `UPDATE incidents SET resolved_at = $2 WHERE id = $1 AND resolved_at IS NULL`
+
+	if _, err := r.db.ExecContext(ctx, query, id, resolvedAt); err != nil {
+		return fmt.Errorf("failed to resolve incident: %w", err)
+	}
+
+	return nil
+}
+
+func (r *incidentRepository) ListByURLID(ctx context.Context, urlID uuid.UUID) ([]*entity.Incident, error) {
+	query := `
+		SELECT id, url_id, locations, started_at, resolved_at
+		FROM incidents
+		WHERE url_id = $1
+		ORDER BY started_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, urlID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list incidents: %w", err)
+	}
+	defer rows.Close()
+
+	var incidents []*entity.Incident
+	for rows.Next() {
+		incident, err := scanIncident(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan incident: %w", err)
+		}
+		incidents = append(incidents, incident)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return incidents, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanIncident(row rowScanner) (*entity.Incident, error) {
+	var incident entity.Incident
+	var data []byte
+	var resolvedAt sql.NullTime
+
+	if err := row.Scan(
+		&incident.ID,
+		&incident.URLID,
+		&data,
+		&incident.StartedAt,
+		&resolvedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	var locations []locationStatus
+	if err := json.Unmarshal(data, &locations); err != nil {
+		return nil, fmt.Errorf("failed to decode incident locations: %w", err)
+	}
+	for _, l := range locations {
+		incident.Locations = append(incident.Locations, entity.LocationStatus(l))
+	}
+
+	if resolvedAt.Valid {
+		incident.ResolvedAt = &resolvedAt.Time
+	}
+
+	return &incident, nil
+}
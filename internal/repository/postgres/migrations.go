@@ -3,49 +3,48 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"embed"
 	"fmt"
+	"strconv"
+
+	"url-sentinel/internal/repository/migrate"
 )
 
-// migrations contains all database migrations
-var migrations = []string{
-	// 001_init.sql
-	`
--- Create URLs table
-CREATE TABLE IF NOT EXISTS urls (
-    id UUID PRIMARY KEY,
-    address TEXT NOT NULL UNIQUE,
-    check_interval INTERVAL NOT NULL,
-    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
-);
-
--- Create index on address for faster lookups
-CREATE INDEX IF NOT EXISTS idx_urls_address ON urls(address);
-
--- Create checks table
-CREATE TABLE IF NOT EXISTS checks (
-    id UUID PRIMARY KEY,
-    url_id UUID NOT NULL REFERENCES urls(id) ON DELETE CASCADE,
-    status BOOLEAN NOT NULL,
-    code INT,
-    duration INTERVAL NOT NULL,
-    checked_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
-);
-
--- Create indexes for checks
-CREATE INDEX IF NOT EXISTS idx_checks_url_id ON checks(url_id);
-CREATE INDEX IF NOT EXISTS idx_checks_checked_at ON checks(checked_at DESC);
-	`,
-}
+// Migrations written before schema_migrations existed are idempotent, so
+// databases created by earlier releases adopt the table by re-running them.
+// New migrations need not be.
+//
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// migrationLockID identifies the advisory lock held while migrating, so
+// replicas starting together apply each migration once
+const migrationLockID = 7_462_301_755_190_117
+
+// NewMigrator creates a migrator for the embedded migrations
+func NewMigrator(db *sql.DB) (*migrate.Migrator, error) {
+	migrations, err := migrate.Load(migrationFiles, "migrations")
+	if err != nil {
+		return nil, err
+	}
 
-// RunMigrations executes all SQL migrations in order
-func RunMigrations(db *sql.DB) error {
-	ctx := context.Background()
+	return migrate.New(db, migrate.Dialect{
+		Lock:        advisoryLock,
+		Placeholder: func(n int) string { return "$" + strconv.Itoa(n) },
+	}, migrations), nil
+}
 
-	for i, migration := range migrations {
-		if _, err := db.ExecContext(ctx, migration); err != nil {
-			return fmt.Errorf("failed to execute migration %d: %w", i+1, err)
-		}
+// advisoryLock blocks until the session of conn holds the migration lock
+func advisoryLock(ctx context.Context, conn *sql.Conn) (func() error, error) {
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, migrationLockID); err != nil {
+		return nil, err
 	}
 
-	return nil
+	return func() error {
+		_, err := conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, migrationLockID)
+		if err != nil {
+			return fmt.Errorf("failed to unlock: %w", err)
+		}
+		return nil
+	}, nil
 }
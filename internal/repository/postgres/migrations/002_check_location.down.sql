@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_checks_url_id_location;
+ALTER TABLE checks DROP COLUMN IF EXISTS location;
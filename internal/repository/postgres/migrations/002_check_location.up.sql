@@ -0,0 +1,5 @@
+-- Add probe location to checks
+ALTER TABLE checks ADD COLUMN IF NOT EXISTS location TEXT NOT NULL DEFAULT 'central';
+
+-- Create index for per-location lookups
+CREATE INDEX IF NOT EXISTS idx_checks_url_id_location ON checks(url_id, location, checked_at DESC);
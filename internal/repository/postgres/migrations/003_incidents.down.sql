@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS incidents;
+ALTER TABLE urls DROP COLUMN IF EXISTS quorum;
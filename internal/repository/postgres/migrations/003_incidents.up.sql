@@ -0,0 +1,15 @@
+-- Add quorum of failing locations required to declare a URL down
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS quorum INT NOT NULL DEFAULT 1;
+
+-- Create incidents table
+CREATE TABLE IF NOT EXISTS incidents (
+    id UUID PRIMARY KEY,
+    url_id UUID NOT NULL REFERENCES urls(id) ON DELETE CASCADE,
+    locations JSONB NOT NULL,
+    started_at TIMESTAMPTZ NOT NULL,
+    resolved_at TIMESTAMPTZ
+);
+
+-- Create indexes for incidents, allowing at most one open incident per URL
+CREATE INDEX IF NOT EXISTS idx_incidents_url_id ON incidents(url_id, started_at DESC);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_incidents_open ON incidents(url_id) WHERE resolved_at IS NULL;
@@ -0,0 +1,9 @@
+-- Create status pages table
+CREATE TABLE IF NOT EXISTS status_pages (
+    id UUID PRIMARY KEY,
+    slug TEXT NOT NULL UNIQUE,
+    title TEXT NOT NULL,
+    logo_text TEXT NOT NULL DEFAULT '',
+    components JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_urls_public_token;
+ALTER TABLE urls DROP COLUMN IF EXISTS public_token;
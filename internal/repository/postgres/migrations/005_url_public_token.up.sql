@@ -0,0 +1,7 @@
+-- Add public token granting access to badges
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS public_token TEXT;
+UPDATE urls SET public_token = replace(gen_random_uuid()::text, '-', '') WHERE public_token IS NULL;
+ALTER TABLE urls ALTER COLUMN public_token SET NOT NULL;
+
+-- Create unique index on public token
+CREATE UNIQUE INDEX IF NOT EXISTS idx_urls_public_token ON urls(public_token);
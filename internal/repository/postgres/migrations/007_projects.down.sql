@@ -0,0 +1,12 @@
+-- Restore global address uniqueness, failing if projects share an address
+DROP INDEX IF EXISTS idx_urls_project_address;
+ALTER TABLE urls ADD CONSTRAINT urls_address_key UNIQUE (address);
+
+-- Detach URLs, API keys and status pages from projects
+DROP INDEX IF EXISTS idx_api_keys_project_id;
+DROP INDEX IF EXISTS idx_status_pages_project_id;
+ALTER TABLE urls DROP COLUMN IF EXISTS project_id;
+ALTER TABLE api_keys DROP COLUMN IF EXISTS project_id;
+ALTER TABLE status_pages DROP COLUMN IF EXISTS project_id;
+
+DROP TABLE IF EXISTS projects;
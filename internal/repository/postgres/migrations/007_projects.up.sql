@@ -0,0 +1,32 @@
+-- Create projects table
+CREATE TABLE IF NOT EXISTS projects (
+    id UUID PRIMARY KEY,
+    name TEXT NOT NULL,
+    max_urls INT NOT NULL DEFAULT 0,
+    min_interval INTERVAL NOT NULL DEFAULT '0 seconds',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Create default project owning data created before projects existed
+INSERT INTO projects (id, name)
+VALUES ('00000000-0000-0000-0000-000000000001', 'default')
+ON CONFLICT (id) DO NOTHING;
+
+-- Assign URLs, API keys and status pages to projects
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS project_id UUID NOT NULL
+    DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES projects(id) ON DELETE CASCADE;
+ALTER TABLE urls ALTER COLUMN project_id DROP DEFAULT;
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS project_id UUID NOT NULL
+    DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES projects(id) ON DELETE CASCADE;
+ALTER TABLE api_keys ALTER COLUMN project_id DROP DEFAULT;
+ALTER TABLE status_pages ADD COLUMN IF NOT EXISTS project_id UUID NOT NULL
+    DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES projects(id) ON DELETE CASCADE;
+ALTER TABLE status_pages ALTER COLUMN project_id DROP DEFAULT;
+
+-- Make address uniqueness per project
+ALTER TABLE urls DROP CONSTRAINT IF EXISTS urls_address_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_urls_project_address ON urls(project_id, address);
+
+-- Create indexes for project lookups
+CREATE INDEX IF NOT EXISTS idx_api_keys_project_id ON api_keys(project_id);
+CREATE INDEX IF NOT EXISTS idx_status_pages_project_id ON status_pages(project_id);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_urls_labels;
+ALTER TABLE urls DROP COLUMN IF EXISTS labels;
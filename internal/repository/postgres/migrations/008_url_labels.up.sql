@@ -0,0 +1,5 @@
+-- Add labels grouping URLs by team, environment or service
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS labels JSONB NOT NULL DEFAULT '{}';
+
+-- Create index serving label selectors
+CREATE INDEX IF NOT EXISTS idx_urls_labels ON urls USING GIN (labels jsonb_path_ops);
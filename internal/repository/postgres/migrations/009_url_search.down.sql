@@ -0,0 +1,4 @@
+-- The pg_trgm extension is left installed, other database objects may use it
+DROP INDEX IF EXISTS idx_urls_address_trgm;
+DROP INDEX IF EXISTS idx_urls_project_created_at;
+DROP INDEX IF EXISTS idx_urls_project_interval;
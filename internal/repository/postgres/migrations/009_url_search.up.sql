@@ -0,0 +1,7 @@
+-- Enable trigram indexes for substring search
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- Create indexes serving search, filters and keyset pagination of URLs
+CREATE INDEX IF NOT EXISTS idx_urls_address_trgm ON urls USING GIN (address gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_urls_project_created_at ON urls(project_id, created_at, id);
+CREATE INDEX IF NOT EXISTS idx_urls_project_interval ON urls(project_id, check_interval);
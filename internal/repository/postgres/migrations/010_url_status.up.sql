@@ -0,0 +1,27 @@
+-- Create current status table updated with every check
+CREATE TABLE IF NOT EXISTS url_status (
+    url_id UUID PRIMARY KEY REFERENCES urls(id) ON DELETE CASCADE,
+    state TEXT NOT NULL,
+    last_status BOOLEAN NOT NULL,
+    last_code INT NOT NULL,
+    last_duration INTERVAL NOT NULL,
+    last_checked_at TIMESTAMPTZ NOT NULL,
+    state_since TIMESTAMPTZ NOT NULL,
+    consecutive_failures INT NOT NULL DEFAULT 0
+);
+
+-- Backfill status of URLs checked before the table existed
+INSERT INTO url_status (url_id, state, last_status, last_code, last_duration, last_checked_at, state_since, consecutive_failures)
+SELECT DISTINCT ON (c.url_id) c.url_id,
+    CASE WHEN i.id IS NULL THEN 'up' ELSE 'down' END,
+    c.status, COALESCE(c.code, 0), c.duration, c.checked_at,
+    COALESCE(i.started_at, c.checked_at),
+    CASE WHEN c.status THEN 0 ELSE 1 END
+FROM checks c
+LEFT JOIN incidents i ON i.url_id = c.url_id AND i.resolved_at IS NULL
+ORDER BY c.url_id, c.checked_at DESC
+ON CONFLICT (url_id) DO NOTHING;
+
+-- Create indexes serving state filters and sorting by last check
+CREATE INDEX IF NOT EXISTS idx_url_status_state ON url_status(state);
+CREATE INDEX IF NOT EXISTS idx_url_status_last_checked_at ON url_status(last_checked_at);
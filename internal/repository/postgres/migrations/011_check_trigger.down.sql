@@ -0,0 +1 @@
+ALTER TABLE checks DROP COLUMN IF EXISTS trigger;
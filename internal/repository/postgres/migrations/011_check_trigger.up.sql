@@ -0,0 +1,2 @@
+-- Add trigger distinguishing scheduled from manual checks
+ALTER TABLE checks ADD COLUMN IF NOT EXISTS trigger TEXT NOT NULL DEFAULT 'scheduled';
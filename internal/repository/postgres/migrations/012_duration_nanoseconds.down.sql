@@ -0,0 +1,18 @@
+-- Restore the interval columns in the form earlier releases read and write
+ALTER TABLE projects ALTER COLUMN min_interval_ns DROP DEFAULT;
+ALTER TABLE projects ALTER COLUMN min_interval_ns TYPE INTERVAL
+    USING make_interval(secs => min_interval_ns);
+ALTER TABLE projects ALTER COLUMN min_interval_ns SET DEFAULT '0 seconds';
+ALTER TABLE projects RENAME COLUMN min_interval_ns TO min_interval;
+
+ALTER TABLE url_status ALTER COLUMN last_duration_ns TYPE INTERVAL
+    USING make_interval(secs => last_duration_ns);
+ALTER TABLE url_status RENAME COLUMN last_duration_ns TO last_duration;
+
+ALTER TABLE checks ALTER COLUMN duration_ns TYPE INTERVAL
+    USING make_interval(secs => duration_ns);
+ALTER TABLE checks RENAME COLUMN duration_ns TO duration;
+
+ALTER TABLE urls ALTER COLUMN check_interval_ns TYPE INTERVAL
+    USING make_interval(secs => check_interval_ns);
+ALTER TABLE urls RENAME COLUMN check_interval_ns TO check_interval;
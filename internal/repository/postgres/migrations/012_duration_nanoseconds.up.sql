@@ -0,0 +1,21 @@
+-- Store durations as integer nanoseconds instead of intervals read back in
+-- whole seconds. Earlier releases bound nanosecond counts to the interval
+-- columns, which PostgreSQL takes as seconds, so the seconds of every stored
+-- interval are the nanoseconds to keep.
+ALTER TABLE urls RENAME COLUMN check_interval TO check_interval_ns;
+ALTER TABLE urls ALTER COLUMN check_interval_ns TYPE BIGINT
+    USING EXTRACT(EPOCH FROM check_interval_ns)::BIGINT;
+
+ALTER TABLE checks RENAME COLUMN duration TO duration_ns;
+ALTER TABLE checks ALTER COLUMN duration_ns TYPE BIGINT
+    USING EXTRACT(EPOCH FROM duration_ns)::BIGINT;
+
+ALTER TABLE url_status RENAME COLUMN last_duration TO last_duration_ns;
+ALTER TABLE url_status ALTER COLUMN last_duration_ns TYPE BIGINT
+    USING EXTRACT(EPOCH FROM last_duration_ns)::BIGINT;
+
+ALTER TABLE projects RENAME COLUMN min_interval TO min_interval_ns;
+ALTER TABLE projects ALTER COLUMN min_interval_ns DROP DEFAULT;
+ALTER TABLE projects ALTER COLUMN min_interval_ns TYPE BIGINT
+    USING EXTRACT(EPOCH FROM min_interval_ns)::BIGINT;
+ALTER TABLE projects ALTER COLUMN min_interval_ns SET DEFAULT 0;
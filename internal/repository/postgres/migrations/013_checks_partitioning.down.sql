@@ -0,0 +1,25 @@
+CREATE TABLE checks_unpartitioned (
+    id UUID PRIMARY KEY,
+    url_id UUID NOT NULL REFERENCES urls(id) ON DELETE CASCADE,
+    status BOOLEAN NOT NULL,
+    code INT,
+    duration_ns BIGINT NOT NULL,
+    checked_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    location TEXT NOT NULL DEFAULT 'central',
+    trigger TEXT NOT NULL DEFAULT 'scheduled'
+);
+
+INSERT INTO checks_unpartitioned (id, url_id, status, code, duration_ns, checked_at, location, trigger)
+SELECT id, url_id, status, code, duration_ns, checked_at, location, trigger
+FROM checks;
+
+-- Drops every partition with the table
+DROP TABLE checks;
+
+ALTER TABLE checks_unpartitioned RENAME TO checks;
+ALTER INDEX checks_unpartitioned_pkey RENAME TO checks_pkey;
+ALTER TABLE checks RENAME CONSTRAINT checks_unpartitioned_url_id_fkey TO checks_url_id_fkey;
+
+CREATE INDEX idx_checks_url_id ON checks(url_id);
+CREATE INDEX idx_checks_checked_at ON checks(checked_at DESC);
+CREATE INDEX idx_checks_url_id_location ON checks(url_id, location, checked_at DESC);
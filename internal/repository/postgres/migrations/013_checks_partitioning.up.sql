@@ -0,0 +1,51 @@
+-- Partition checks by week of checked_at, so expired checks are dropped a
+-- partition at a time. Partitions are named after the Monday (UTC) they start
+-- on and created ahead by the server; checks outside every partition land in
+-- checks_default.
+ALTER TABLE checks RENAME TO checks_unpartitioned;
+ALTER INDEX checks_pkey RENAME TO checks_unpartitioned_pkey;
+DROP INDEX IF EXISTS idx_checks_url_id;
+DROP INDEX IF EXISTS idx_checks_checked_at;
+DROP INDEX IF EXISTS idx_checks_url_id_location;
+
+CREATE TABLE checks (
+    id UUID NOT NULL,
+    url_id UUID NOT NULL REFERENCES urls(id) ON DELETE CASCADE,
+    location TEXT NOT NULL DEFAULT 'central',
+    trigger TEXT NOT NULL DEFAULT 'scheduled',
+    status BOOLEAN NOT NULL,
+    code INT,
+    duration_ns BIGINT NOT NULL,
+    checked_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (id, checked_at)
+) PARTITION BY RANGE (checked_at);
+
+CREATE TABLE checks_default PARTITION OF checks DEFAULT;
+
+-- Create indexes serving history and per-location lookups
+CREATE INDEX idx_checks_url_id_checked_at ON checks(url_id, checked_at DESC);
+CREATE INDEX idx_checks_url_id_location ON checks(url_id, location, checked_at DESC);
+
+-- Create partitions from the week of the oldest check to two weeks ahead
+DO $$
+DECLARE
+    week TIMESTAMP := date_trunc('week', COALESCE((SELECT min(checked_at) FROM checks_unpartitioned), NOW()) AT TIME ZONE 'UTC');
+    last TIMESTAMP := date_trunc('week', NOW() AT TIME ZONE 'UTC') + INTERVAL '2 weeks';
+BEGIN
+    WHILE week <= last LOOP
+        EXECUTE format(
+            'CREATE TABLE %I PARTITION OF checks FOR VALUES FROM (%L) TO (%L)',
+            'checks_p' || to_char(week, 'YYYYMMDD'),
+            week AT TIME ZONE 'UTC',
+            (week + INTERVAL '1 week') AT TIME ZONE 'UTC'
+        );
+        week := week + INTERVAL '1 week';
+    END LOOP;
+END $$;
+
+-- Move existing checks into their partitions
+INSERT INTO checks (id, url_id, location, trigger, status, code, duration_ns, checked_at)
+SELECT id, url_id, location, trigger, status, code, duration_ns, checked_at
+FROM checks_unpartitioned;
+
+DROP TABLE checks_unpartitioned;
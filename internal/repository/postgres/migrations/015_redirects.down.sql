@@ -0,0 +1,4 @@
+ALTER TABLE urls DROP COLUMN IF EXISTS expect_redirect_domain;
+ALTER TABLE urls DROP COLUMN IF EXISTS expect_final_url;
+ALTER TABLE checks DROP COLUMN IF EXISTS redirects;
+ALTER TABLE urls DROP COLUMN IF EXISTS redirect_policy;
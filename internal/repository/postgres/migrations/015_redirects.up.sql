@@ -0,0 +1,9 @@
+-- Add redirect policy: 0 follows up to 10 redirects, -1 none, a positive value at most that many
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS redirect_policy INTEGER NOT NULL DEFAULT 0;
+
+-- Add redirect chain of checks, NULL when the first response was final
+ALTER TABLE checks ADD COLUMN IF NOT EXISTS redirects JSONB;
+
+-- Add redirect expectations of URLs, empty when checks accept any final URL or redirect target
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS expect_final_url TEXT NOT NULL DEFAULT '';
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS expect_redirect_domain TEXT NOT NULL DEFAULT '';
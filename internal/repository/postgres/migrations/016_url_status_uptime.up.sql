@@ -0,0 +1,11 @@
+-- Add uptime over the 24 hours up to the last check, stored so the URL list sorts by a stable key
+ALTER TABLE url_status ADD COLUMN IF NOT EXISTS uptime DOUBLE PRECISION;
+
+-- Backfill uptime of URLs checked before the column existed
+UPDATE url_status s SET uptime = (
+    SELECT AVG(c.status::int) FROM checks c
+    WHERE c.url_id = s.url_id AND c.checked_at > s.last_checked_at - INTERVAL '24 hours' AND c.checked_at <= s.last_checked_at
+);
+
+-- Create index serving sorting by uptime
+CREATE INDEX IF NOT EXISTS idx_url_status_uptime ON url_status(uptime);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_urls_paused;
+ALTER TABLE urls DROP COLUMN IF EXISTS paused;
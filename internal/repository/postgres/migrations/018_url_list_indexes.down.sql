@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_urls_project_address_id;
+
+DROP INDEX IF EXISTS idx_url_status_last_checked_at;
+CREATE INDEX IF NOT EXISTS idx_url_status_last_checked_at ON url_status(last_checked_at);
+
+DROP INDEX IF EXISTS idx_url_status_uptime;
+CREATE INDEX IF NOT EXISTS idx_url_status_uptime ON url_status(uptime);
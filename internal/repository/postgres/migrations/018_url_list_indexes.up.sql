@@ -0,0 +1,8 @@
+-- Create indexes matching the sort keys and tie breaker of the URL list
+CREATE INDEX IF NOT EXISTS idx_urls_project_address_id ON urls(project_id, address, id);
+
+DROP INDEX IF EXISTS idx_url_status_last_checked_at;
+CREATE INDEX IF NOT EXISTS idx_url_status_last_checked_at ON url_status(last_checked_at, url_id);
+
+DROP INDEX IF EXISTS idx_url_status_uptime;
+CREATE INDEX IF NOT EXISTS idx_url_status_uptime ON url_status(uptime NULLS FIRST, url_id);
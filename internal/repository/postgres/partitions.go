@@ -0,0 +1,148 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Checks are partitioned by the week of checked_at. A partition is named after
+// the Monday (UTC) it starts on, e.g. checks_p20261012, and checks outside
+// every partition land in checks_default.
+const (
+	partitionPrefix = "checks_p"
+	partitionLayout = "20060102"
+
+	// partitionLookahead is the number of weeks after the current one with partitions
+	partitionLookahead = 2
+
+	// partitionLockID identifies the advisory lock held while creating a
+	// partition, so replicas do not race on the same week
+	partitionLockID = 7_462_301_755_190_118
+)
+
+// partitionStart returns the start of the week containing t
+func partitionStart(t time.Time) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	sinceMonday := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -sinceMonday)
+}
+
+// EnsurePartitions creates the partitions of the current week and the following ones
+func (r *checkRepository) EnsurePartitions(ctx context.Context, now time.Time) error {
+	start := partitionStart(now)
+	for week := 0; week <= partitionLookahead; week++ {
+		if err := r.createPartition(ctx, start.AddDate(0, 0, 7*week)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// createPartition creates the partition of the week beginning at start unless
+// it exists. Checks of the week that arrived before it are moved out of the
+// default partition, which would otherwise block attaching the new one.
+func (r *checkRepository) createPartition(ctx context.Context, start time.Time) error {
+	name := partitionPrefix + start.Format(partitionLayout)
+	table := pq.QuoteIdentifier(name)
+	from := pq.QuoteLiteral(start.Format(time.RFC3339))
+	to := pq.QuoteLiteral(start.AddDate(0, 0, 7).Format(time.RFC3339))
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock($1)`, partitionLockID); err != nil {
+		return fmt.Errorf("failed to lock partitions: %w", err)
+	}
+
+	var exists bool
+	if err := tx.QueryRowContext(ctx, `SELECT to_regclass($1) IS NOT NULL`, name).Scan(&exists); err != nil {
+		return fmt.Errorf("failed to look up partition %s: %w", name, err)
+	}
+	if exists {
+		return nil
+	}
+
+	statements := []string{
+		`CREATE TABLE ` + table + ` (LIKE checks INCLUDING DEFAULTS INCLUDING CONSTRAINTS)`,
+		`WITH moved AS (
+			DELETE FROM checks_default WHERE checked_at >= ` + from + ` AND checked_at < ` + to + ` RETURNING *
+		)
+		INSERT INTO ` + table + ` SELECT * FROM moved`,
+		`ALTER TABLE checks ATTACH PARTITION ` + table + ` FOR VALUES FROM (` + from + `) TO (` + to + `)`,
+	}
+	for _, statement := range statements {
+		if _, err := tx.ExecContext(ctx, statement); err != nil {
+			return fmt.Errorf("failed to create partition %s: %w", name, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteBefore drops the partitions that ended before the given time, checks
+// are kept until their whole week expires. Expired checks in the default
+// partition are deleted row by row.
+func (r *checkRepository) DeleteBefore(ctx context.Context, before time.Time) error {
+	query := `
+		SELECT c.relname
+		FROM pg_inherits i
+		JOIN pg_class c ON c.oid = i.inhrelid
+		WHERE i.inhparent = 'checks'::regclass
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("failed to list partitions: %w", err)
+	}
+	defer rows.Close()
+
+	var expired []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return fmt.Errorf("failed to scan partition: %w", err)
+		}
+
+		week, ok := strings.CutPrefix(name, partitionPrefix)
+		if !ok {
+			continue
+		}
+		start, err := time.Parse(partitionLayout, week)
+		if err != nil {
+			continue
+		}
+		if !start.AddDate(0, 0, 7).After(before) {
+			expired = append(expired, name)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("rows iteration error: %w", err)
+	}
+	rows.Close()
+
+	for _, name := range expired {
+		if _, err := r.db.ExecContext(ctx, `DROP TABLE IF EXISTS `+pq.QuoteIdentifier(name)); err != nil {
+			return fmt.Errorf("failed to drop partition %s: %w", name, err)
+		}
+	}
+
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM checks_default WHERE checked_at < $1`, before); err != nil {
+		return fmt.Errorf("failed to delete expired checks: %w", err)
+	}
+
+	return nil
+}
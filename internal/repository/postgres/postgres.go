@@ -6,7 +6,9 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/XSAM/otelsql"
 	_ "github.com/lib/pq"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
 )
 
 // DB wraps sql.DB with additional functionality
@@ -14,9 +16,10 @@ type DB struct {
 	*sql.DB
 }
 
-// New creates a new database connection and runs migrations
+// New creates a new database connection, migrations are applied separately
+// with NewMigrator. Queries are traced as child spans of the caller's context.
 func New(dsn string) (*DB, error) {
-	db, err := sql.Open("postgres", dsn)
+	db, err := otelsql.Open("postgres", dsn, otelsql.WithAttributes(semconv.DBSystemPostgreSQL))
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
@@ -35,11 +38,6 @@ func New(dsn string) (*DB, error) {
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	// Run migrations
-	if err := RunMigrations(db); err != nil {
-		return nil, fmt.Errorf("failed to run migrations: %w", err)
-	}
-
 	return &DB{DB: db}, nil
 }
 
@@ -0,0 +1,46 @@
+package postgres_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"url-sentinel/internal/repository/postgres"
+	"url-sentinel/internal/repository/repotest"
+)
+
+// TestRepositories runs against the database in TEST_POSTGRES_DSN and is
+// skipped without it. The database is wiped before every test, never point
+// it at one holding data you want to keep.
+func TestRepositories(t *testing.T) {
+	dsn := os.Getenv("TEST_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("TEST_POSTGRES_DSN is not set")
+	}
+
+	db, err := postgres.New(dsn)
+	if err != nil {
+		t.Fatalf("failed to connect to database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	migrator, err := postgres.NewMigrator(db.DB)
+	if err != nil {
+		t.Fatalf("failed to load migrations: %v", err)
+	}
+	if _, err := migrator.Up(context.Background()); err != nil {
+		t.Fatalf("failed to run migrations: %v", err)
+	}
+
+	repotest.Run(t, func(t *testing.T) repotest.Repositories {
+		if _, err := db.Exec(`TRUNCATE urls CASCADE`); err != nil {
+			t.Fatalf("failed to truncate urls: %v", err)
+		}
+
+		return repotest.Repositories{
+			URLs:     postgres.NewURLRepository(db.DB),
+			Checks:   postgres.NewCheckRepository(db.DB),
+			Contents: postgres.NewContentRepository(db.DB),
+		}
+	})
+}
@@ -0,0 +1,156 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"url-sentinel/internal/domain/entity"
+	"url-sentinel/internal/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+type projectRepository struct {
+	db *sql.DB
+}
+
+// NewProjectRepository creates a new PostgreSQL project repository
+func NewProjectRepository(db *sql.DB) repository.ProjectRepository {
+	return &projectRepository{db: db}
+}
+
+func (r *projectRepository) Create(ctx context.Context, project *entity.Project) error {
+	query := `
+		INSERT INTO projects (id, name, max_urls, min_interval_ns, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	_, err := r.db.ExecContext(
+		ctx,
+		query,
+		project.ID,
+		project.Name,
+		project.MaxURLs,
+		int64(project.MinInterval),
+		project.CreatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create project: %w", err)
+	}
+
+	return nil
+}
+
+func (r *projectRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Project, error) {
+	query := `
+		SELECT id, name, max_urls, min_interval_ns, created_at
+		FROM projects
+		WHERE id = $1
+	`
+
+	project, err := scanProject(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrProjectNotFound
+		}
+		return nil, fmt.Errorf("failed to get project by id: %w", err)
+	}
+
+	return project, nil
+}
+
+func (r *projectRepository) List(ctx context.Context) ([]*entity.Project, error) {
+	query := `
+		SELECT id, name, max_urls, min_interval_ns, created_at
+		FROM projects
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+	defer rows.Close()
+
+	var projects []*entity.Project
+	for rows.Next() {
+		project, err := scanProject(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan project: %w", err)
+		}
+		projects = append(projects, project)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return projects, nil
+}
+
+func (r *projectRepository) Update(ctx context.Context, project *entity.Project) error {
+	query := `
+		UPDATE projects
+		SET name = $2, max_urls = $3, min_interval_ns = $4
+		WHERE id = $1
+	`
+
+	result, err := r.db.ExecContext(ctx, query, project.ID, project.Name, project.MaxURLs, int64(project.MinInterval))
+	if err != nil {
+		return fmt.Errorf("failed to update project: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrProjectNotFound
+	}
+
+	return nil
+}
+
+func (r *projectRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM projects WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete project: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrProjectNotFound
+	}
+
+	return nil
+}
+
+func scanProject(row rowScanner) (*entity.Project, error) {
+	var project entity.Project
+	var minIntervalNs int64
+
+	if err := row.Scan(
+		&project.ID,
+		&project.Name,
+		&project.MaxURLs,
+		&minIntervalNs,
+		&project.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	project.MinInterval = time.Duration(minIntervalNs)
+
+	return &project, nil
+}
@@ -3,8 +3,11 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"url-sentinel/internal/domain/entity"
@@ -14,6 +17,117 @@ import (
 	"github.com/lib/pq"
 )
 
+// urlColumns selects URL u together with its current status s
+const urlColumns = `u.id, u.project_id, u.address, u.check_interval_ns,
+	u.quorum, u.public_token, u.labels, u.content_watch, u.redirect_policy,
+	u.expect_final_url, u.expect_redirect_domain, u.paused, u.created_at,
+	s.state, s.last_status, s.last_code, s.last_duration_ns,
+	s.last_checked_at, s.state_since, s.consecutive_failures, s.uptime`
+
+// urlSort describes how URLs are ordered by a field and how its values
+// are carried in pagination cursors
+type urlSort struct {
+	expr     string // indexed column holding the sort key of URL u
+	cast     string // SQL type of the sort key
+	nullable bool   // the key is NULL for URLs never checked, carried as an empty cursor value
+	parse    func(value string) (any, error)
+	format   func(value any) string
+}
+
+var urlSorts = map[repository.URLSort]urlSort{
+	repository.URLSortCreatedAt: {
+		expr:   `u.created_at`,
+		cast:   "timestamptz",
+		parse:  parseTimeCursor,
+		format: formatTimeCursor,
+	},
+	repository.URLSortAddress: {
+		expr:   `u.address`,
+		cast:   "text",
+		parse:  func(value string) (any, error) { return value, nil },
+		format: func(value any) string { return fmt.Sprint(value) },
+	},
+	repository.URLSortLastChecked: {
+		expr:     `s.last_checked_at`,
+		cast:     "timestamptz",
+		nullable: true,
+		parse:    parseTimeCursor,
+		format:   formatTimeCursor,
+	},
+	repository.URLSortUptime: {
+		expr:     `s.uptime`,
+		cast:     "float8",
+		nullable: true,
+		parse: func(value string) (any, error) {
+			return strconv.ParseFloat(value, 64)
+		},
+		format: func(value any) string {
+			f, ok := value.(float64)
+			if !ok {
+				return ""
+			}
+			return strconv.FormatFloat(f, 'g', -1, 64)
+		},
+	},
+}
+
+// order returns the ORDER BY clause of the sort. URLs never checked come
+// first in ascending order and last in descending order.
+func (s urlSort) order(desc bool) string {
+	switch {
+	case desc && s.nullable:
+		return s.expr + " DESC NULLS LAST, u.id DESC"
+	case desc:
+		return s.expr + " DESC, u.id DESC"
+	case s.nullable:
+		return s.expr + " ASC NULLS FIRST, u.id ASC"
+	default:
+		return s.expr + " ASC, u.id ASC"
+	}
+}
+
+// after returns the condition selecting the URLs that follow the cursor in
+// the order of the sort, with NULL keys ordered before every other key
+func (s urlSort) after(cursor *repository.URLCursor, desc bool, arg func(any) string) (string, error) {
+	op := ">"
+	if desc {
+		op = "<"
+	}
+
+	if s.nullable && cursor.Value == "" {
+		condition := fmt.Sprintf("(%s IS NULL AND u.id %s %s)", s.expr, op, arg(cursor.ID))
+		if !desc {
+			condition = fmt.Sprintf("(%s OR %s IS NOT NULL)", condition, s.expr)
+		}
+		return condition, nil
+	}
+
+	value, err := s.parse(cursor.Value)
+	if err != nil {
+		return "", repository.ErrInvalidCursor
+	}
+	condition := fmt.Sprintf("(%s, u.id) %s (%s::%s, %s)", s.expr, op, arg(value), s.cast, arg(cursor.ID))
+	if desc && s.nullable {
+		condition = fmt.Sprintf("(%s OR %s IS NULL)", condition, s.expr)
+	}
+	return condition, nil
+}
+
+func parseTimeCursor(value string) (any, error) {
+	return time.Parse(time.RFC3339Nano, value)
+}
+
+func formatTimeCursor(value any) string {
+	t, ok := value.(time.Time)
+	if !ok {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// likeEscaper escapes LIKE wildcards in user input
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
 type urlRepository struct {
 	db *sql.DB
 }
@@ -25,16 +139,35 @@ func NewURLRepository(db *sql.DB) repository.URLRepository {
 
 func (r *urlRepository) Create(ctx context.Context, url *entity.URL) error {
 	query := `
-		INSERT INTO urls (id, address, check_interval, created_at)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO urls (id, project_id, address, check_interval_ns, quorum, public_token, labels, content_watch, redirect_policy,
+			expect_final_url, expect_redirect_domain, paused, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
 	`
 
-	_, err := r.db.ExecContext(
+	labels, err := encodeLabels(url.Labels)
+	if err != nil {
+		return err
+	}
+	watch, err := encodeContentWatch(url.ContentWatch)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(
 		ctx,
 		query,
 		url.ID,
+		url.ProjectID,
 		url.Address,
-		url.CheckInterval,
+		int64(url.CheckInterval),
+		url.Quorum,
+		url.PublicToken,
+		labels,
+		watch,
+		url.Redirects,
+		url.ExpectFinalURL,
+		url.ExpectRedirectDomain,
+		url.Paused,
 		url.CreatedAt,
 	)
 
@@ -49,25 +182,15 @@ func (r *urlRepository) Create(ctx context.Context, url *entity.URL) error {
 	return nil
 }
 
-func (r *urlRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.URL, error) {
+func (r *urlRepository) GetByID(ctx context.Context, projectID, id uuid.UUID) (*entity.URL, error) {
 	query := `
-		SELECT id, address,
-			EXTRACT(EPOCH FROM check_interval)::BIGINT * 1000000000 AS check_interval_ns,
-			created_at
-		FROM urls
-		WHERE id = $1
+		SELECT ` + urlColumns + `
+		FROM urls u
+		LEFT JOIN url_status s ON s.url_id = u.id
+		WHERE u.id = $1 AND ($2::uuid IS NULL OR u.project_id = $2)
 	`
 
-	var url entity.URL
-	var intervalNs int64
-
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&url.ID,
-		&url.Address,
-		&intervalNs,
-		&url.CreatedAt,
-	)
-
+	url, err := scanURL(r.db.QueryRowContext(ctx, query, id, projectScope(projectID)))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, repository.ErrURLNotFound
@@ -75,55 +198,156 @@ func (r *urlRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.URL,
 		return nil, fmt.Errorf("failed to get url by id: %w", err)
 	}
 
-	url.CheckInterval = time.Duration(intervalNs)
-
-	return &url, nil
+	return url, nil
 }
 
-func (r *urlRepository) List(ctx context.Context) ([]*entity.URL, error) {
+func (r *urlRepository) List(ctx context.Context, projectID uuid.UUID, filter repository.URLFilter) (*repository.URLPage, error) {
+	var conditions []string
+	var args []any
+	arg := func(value any) string {
+		args = append(args, value)
+		return "$" + strconv.Itoa(len(args))
+	}
+
+	if projectID != uuid.Nil {
+		conditions = append(conditions, "u.project_id = "+arg(projectID))
+	}
+	if len(filter.Labels) > 0 {
+		labels, err := encodeLabels(filter.Labels)
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, "u.labels @> "+arg(labels))
+	}
+	if filter.Search != "" {
+		conditions = append(conditions, "u.address ILIKE "+arg("%"+likeEscaper.Replace(filter.Search)+"%"))
+	}
+	if filter.Interval > 0 {
+		conditions = append(conditions, "u.check_interval_ns = "+arg(int64(filter.Interval)))
+	}
+	switch filter.State {
+	case "":
+	case entity.StatePaused:
+		conditions = append(conditions, "u.paused")
+	default:
+		conditions = append(conditions, "NOT u.paused AND COALESCE(s.state, 'unknown') = "+arg(string(filter.State)))
+	}
+
+	sort, ok := urlSorts[filter.Sort]
+	if !ok {
+		sort = urlSorts[repository.URLSortCreatedAt]
+	}
+
+	if filter.After != nil {
+		condition, err := sort.after(filter.After, filter.Desc, arg)
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, condition)
+	}
+
 	query := `
-		SELECT id, address,
-			EXTRACT(EPOCH FROM check_interval)::BIGINT * 1000000000 AS check_interval_ns,
-			created_at
-		FROM urls
-		ORDER BY created_at ASC
-	`
+		SELECT ` + urlColumns + `, ` + sort.expr + ` AS sort_key
+		FROM urls u
+		LEFT JOIN url_status s ON s.url_id = u.id`
+	if len(conditions) > 0 {
+		query += `
+		WHERE ` + strings.Join(conditions, " AND ")
+	}
+	query += `
+		ORDER BY ` + sort.order(filter.Desc)
+	if filter.Limit > 0 {
+		// Fetch one extra row to find out whether there is a next page
+		query += `
+		LIMIT ` + arg(filter.Limit+1)
+	}
 
-	rows, err := r.db.QueryContext(ctx, query)
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list urls: %w", err)
 	}
 	defer rows.Close()
 
-	var urls []*entity.URL
+	page := &repository.URLPage{}
+	var sortKeys []any
 	for rows.Next() {
-		var url entity.URL
-		var intervalNs int64
-
-		if err := rows.Scan(
-			&url.ID,
-			&url.Address,
-			&intervalNs,
-			&url.CreatedAt,
-		); err != nil {
+		var sortKey any
+		url, err := scanURL(rows, &sortKey)
+		if err != nil {
 			return nil, fmt.Errorf("failed to scan url: %w", err)
 		}
-
-		url.CheckInterval = time.Duration(intervalNs)
-		urls = append(urls, &url)
+		page.URLs = append(page.URLs, url)
+		sortKeys = append(sortKeys, sortKey)
 	}
 
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("rows iteration error: %w", err)
 	}
 
-	return urls, nil
+	if filter.Limit > 0 && len(page.URLs) > filter.Limit {
+		page.URLs = page.URLs[:filter.Limit]
+		last := page.URLs[filter.Limit-1]
+		page.Next = &repository.URLCursor{
+			Value: sort.format(sortKeys[filter.Limit-1]),
+			ID:    last.ID,
+		}
+	}
+
+	return page, nil
+}
+
+func (r *urlRepository) Update(ctx context.Context, url *entity.URL) error {
+	query := `
+		WITH previous AS (
+			SELECT id, content_watch FROM urls WHERE id = $1 AND project_id = $2 FOR UPDATE
+		)
+		UPDATE urls u
+		SET check_interval_ns = $3, quorum = $4, labels = $5, content_watch = $6, redirect_policy = $7,
+			expect_final_url = $8, expect_redirect_domain = $9, paused = $10
+		FROM previous
+		WHERE u.id = previous.id
+		RETURNING previous.content_watch
+	`
+
+	labels, err := encodeLabels(url.Labels)
+	if err != nil {
+		return err
+	}
+	watch, err := encodeContentWatch(url.ContentWatch)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin update: %w", err)
+	}
+	defer tx.Rollback()
+
+	var previous []byte
+	err = tx.QueryRowContext(ctx, query, url.ID, url.ProjectID, int64(url.CheckInterval), url.Quorum, labels, watch, url.Redirects, url.ExpectFinalURL, url.ExpectRedirectDomain, url.Paused).
+		Scan(&previous)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return repository.ErrURLNotFound
+		}
+		return fmt.Errorf("failed to update url: %w", err)
+	}
+	if err := resetContentBaseline(ctx, tx, url.ID, previous, url.ContentWatch); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit update: %w", err)
+	}
+
+	return nil
 }
 
-func (r *urlRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	query := `DELETE FROM urls WHERE id = $1`
+func (r *urlRepository) Delete(ctx context.Context, projectID, id uuid.UUID) error {
+	query := `DELETE FROM urls WHERE id = $1 AND ($2::uuid IS NULL OR project_id = $2)`
 
-	result, err := r.db.ExecContext(ctx, query, id)
+	result, err := r.db.ExecContext(ctx, query, id, projectScope(projectID))
 	if err != nil {
 		return fmt.Errorf("failed to delete url: %w", err)
 	}
@@ -140,14 +364,317 @@ func (r *urlRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
-func (r *urlRepository) ExistsByAddress(ctx context.Context, address string) (bool, error) {
-	query := `SELECT EXISTS(SELECT 1 FROM urls WHERE address = $1)`
+func (r *urlRepository) ExistsByAddress(ctx context.Context, projectID uuid.UUID, address string) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM urls WHERE project_id = $1 AND address = $2)`
 
 	var exists bool
-	err := r.db.QueryRowContext(ctx, query, address).Scan(&exists)
+	err := r.db.QueryRowContext(ctx, query, projectID, address).Scan(&exists)
 	if err != nil {
 		return false, fmt.Errorf("failed to check url existence: %w", err)
 	}
 
 	return exists, nil
 }
+
+func (r *urlRepository) Import(ctx context.Context, projectID uuid.UUID, create, update []*entity.URL) error {
+	insertQuery := `
+		INSERT INTO urls (id, project_id, address, check_interval_ns, quorum, public_token, labels, content_watch, redirect_policy,
+			expect_final_url, expect_redirect_domain, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`
+	updateQuery := `
+		WITH previous AS (
+			SELECT id, content_watch FROM urls WHERE project_id = $1 AND address = $2 FOR UPDATE
+		)
+		UPDATE urls u
+		SET check_interval_ns = $3, quorum = $4, labels = $5, content_watch = $6, redirect_policy = $7,
+			expect_final_url = $8, expect_redirect_domain = $9
+		FROM previous
+		WHERE u.id = previous.id
+		RETURNING u.id, u.public_token, u.created_at, previous.content_watch
+	`
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin import: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, url := range create {
+		labels, err := encodeLabels(url.Labels)
+		if err != nil {
+			return err
+		}
+		watch, err := encodeContentWatch(url.ContentWatch)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(
+			ctx,
+			insertQuery,
+			url.ID,
+			projectID,
+			url.Address,
+			int64(url.CheckInterval),
+			url.Quorum,
+			url.PublicToken,
+			labels,
+			watch,
+			url.Redirects,
+			url.ExpectFinalURL,
+			url.ExpectRedirectDomain,
+			url.CreatedAt,
+		)
+		if err != nil {
+			var pqErr *pq.Error
+			if errors.As(err, &pqErr) && pqErr.Code == "23505" { // unique_violation
+				return fmt.Errorf("%w: %s", repository.ErrURLAddressExists, url.Address)
+			}
+			return fmt.Errorf("failed to import url %s: %w", url.Address, err)
+		}
+	}
+
+	for _, url := range update {
+		labels, err := encodeLabels(url.Labels)
+		if err != nil {
+			return err
+		}
+		watch, err := encodeContentWatch(url.ContentWatch)
+		if err != nil {
+			return err
+		}
+
+		var previous []byte
+		err = tx.QueryRowContext(ctx, updateQuery, projectID, url.Address, int64(url.CheckInterval), url.Quorum, labels, watch, url.Redirects, url.ExpectFinalURL, url.ExpectRedirectDomain).
+			Scan(&url.ID, &url.PublicToken, &url.CreatedAt, &previous)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("%w: %s", repository.ErrURLNotFound, url.Address)
+			}
+			return fmt.Errorf("failed to import url %s: %w", url.Address, err)
+		}
+		if err := resetContentBaseline(ctx, tx, url.ID, previous, url.ContentWatch); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit import: %w", err)
+	}
+
+	return nil
+}
+
+func (r *urlRepository) Count(ctx context.Context, projectID uuid.UUID) (int, error) {
+	query := `SELECT COUNT(*) FROM urls WHERE project_id = $1`
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, projectID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count urls: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *urlRepository) RecordStatus(ctx context.Context, check *entity.Check, state entity.State) (*entity.StateChange, error) {
+	query := `
+		WITH previous AS (
+			SELECT state FROM url_status WHERE url_id = $1 FOR UPDATE
+		)
+		INSERT INTO url_status AS s (url_id, state, last_status, last_code, last_duration_ns,
+			last_checked_at, state_since, consecutive_failures, uptime)
+		VALUES ($1, $2, $3, $4, $5, $6, $6, CASE WHEN $3 THEN 0 ELSE 1 END,
+			COALESCE(
+				(SELECT AVG(c.status::int) FROM checks c
+					WHERE c.url_id = $1 AND c.checked_at > $6 - INTERVAL '24 hours' AND c.checked_at <= $6),
+				CASE WHEN $3 THEN 1 ELSE 0 END))
+		ON CONFLICT (url_id) DO UPDATE SET
+			state = EXCLUDED.state,
+			last_status = EXCLUDED.last_status,
+			last_code = EXCLUDED.last_code,
+			last_duration_ns = EXCLUDED.last_duration_ns,
+			last_checked_at = EXCLUDED.last_checked_at,
+			state_since = CASE WHEN s.state = EXCLUDED.state THEN s.state_since ELSE EXCLUDED.state_since END,
+			consecutive_failures = CASE WHEN EXCLUDED.last_status THEN 0 ELSE s.consecutive_failures + 1 END,
+			uptime = EXCLUDED.uptime
+		WHERE s.last_checked_at <= EXCLUDED.last_checked_at
+		RETURNING COALESCE((SELECT state FROM previous), 'unknown')
+	`
+
+	var previous string
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		check.URLID,
+		string(state),
+		check.Status,
+		check.Code,
+		int64(check.Duration),
+		check.CheckedAt,
+	).Scan(&previous)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil // older than the recorded result
+		}
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23503" { // foreign_key_violation
+			return nil, repository.ErrURLNotFound
+		}
+		return nil, fmt.Errorf("failed to record url status: %w", err)
+	}
+
+	if entity.State(previous) == state {
+		return nil, nil
+	}
+
+	return &entity.StateChange{
+		URLID: check.URLID,
+		From:  entity.State(previous),
+		To:    state,
+		At:    check.CheckedAt,
+	}, nil
+}
+
+// scanURL scans a row of urlColumns followed by optional extra columns
+func scanURL(row rowScanner, extra ...any) (*entity.URL, error) {
+	var url entity.URL
+	var intervalNs int64
+	var labels, watch []byte
+	var state sql.NullString
+	var lastStatus sql.NullBool
+	var lastCode, lastDurationNs, consecutiveFailures sql.NullInt64
+	var lastCheckedAt, stateSince sql.NullTime
+	var uptime sql.NullFloat64
+
+	dest := append([]any{
+		&url.ID,
+		&url.ProjectID,
+		&url.Address,
+		&intervalNs,
+		&url.Quorum,
+		&url.PublicToken,
+		&labels,
+		&watch,
+		&url.Redirects,
+		&url.ExpectFinalURL,
+		&url.ExpectRedirectDomain,
+		&url.Paused,
+		&url.CreatedAt,
+		&state,
+		&lastStatus,
+		&lastCode,
+		&lastDurationNs,
+		&lastCheckedAt,
+		&stateSince,
+		&consecutiveFailures,
+		&uptime,
+	}, extra...)
+
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	url.CheckInterval = time.Duration(intervalNs)
+	if err := json.Unmarshal(labels, &url.Labels); err != nil {
+		return nil, fmt.Errorf("failed to decode url labels: %w", err)
+	}
+	settings, err := decodeContentWatch(watch)
+	if err != nil {
+		return nil, err
+	}
+	url.ContentWatch = settings
+
+	if state.Valid {
+		url.Status = &entity.URLStatus{
+			State:               entity.State(state.String),
+			LastStatus:          lastStatus.Bool,
+			LastCode:            int(lastCode.Int64),
+			LastDuration:        time.Duration(lastDurationNs.Int64),
+			LastCheckedAt:       lastCheckedAt.Time,
+			StateSince:          stateSince.Time,
+			ConsecutiveFailures: int(consecutiveFailures.Int64),
+			Uptime:              uptime.Float64,
+		}
+	}
+
+	return &url, nil
+}
+
+// encodeLabels converts labels or a label selector into a JSONB object
+func encodeLabels[M ~map[string]string](labels M) ([]byte, error) {
+	if labels == nil {
+		return []byte("{}"), nil
+	}
+
+	data, err := json.Marshal(labels)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode labels: %w", err)
+	}
+
+	return data, nil
+}
+
+// contentWatch is the JSONB representation of entity.ContentWatch
+type contentWatch struct {
+	IgnoreSelectors []string `json:"ignore_selectors,omitempty"`
+	IgnorePatterns  []string `json:"ignore_patterns,omitempty"`
+	Alert           bool     `json:"alert,omitempty"`
+}
+
+// encodeContentWatch converts content watch settings into a JSONB object, NULL when disabled
+func encodeContentWatch(watch *entity.ContentWatch) (sql.NullString, error) {
+	if watch == nil {
+		return sql.NullString{}, nil
+	}
+
+	data, err := json.Marshal(contentWatch(*watch))
+	if err != nil {
+		return sql.NullString{}, fmt.Errorf("failed to encode content watch: %w", err)
+	}
+
+	return sql.NullString{String: string(data), Valid: true}, nil
+}
+
+// decodeContentWatch converts a JSONB object into content watch settings, nil for NULL
+func decodeContentWatch(data []byte) (*entity.ContentWatch, error) {
+	if data == nil {
+		return nil, nil
+	}
+
+	var watch contentWatch
+	if err := json.Unmarshal(data, &watch); err != nil {
+		return nil, fmt.Errorf("failed to decode content watch: %w", err)
+	}
+
+	return (*entity.ContentWatch)(&watch), nil
+}
+
+// resetContentBaseline deletes the content snapshot of a URL whose content watch
+// rules changed from the previous ones, stored as JSONB. The next check then stores
+// a new baseline instead of reporting what the new rules remove or keep as a change.
+func resetContentBaseline(ctx context.Context, tx *sql.Tx, urlID uuid.UUID, previous []byte, current *entity.ContentWatch) error {
+	watch, err := decodeContentWatch(previous)
+	if err != nil {
+		return err
+	}
+	if watch.SameRules(current) {
+		return nil
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM content_snapshots WHERE url_id = $1`, urlID); err != nil {
+		return fmt.Errorf("failed to reset content snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// projectScope converts a project ID into a query parameter where uuid.Nil
+// becomes NULL, disabling the project filter
+func projectScope(projectID uuid.UUID) any {
+	if projectID == uuid.Nil {
+		return nil
+	}
+	return projectID
+}
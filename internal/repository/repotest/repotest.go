@@ -0,0 +1,1011 @@
+// Package repotest is a conformance suite for URLRepository, CheckRepository
+// and ContentRepository implementations. Every storage driver runs it from
+// its own tests, so the drivers stay interchangeable.
+package repotest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"url-sentinel/internal/domain/entity"
+	"url-sentinel/internal/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+// Repositories are the implementations under test. All must share one
+// store, so URL deletes are visible to the check and content repositories.
+type Repositories struct {
+	URLs     repository.URLRepository
+	Checks   repository.CheckRepository
+	Contents repository.ContentRepository
+}
+
+// Factory returns repositories holding no URLs, with the default project
+// present. It is called once per test.
+type Factory func(t *testing.T) Repositories
+
+// Run runs the whole suite against the repositories returned by newRepos
+func Run(t *testing.T, newRepos Factory) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, repos Repositories)
+	}{
+		{"CreateAndGet", testCreateAndGet},
+		{"GetNotFound", testGetNotFound},
+		{"CreateDuplicateAddress", testCreateDuplicateAddress},
+		{"List", testList},
+		{"ListPagination", testListPagination},
+		{"Update", testUpdate},
+		{"UpdateNotFound", testUpdateNotFound},
+		{"Delete", testDelete},
+		{"DeleteNotFound", testDeleteNotFound},
+		{"CheckForUnknownURL", testCheckForUnknownURL},
+		{"DeleteCascadesChecks", testDeleteCascadesChecks},
+		{"ListByURLIDOrder", testListByURLIDOrder},
+		{"GetLatestEmpty", testGetLatestEmpty},
+		{"GetLatest", testGetLatest},
+		{"DurationPrecision", testDurationPrecision},
+		{"UptimeSort", testUptimeSort},
+		{"LastCheckedSort", testLastCheckedSort},
+		{"PausedState", testPausedState},
+		{"DeleteBefore", testDeleteBefore},
+		{"ContentWatch", testContentWatch},
+		{"ContentSnapshot", testContentSnapshot},
+		{"ContentBaselineReset", testContentBaselineReset},
+		{"ContentChangesOrder", testContentChangesOrder},
+		{"ContentForUnknownURL", testContentForUnknownURL},
+		{"DeleteCascadesContent", testDeleteCascadesContent},
+		{"RedirectPolicy", testRedirectPolicy},
+		{"CheckRedirects", testCheckRedirects},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(t, newRepos(t))
+		})
+	}
+}
+
+// location is the probe location of the checks created by the suite
+const location = "central"
+
+// Timestamps and durations are built at microsecond precision, the finest
+// every supported database keeps
+var base = time.Now().UTC().Truncate(time.Microsecond)
+
+func newURL(t *testing.T, address string) *entity.URL {
+	t.Helper()
+
+	url, err := entity.NewURL(entity.DefaultProjectID, address, time.Minute)
+	if err != nil {
+		t.Fatalf("NewURL(%q): %v", address, err)
+	}
+	url.CreatedAt = url.CreatedAt.Truncate(time.Microsecond)
+
+	return url
+}
+
+func createURL(t *testing.T, repos Repositories, address string) *entity.URL {
+	t.Helper()
+
+	url := newURL(t, address)
+	if err := repos.URLs.Create(context.Background(), url); err != nil {
+		t.Fatalf("Create(%q): %v", address, err)
+	}
+
+	return url
+}
+
+func createCheck(t *testing.T, repos Repositories, urlID uuid.UUID, checkedAt time.Time) *entity.Check {
+	t.Helper()
+
+	check := entity.NewCheck(urlID, location, true, 200, 250*time.Millisecond)
+	check.CheckedAt = checkedAt
+	if err := repos.Checks.Create(context.Background(), check); err != nil {
+		t.Fatalf("Create check: %v", err)
+	}
+
+	return check
+}
+
+func assertURL(t *testing.T, got, want *entity.URL) {
+	t.Helper()
+
+	if got.ID != want.ID ||
+		got.ProjectID != want.ProjectID ||
+		got.Address != want.Address ||
+		got.CheckInterval != want.CheckInterval ||
+		got.Quorum != want.Quorum ||
+		got.PublicToken != want.PublicToken ||
+		!got.CreatedAt.Equal(want.CreatedAt) {
+		t.Errorf("url = %+v, want %+v", got, want)
+	}
+	if len(got.Labels) != len(want.Labels) {
+		t.Errorf("labels = %v, want %v", got.Labels, want.Labels)
+	}
+	for key, value := range want.Labels {
+		if got.Labels[key] != value {
+			t.Errorf("labels = %v, want %v", got.Labels, want.Labels)
+			break
+		}
+	}
+}
+
+func testCreateAndGet(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+
+	url := newURL(t, "https://example.com/health")
+	url.Quorum = 2
+	url.Labels = entity.Labels{"env": "prod", "team": "payments"}
+	if err := repos.URLs.Create(ctx, url); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	got, err := repos.URLs.GetByID(ctx, url.ProjectID, url.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	assertURL(t, got, url)
+	if got.Status != nil {
+		t.Errorf("status = %+v, want nil before the first check", got.Status)
+	}
+
+	// uuid.Nil disables project scoping
+	if _, err := repos.URLs.GetByID(ctx, uuid.Nil, url.ID); err != nil {
+		t.Errorf("GetByID unscoped: %v", err)
+	}
+
+	exists, err := repos.URLs.ExistsByAddress(ctx, url.ProjectID, url.Address)
+	if err != nil || !exists {
+		t.Errorf("ExistsByAddress = %v, %v, want true", exists, err)
+	}
+
+	count, err := repos.URLs.Count(ctx, url.ProjectID)
+	if err != nil || count != 1 {
+		t.Errorf("Count = %d, %v, want 1", count, err)
+	}
+}
+
+func testGetNotFound(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+
+	if _, err := repos.URLs.GetByID(ctx, entity.DefaultProjectID, uuid.New()); !errors.Is(err, repository.ErrURLNotFound) {
+		t.Errorf("GetByID unknown id: err = %v, want ErrURLNotFound", err)
+	}
+
+	url := createURL(t, repos, "https://example.com")
+	if _, err := repos.URLs.GetByID(ctx, uuid.New(), url.ID); !errors.Is(err, repository.ErrURLNotFound) {
+		t.Errorf("GetByID other project: err = %v, want ErrURLNotFound", err)
+	}
+}
+
+func testCreateDuplicateAddress(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+
+	url := createURL(t, repos, "https://example.com")
+
+	duplicate := newURL(t, url.Address)
+	if err := repos.URLs.Create(ctx, duplicate); !errors.Is(err, repository.ErrURLAddressExists) {
+		t.Fatalf("Create duplicate: err = %v, want ErrURLAddressExists", err)
+	}
+
+	count, err := repos.URLs.Count(ctx, url.ProjectID)
+	if err != nil || count != 1 {
+		t.Errorf("Count = %d, %v, want 1", count, err)
+	}
+}
+
+func testList(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+
+	var want []*entity.URL
+	for i, address := range []string{"https://c.example.com", "https://a.example.com", "https://b.example.com"} {
+		url := newURL(t, address)
+		url.CreatedAt = base.Add(time.Duration(i) * time.Second)
+		if err := repos.URLs.Create(ctx, url); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		want = append(want, url)
+	}
+
+	page, err := repos.URLs.List(ctx, entity.DefaultProjectID, repository.URLFilter{})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if page.Next != nil {
+		t.Errorf("next = %+v, want nil on the only page", page.Next)
+	}
+	if len(page.URLs) != len(want) {
+		t.Fatalf("List returned %d urls, want %d", len(page.URLs), len(want))
+	}
+	for i := range want {
+		assertURL(t, page.URLs[i], want[i])
+	}
+
+	page, err = repos.URLs.List(ctx, entity.DefaultProjectID, repository.URLFilter{Sort: repository.URLSortAddress, Desc: true})
+	if err != nil {
+		t.Fatalf("List by address: %v", err)
+	}
+	for i, address := range []string{"https://c.example.com", "https://b.example.com", "https://a.example.com"} {
+		if i >= len(page.URLs) || page.URLs[i].Address != address {
+			t.Fatalf("List by address desc returned %v", addresses(page.URLs))
+		}
+	}
+
+	page, err = repos.URLs.List(ctx, uuid.New(), repository.URLFilter{})
+	if err != nil {
+		t.Fatalf("List other project: %v", err)
+	}
+	if len(page.URLs) != 0 {
+		t.Errorf("List other project returned %v, want none", addresses(page.URLs))
+	}
+}
+
+func testListPagination(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+
+	var want []string
+	for i, address := range []string{"https://a.example.com", "https://b.example.com", "https://c.example.com", "https://d.example.com", "https://e.example.com"} {
+		url := newURL(t, address)
+		// Equal creation times exercise the ID tie-breaker of the cursor
+		url.CreatedAt = base.Add(time.Duration(i/2) * time.Second)
+		if err := repos.URLs.Create(ctx, url); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		want = append(want, address)
+	}
+
+	seen := make(map[string]bool)
+	filter := repository.URLFilter{Limit: 2}
+	for pages := 0; ; pages++ {
+		if pages > len(want) {
+			t.Fatal("pagination does not terminate")
+		}
+
+		page, err := repos.URLs.List(ctx, entity.DefaultProjectID, filter)
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		if len(page.URLs) > filter.Limit {
+			t.Fatalf("page has %d urls, limit is %d", len(page.URLs), filter.Limit)
+		}
+		for _, url := range page.URLs {
+			if seen[url.Address] {
+				t.Fatalf("%s returned twice", url.Address)
+			}
+			seen[url.Address] = true
+		}
+
+		if page.Next == nil {
+			break
+		}
+		filter.After = page.Next
+	}
+
+	if len(seen) != len(want) {
+		t.Errorf("pages returned %d urls, want %d", len(seen), len(want))
+	}
+}
+
+func testUpdate(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+
+	url := createURL(t, repos, "https://example.com")
+
+	changed := *url
+	changed.CheckInterval = 5 * time.Minute
+	changed.Quorum = 2
+	changed.Labels = entity.Labels{"team": "payments"}
+	changed.ContentWatch = &entity.ContentWatch{IgnoreSelectors: []string{"#clock"}}
+	changed.Redirects = entity.NoRedirects
+	// The address, public token and creation time are not updated
+	changed.Address = "https://other.example.com"
+	changed.PublicToken = "ignored"
+	changed.CreatedAt = base.Add(-time.Hour)
+
+	if err := repos.URLs.Update(ctx, &changed); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	got, err := repos.URLs.GetByID(ctx, url.ProjectID, url.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	want := changed
+	want.Address, want.PublicToken, want.CreatedAt = url.Address, url.PublicToken, url.CreatedAt
+	assertURL(t, got, &want)
+	if !got.ContentWatch.Equal(want.ContentWatch) {
+		t.Errorf("content watch = %+v, want %+v", got.ContentWatch, want.ContentWatch)
+	}
+	if got.Redirects != want.Redirects {
+		t.Errorf("redirects = %v, want %v", got.Redirects, want.Redirects)
+	}
+}
+
+func testUpdateNotFound(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+
+	if err := repos.URLs.Update(ctx, newURL(t, "https://example.com")); !errors.Is(err, repository.ErrURLNotFound) {
+		t.Errorf("Update unknown id: err = %v, want ErrURLNotFound", err)
+	}
+
+	// A URL is only updated within its project
+	url := createURL(t, repos, "https://example.com")
+	url.ProjectID = uuid.New()
+	if err := repos.URLs.Update(ctx, url); !errors.Is(err, repository.ErrURLNotFound) {
+		t.Errorf("Update in other project: err = %v, want ErrURLNotFound", err)
+	}
+}
+
+func testDelete(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+
+	url := createURL(t, repos, "https://example.com")
+	other := createURL(t, repos, "https://other.example.com")
+
+	if err := repos.URLs.Delete(ctx, uuid.New(), url.ID); !errors.Is(err, repository.ErrURLNotFound) {
+		t.Errorf("Delete from other project: err = %v, want ErrURLNotFound", err)
+	}
+	if err := repos.URLs.Delete(ctx, url.ProjectID, url.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	if _, err := repos.URLs.GetByID(ctx, url.ProjectID, url.ID); !errors.Is(err, repository.ErrURLNotFound) {
+		t.Errorf("GetByID after delete: err = %v, want ErrURLNotFound", err)
+	}
+	if _, err := repos.URLs.GetByID(ctx, other.ProjectID, other.ID); err != nil {
+		t.Errorf("GetByID of the other url: %v", err)
+	}
+
+	exists, err := repos.URLs.ExistsByAddress(ctx, url.ProjectID, url.Address)
+	if err != nil || exists {
+		t.Errorf("ExistsByAddress after delete = %v, %v, want false", exists, err)
+	}
+
+	// The address is free again
+	if err := repos.URLs.Create(ctx, newURL(t, url.Address)); err != nil {
+		t.Errorf("Create after delete: %v", err)
+	}
+}
+
+func testDeleteNotFound(t *testing.T, repos Repositories) {
+	err := repos.URLs.Delete(context.Background(), entity.DefaultProjectID, uuid.New())
+	if !errors.Is(err, repository.ErrURLNotFound) {
+		t.Errorf("Delete unknown id: err = %v, want ErrURLNotFound", err)
+	}
+}
+
+func testCheckForUnknownURL(t *testing.T, repos Repositories) {
+	check := entity.NewCheck(uuid.New(), location, true, 200, time.Second)
+	if err := repos.Checks.Create(context.Background(), check); !errors.Is(err, repository.ErrURLNotFound) {
+		t.Errorf("Create check: err = %v, want ErrURLNotFound", err)
+	}
+}
+
+func testDeleteCascadesChecks(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+
+	url := createURL(t, repos, "https://example.com")
+	other := createURL(t, repos, "https://other.example.com")
+	for i := range 3 {
+		createCheck(t, repos, url.ID, base.Add(time.Duration(i)*time.Second))
+	}
+	createCheck(t, repos, other.ID, base)
+
+	if err := repos.URLs.Delete(ctx, url.ProjectID, url.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	checks, err := repos.Checks.ListByURLID(ctx, uuid.Nil, url.ID)
+	if err != nil {
+		t.Fatalf("ListByURLID: %v", err)
+	}
+	if len(checks) != 0 {
+		t.Errorf("ListByURLID after delete returned %d checks, want none", len(checks))
+	}
+
+	latest, err := repos.Checks.GetLatestByURLID(ctx, uuid.Nil, url.ID)
+	if err != nil || latest != nil {
+		t.Errorf("GetLatestByURLID after delete = %+v, %v, want nil", latest, err)
+	}
+
+	checks, err = repos.Checks.ListByURLID(ctx, other.ProjectID, other.ID)
+	if err != nil || len(checks) != 1 {
+		t.Errorf("ListByURLID of the other url returned %d checks, %v, want 1", len(checks), err)
+	}
+}
+
+func testListByURLIDOrder(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+
+	url := createURL(t, repos, "https://example.com")
+
+	// Inserted out of order, listed newest first
+	var want []uuid.UUID
+	for _, offset := range []int{1, 3, 0, 2} {
+		check := createCheck(t, repos, url.ID, base.Add(time.Duration(offset)*time.Minute))
+		want = append(want, check.ID)
+	}
+	want = []uuid.UUID{want[1], want[3], want[0], want[2]}
+
+	checks, err := repos.Checks.ListByURLID(ctx, url.ProjectID, url.ID)
+	if err != nil {
+		t.Fatalf("ListByURLID: %v", err)
+	}
+	if len(checks) != len(want) {
+		t.Fatalf("ListByURLID returned %d checks, want %d", len(checks), len(want))
+	}
+	for i := range want {
+		if checks[i].ID != want[i] {
+			t.Fatalf("checks[%d] checked at %s, want newest first", i, checks[i].CheckedAt)
+		}
+	}
+
+	checks, err = repos.Checks.ListByURLID(ctx, uuid.New(), url.ID)
+	if err != nil {
+		t.Fatalf("ListByURLID other project: %v", err)
+	}
+	if len(checks) != 0 {
+		t.Errorf("ListByURLID other project returned %d checks, want none", len(checks))
+	}
+}
+
+func testGetLatestEmpty(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+
+	url := createURL(t, repos, "https://example.com")
+
+	latest, err := repos.Checks.GetLatestByURLID(ctx, url.ProjectID, url.ID)
+	if err != nil {
+		t.Fatalf("GetLatestByURLID: %v", err)
+	}
+	if latest != nil {
+		t.Errorf("GetLatestByURLID = %+v, want nil without checks", latest)
+	}
+}
+
+func testGetLatest(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+
+	url := createURL(t, repos, "https://example.com")
+	createCheck(t, repos, url.ID, base)
+	want := createCheck(t, repos, url.ID, base.Add(time.Minute))
+	createCheck(t, repos, url.ID, base.Add(-time.Minute))
+
+	latest, err := repos.Checks.GetLatestByURLID(ctx, url.ProjectID, url.ID)
+	if err != nil {
+		t.Fatalf("GetLatestByURLID: %v", err)
+	}
+	if latest == nil || latest.ID != want.ID {
+		t.Fatalf("GetLatestByURLID = %+v, want %+v", latest, want)
+	}
+	if !latest.CheckedAt.Equal(want.CheckedAt) {
+		t.Errorf("checked at = %s, want %s", latest.CheckedAt, want.CheckedAt)
+	}
+}
+
+func testDurationPrecision(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+
+	url := newURL(t, "https://example.com")
+	url.CheckInterval = 90*time.Second + 250*time.Millisecond
+	if err := repos.URLs.Create(ctx, url); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	check := entity.NewCheck(url.ID, location, true, 200, 1234567*time.Microsecond)
+	check.CheckedAt = base
+	if err := repos.Checks.Create(ctx, check); err != nil {
+		t.Fatalf("Create check: %v", err)
+	}
+	if _, err := repos.URLs.RecordStatus(ctx, check, entity.StateUp); err != nil {
+		t.Fatalf("RecordStatus: %v", err)
+	}
+
+	got, err := repos.URLs.GetByID(ctx, url.ProjectID, url.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if got.CheckInterval != url.CheckInterval {
+		t.Errorf("check interval = %s, want %s", got.CheckInterval, url.CheckInterval)
+	}
+	if got.Status == nil {
+		t.Fatal("status = nil after RecordStatus")
+	}
+	if got.Status.LastDuration != check.Duration {
+		t.Errorf("last duration = %s, want %s", got.Status.LastDuration, check.Duration)
+	}
+
+	latest, err := repos.Checks.GetLatestByURLID(ctx, url.ProjectID, url.ID)
+	if err != nil || latest == nil {
+		t.Fatalf("GetLatestByURLID = %+v, %v", latest, err)
+	}
+	if latest.Duration != check.Duration {
+		t.Errorf("check duration = %s, want %s", latest.Duration, check.Duration)
+	}
+
+	checks, err := repos.Checks.ListByURLID(ctx, url.ProjectID, url.ID)
+	if err != nil || len(checks) != 1 {
+		t.Fatalf("ListByURLID returned %d checks, %v", len(checks), err)
+	}
+	if checks[0].Duration != check.Duration {
+		t.Errorf("listed check duration = %s, want %s", checks[0].Duration, check.Duration)
+	}
+}
+
+func testUptimeSort(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+
+	flaky := createURL(t, repos, "https://flaky.example.com")
+	healthy := createURL(t, repos, "https://healthy.example.com")
+	unchecked := createURL(t, repos, "https://unchecked.example.com")
+
+	// Checks older than 24 hours before the last one do not count
+	results := map[*entity.URL][]bool{
+		flaky:   {false, false, true, false, true},
+		healthy: {false, true, true},
+	}
+	for url, statuses := range results {
+		var last *entity.Check
+		for i, status := range statuses {
+			last = entity.NewCheck(url.ID, location, status, 200, 250*time.Millisecond)
+			last.CheckedAt = base.Add(time.Duration(i-len(statuses)+1) * 12 * time.Hour)
+			if err := repos.Checks.Create(ctx, last); err != nil {
+				t.Fatalf("Create check: %v", err)
+			}
+		}
+		if _, err := repos.URLs.RecordStatus(ctx, last, entity.StateUp); err != nil {
+			t.Fatalf("RecordStatus: %v", err)
+		}
+	}
+
+	got, err := repos.URLs.GetByID(ctx, flaky.ProjectID, flaky.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if got.Status == nil || got.Status.Uptime != 0.5 {
+		t.Errorf("status = %+v, want uptime 0.5", got.Status)
+	}
+
+	// The sort key is stored, so it stays the same while paging
+	ids := listPages(t, repos, repository.URLFilter{Sort: repository.URLSortUptime, Limit: 1})
+	want := []uuid.UUID{unchecked.ID, flaky.ID, healthy.ID}
+	if !slices.Equal(ids, want) {
+		t.Errorf("List by uptime = %v, want %v", ids, want)
+	}
+}
+
+func testLastCheckedSort(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+
+	var unchecked, checked []uuid.UUID
+	for i := range 3 {
+		unchecked = append(unchecked, createURL(t, repos, fmt.Sprintf("https://unchecked%d.example.com", i)).ID)
+
+		url := createURL(t, repos, fmt.Sprintf("https://checked%d.example.com", i))
+		check := createCheck(t, repos, url.ID, base.Add(time.Duration(i)*time.Minute))
+		if _, err := repos.URLs.RecordStatus(ctx, check, entity.StateUp); err != nil {
+			t.Fatalf("RecordStatus: %v", err)
+		}
+		checked = append(checked, url.ID)
+	}
+	slices.SortFunc(unchecked, func(a, b uuid.UUID) int { return slices.Compare(a[:], b[:]) })
+
+	// URLs never checked come first, also when a page ends among them
+	want := append(slices.Clone(unchecked), checked...)
+	reversed := slices.Clone(want)
+	slices.Reverse(reversed)
+	for _, limit := range []int{1, 2} {
+		ids := listPages(t, repos, repository.URLFilter{Sort: repository.URLSortLastChecked, Limit: limit})
+		if !slices.Equal(ids, want) {
+			t.Errorf("List by last check with limit %d = %v, want %v", limit, ids, want)
+		}
+
+		ids = listPages(t, repos, repository.URLFilter{Sort: repository.URLSortLastChecked, Desc: true, Limit: limit})
+		if !slices.Equal(ids, reversed) {
+			t.Errorf("List by last check descending with limit %d = %v, want %v", limit, ids, reversed)
+		}
+	}
+}
+
+// listPages lists every page of URLs matching the filter and returns their IDs in order
+func listPages(t *testing.T, repos Repositories, filter repository.URLFilter) []uuid.UUID {
+	t.Helper()
+
+	var ids []uuid.UUID
+	for {
+		page, err := repos.URLs.List(context.Background(), entity.DefaultProjectID, filter)
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		for _, url := range page.URLs {
+			ids = append(ids, url.ID)
+		}
+		if page.Next == nil {
+			return ids
+		}
+		filter.After = page.Next
+	}
+}
+
+func testPausedState(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+
+	paused := createURL(t, repos, "https://paused.example.com")
+	active := createURL(t, repos, "https://active.example.com")
+	for _, url := range []*entity.URL{paused, active} {
+		check := createCheck(t, repos, url.ID, base)
+		if _, err := repos.URLs.RecordStatus(ctx, check, entity.StateUp); err != nil {
+			t.Fatalf("RecordStatus: %v", err)
+		}
+	}
+
+	paused.Paused = true
+	if err := repos.URLs.Update(ctx, paused); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	got, err := repos.URLs.GetByID(ctx, paused.ProjectID, paused.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if !got.Paused {
+		t.Error("paused = false after Update")
+	}
+
+	// A paused URL is listed as paused whatever its last status
+	for state, want := range map[entity.State]*entity.URL{entity.StatePaused: paused, entity.StateUp: active} {
+		page, err := repos.URLs.List(ctx, entity.DefaultProjectID, repository.URLFilter{State: state})
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		if len(page.URLs) != 1 || page.URLs[0].ID != want.ID {
+			t.Errorf("List(state=%s) returned %d urls, want only %s", state, len(page.URLs), want.Address)
+		}
+	}
+}
+
+func testDeleteBefore(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+
+	url := createURL(t, repos, "https://example.com")
+	old := createCheck(t, repos, url.ID, base.Add(-30*24*time.Hour))
+	recent := createCheck(t, repos, url.ID, base)
+
+	// Well past the week of the old check, so partitioned storage drops it too
+	if err := repos.Checks.DeleteBefore(ctx, base.Add(-24*time.Hour)); err != nil {
+		t.Fatalf("DeleteBefore: %v", err)
+	}
+
+	checks, err := repos.Checks.ListByURLID(ctx, url.ProjectID, url.ID)
+	if err != nil {
+		t.Fatalf("ListByURLID: %v", err)
+	}
+	if len(checks) != 1 || checks[0].ID != recent.ID {
+		t.Errorf("ListByURLID after DeleteBefore returned %d checks, want only %s and not %s", len(checks), recent.ID, old.ID)
+	}
+}
+
+func testContentWatch(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+
+	watch := &entity.ContentWatch{
+		IgnoreSelectors: []string{"#clock", "div.ad"},
+		IgnorePatterns:  []string{`\d{2}:\d{2}`},
+		Alert:           true,
+	}
+	watched := newURL(t, "https://example.com/watched")
+	watched.ContentWatch = watch
+	if err := repos.URLs.Create(ctx, watched); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	plain := createURL(t, repos, "https://example.com/plain")
+
+	got, err := repos.URLs.GetByID(ctx, watched.ProjectID, watched.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if !got.ContentWatch.Equal(watch) {
+		t.Errorf("content watch = %+v, want %+v", got.ContentWatch, watch)
+	}
+
+	got, err = repos.URLs.GetByID(ctx, plain.ProjectID, plain.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if got.ContentWatch != nil {
+		t.Errorf("content watch = %+v, want nil when not set", got.ContentWatch)
+	}
+
+	// Updating a definition replaces its content watch, nil disables it
+	update := newURL(t, watched.Address)
+	if err := repos.URLs.Import(ctx, entity.DefaultProjectID, nil, []*entity.URL{update}); err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	got, err = repos.URLs.GetByID(ctx, watched.ProjectID, watched.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if got.ContentWatch != nil {
+		t.Errorf("content watch after update = %+v, want nil", got.ContentWatch)
+	}
+}
+
+func testContentSnapshot(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+
+	url := createURL(t, repos, "https://example.com")
+
+	snapshot, err := repos.Contents.GetSnapshot(ctx, url.ID)
+	if err != nil || snapshot != nil {
+		t.Fatalf("GetSnapshot before the first save = %+v, %v, want nil", snapshot, err)
+	}
+
+	first := entity.NewContentSnapshot(url.ID, "Hello\nWorld")
+	first.CapturedAt = base
+	if err := repos.Contents.SaveSnapshot(ctx, first, nil); err != nil {
+		t.Fatalf("SaveSnapshot: %v", err)
+	}
+
+	second := entity.NewContentSnapshot(url.ID, "Hello\nEveryone")
+	second.CapturedAt = base.Add(time.Minute)
+	change := entity.NewContentChange(first, second, "-World\n+Everyone\n")
+	if err := repos.Contents.SaveSnapshot(ctx, second, change); err != nil {
+		t.Fatalf("SaveSnapshot with change: %v", err)
+	}
+
+	snapshot, err = repos.Contents.GetSnapshot(ctx, url.ID)
+	if err != nil {
+		t.Fatalf("GetSnapshot: %v", err)
+	}
+	if snapshot.URLID != url.ID || snapshot.Hash != second.Hash || snapshot.Text != second.Text ||
+		!snapshot.CapturedAt.Equal(second.CapturedAt) {
+		t.Errorf("snapshot = %+v, want %+v", snapshot, second)
+	}
+
+	changes, err := repos.Contents.ListChanges(ctx, url.ID)
+	if err != nil {
+		t.Fatalf("ListChanges: %v", err)
+	}
+	if len(changes) != 1 {
+		t.Fatalf("ListChanges returned %d changes, want 1", len(changes))
+	}
+	got := changes[0]
+	if got.ID != change.ID || got.URLID != url.ID || got.PreviousHash != first.Hash ||
+		got.Hash != second.Hash || got.Diff != change.Diff || !got.DetectedAt.Equal(second.CapturedAt) {
+		t.Errorf("change = %+v, want %+v", got, change)
+	}
+}
+
+func testContentBaselineReset(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+
+	url := newURL(t, "https://example.com")
+	url.ContentWatch = &entity.ContentWatch{IgnoreSelectors: []string{"#clock"}}
+	if err := repos.URLs.Create(ctx, url); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	saveSnapshot := func() {
+		t.Helper()
+		if err := repos.Contents.SaveSnapshot(ctx, entity.NewContentSnapshot(url.ID, "Hello"), nil); err != nil {
+			t.Fatalf("SaveSnapshot: %v", err)
+		}
+	}
+	assertSnapshot := func(step string, kept bool) {
+		t.Helper()
+		snapshot, err := repos.Contents.GetSnapshot(ctx, url.ID)
+		if err != nil {
+			t.Fatalf("GetSnapshot %s: %v", step, err)
+		}
+		if (snapshot != nil) != kept {
+			t.Errorf("snapshot %s = %+v, kept = %v", step, snapshot, kept)
+		}
+	}
+
+	// Alerting does not change how bodies are normalized, the baseline is kept
+	saveSnapshot()
+	url.ContentWatch = &entity.ContentWatch{IgnoreSelectors: []string{"#clock"}, Alert: true}
+	if err := repos.URLs.Update(ctx, url); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	assertSnapshot("after enabling alerts", true)
+
+	// New ignore rules reset the baseline, through an update as well as an import
+	url.ContentWatch = &entity.ContentWatch{IgnorePatterns: []string{`\d+`}}
+	if err := repos.URLs.Update(ctx, url); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	assertSnapshot("after updating the rules", false)
+
+	saveSnapshot()
+	imported := newURL(t, url.Address)
+	imported.ContentWatch = &entity.ContentWatch{IgnoreSelectors: []string{"#ad"}}
+	if err := repos.URLs.Import(ctx, url.ProjectID, nil, []*entity.URL{imported}); err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	assertSnapshot("after importing new rules", false)
+
+	saveSnapshot()
+	imported.ContentWatch = &entity.ContentWatch{IgnoreSelectors: []string{"#ad"}}
+	if err := repos.URLs.Import(ctx, url.ProjectID, nil, []*entity.URL{imported}); err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	assertSnapshot("after importing the same rules", true)
+}
+
+func testContentChangesOrder(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+
+	url := createURL(t, repos, "https://example.com")
+	previous := entity.NewContentSnapshot(url.ID, "v0")
+	previous.CapturedAt = base
+	if err := repos.Contents.SaveSnapshot(ctx, previous, nil); err != nil {
+		t.Fatalf("SaveSnapshot: %v", err)
+	}
+
+	var want []uuid.UUID
+	for i := 1; i <= 3; i++ {
+		snapshot := entity.NewContentSnapshot(url.ID, fmt.Sprintf("v%d", i))
+		snapshot.CapturedAt = base.Add(time.Duration(i) * time.Minute)
+		change := entity.NewContentChange(previous, snapshot, "")
+		if err := repos.Contents.SaveSnapshot(ctx, snapshot, change); err != nil {
+			t.Fatalf("SaveSnapshot: %v", err)
+		}
+		want = append([]uuid.UUID{change.ID}, want...)
+		previous = snapshot
+	}
+
+	changes, err := repos.Contents.ListChanges(ctx, url.ID)
+	if err != nil {
+		t.Fatalf("ListChanges: %v", err)
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("ListChanges returned %d changes, want %d", len(changes), len(want))
+	}
+	for i, change := range changes {
+		if change.ID != want[i] {
+			t.Errorf("changes[%d] = %s, want %s, most recent first", i, change.ID, want[i])
+		}
+	}
+}
+
+func testContentForUnknownURL(t *testing.T, repos Repositories) {
+	snapshot := entity.NewContentSnapshot(uuid.New(), "Hello")
+	if err := repos.Contents.SaveSnapshot(context.Background(), snapshot, nil); !errors.Is(err, repository.ErrURLNotFound) {
+		t.Errorf("SaveSnapshot: err = %v, want ErrURLNotFound", err)
+	}
+}
+
+func testDeleteCascadesContent(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+
+	url := createURL(t, repos, "https://example.com")
+	first := entity.NewContentSnapshot(url.ID, "Hello")
+	second := entity.NewContentSnapshot(url.ID, "Goodbye")
+	if err := repos.Contents.SaveSnapshot(ctx, first, nil); err != nil {
+		t.Fatalf("SaveSnapshot: %v", err)
+	}
+	if err := repos.Contents.SaveSnapshot(ctx, second, entity.NewContentChange(first, second, "")); err != nil {
+		t.Fatalf("SaveSnapshot with change: %v", err)
+	}
+
+	if err := repos.URLs.Delete(ctx, url.ProjectID, url.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	snapshot, err := repos.Contents.GetSnapshot(ctx, url.ID)
+	if err != nil || snapshot != nil {
+		t.Errorf("GetSnapshot after delete = %+v, %v, want nil", snapshot, err)
+	}
+	changes, err := repos.Contents.ListChanges(ctx, url.ID)
+	if err != nil || len(changes) != 0 {
+		t.Errorf("ListChanges after delete returned %d changes, %v, want none", len(changes), err)
+	}
+}
+
+func testRedirectPolicy(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+
+	limited := newURL(t, "https://example.com/limited")
+	limited.Redirects = 3
+	limited.ExpectFinalURL = "https://www.example.com/limited"
+	limited.ExpectRedirectDomain = "example.com"
+	if err := repos.URLs.Create(ctx, limited); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	plain := createURL(t, repos, "https://example.com/plain")
+
+	for _, want := range []*entity.URL{limited, plain} {
+		got, err := repos.URLs.GetByID(ctx, want.ProjectID, want.ID)
+		if err != nil {
+			t.Fatalf("GetByID: %v", err)
+		}
+		if got.Redirects != want.Redirects {
+			t.Errorf("%s: redirect policy = %s, want %s", want.Address, got.Redirects, want.Redirects)
+		}
+		if got.ExpectFinalURL != want.ExpectFinalURL || got.ExpectRedirectDomain != want.ExpectRedirectDomain {
+			t.Errorf("%s: redirect expectations = %q, %q, want %q, %q", want.Address,
+				got.ExpectFinalURL, got.ExpectRedirectDomain, want.ExpectFinalURL, want.ExpectRedirectDomain)
+		}
+	}
+
+	// Updating a definition replaces its redirect policy and expectations
+	update := newURL(t, limited.Address)
+	update.Redirects = entity.NoRedirects
+	update.ExpectRedirectDomain = "example.org"
+	if err := repos.URLs.Import(ctx, entity.DefaultProjectID, nil, []*entity.URL{update}); err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	got, err := repos.URLs.GetByID(ctx, limited.ProjectID, limited.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if got.Redirects != entity.NoRedirects {
+		t.Errorf("redirect policy after update = %s, want off", got.Redirects)
+	}
+	if got.ExpectFinalURL != "" || got.ExpectRedirectDomain != "example.org" {
+		t.Errorf("redirect expectations after update = %q, %q, want none and example.org",
+			got.ExpectFinalURL, got.ExpectRedirectDomain)
+	}
+}
+
+func testCheckRedirects(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+
+	url := createURL(t, repos, "https://example.com")
+	createCheck(t, repos, url.ID, base)
+
+	redirects := []entity.Redirect{
+		{Code: 301, Location: "https://www.example.com/"},
+		{Code: 302, Location: "https://www.example.com/login?next=%2F"},
+	}
+	redirected := entity.NewCheck(url.ID, location, false, 302, time.Second)
+	redirected.Redirects = redirects
+	redirected.CheckedAt = base.Add(time.Minute)
+	if err := repos.Checks.Create(ctx, redirected); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	checks, err := repos.Checks.ListByURLID(ctx, url.ProjectID, url.ID)
+	if err != nil {
+		t.Fatalf("ListByURLID: %v", err)
+	}
+	if len(checks) != 2 {
+		t.Fatalf("ListByURLID returned %d checks, want 2", len(checks))
+	}
+	if !slices.Equal(checks[0].Redirects, redirects) {
+		t.Errorf("redirects = %+v, want %+v", checks[0].Redirects, redirects)
+	}
+	if len(checks[1].Redirects) != 0 {
+		t.Errorf("redirects of a check without redirects = %+v, want none", checks[1].Redirects)
+	}
+
+	latest, err := repos.Checks.GetLatestByURLID(ctx, url.ProjectID, url.ID)
+	if err != nil {
+		t.Fatalf("GetLatestByURLID: %v", err)
+	}
+	if latest == nil || !slices.Equal(latest.Redirects, redirects) {
+		t.Errorf("GetLatestByURLID = %+v, want redirects %+v", latest, redirects)
+	}
+
+	byLocation, err := repos.Checks.ListLatestByLocation(ctx, url.ProjectID, url.ID)
+	if err != nil {
+		t.Fatalf("ListLatestByLocation: %v", err)
+	}
+	if len(byLocation) != 1 || !slices.Equal(byLocation[0].Redirects, redirects) {
+		t.Errorf("ListLatestByLocation = %+v, want one check with redirects %+v", byLocation, redirects)
+	}
+}
+
+func addresses(urls []*entity.URL) []string {
+	var result []string
+	for _, url := range urls {
+		result = append(result, url.Address)
+	}
+	return result
+}
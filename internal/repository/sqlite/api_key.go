@@ -0,0 +1,165 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"url-sentinel/internal/domain/entity"
+	"url-sentinel/internal/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+type apiKeyRepository struct {
+	db *sql.DB
+}
+
+// NewAPIKeyRepository creates a new SQLite API key repository
+func NewAPIKeyRepository(db *sql.DB) repository.APIKeyRepository {
+	return &apiKeyRepository{db: db}
+}
+
+func (r *apiKeyRepository) Create(ctx context.Context, key *entity.APIKey) error {
+	query := `
+		INSERT INTO api_keys (id, project_id, name, prefix, key_hash, scopes, expires_at, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	scopes, err := json.Marshal(key.Scopes)
+	if err != nil {
+		return fmt.Errorf("failed to encode api key scopes: %w", err)
+	}
+
+	_, err = r.db.ExecContext(
+		ctx,
+		query,
+		key.ID,
+		key.ProjectID,
+		key.Name,
+		key.Prefix,
+		key.Hash,
+		string(scopes),
+		toNullNanos(key.ExpiresAt),
+		toNanos(key.CreatedAt),
+	)
+
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return fmt.Errorf("failed to create api key: %w", repository.ErrProjectNotFound)
+		}
+		return fmt.Errorf("failed to create api key: %w", err)
+	}
+
+	return nil
+}
+
+func (r *apiKeyRepository) GetByPrefix(ctx context.Context, prefix string) (*entity.APIKey, error) {
+	query := `
+		SELECT id, project_id, name, prefix, key_hash, scopes, expires_at, last_used_at, created_at
+		FROM api_keys
+		WHERE prefix = ?
+	`
+
+	key, err := scanAPIKey(r.db.QueryRowContext(ctx, query, prefix))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrAPIKeyNotFound
+		}
+		return nil, fmt.Errorf("failed to get api key by prefix: %w", err)
+	}
+
+	return key, nil
+}
+
+func (r *apiKeyRepository) List(ctx context.Context) ([]*entity.APIKey, error) {
+	query := `
+		SELECT id, project_id, name, prefix, key_hash, scopes, expires_at, last_used_at, created_at
+		FROM api_keys
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []*entity.APIKey
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan api key: %w", err)
+		}
+		keys = append(keys, key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return keys, nil
+}
+
+func (r *apiKeyRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM api_keys WHERE id = ?`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete api key: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrAPIKeyNotFound
+	}
+
+	return nil
+}
+
+func (r *apiKeyRepository) TouchLastUsed(ctx context.Context, id uuid.UUID, usedAt time.Time) error {
+	query := `UPDATE api_keys SET last_used_at = ? WHERE id = ?`
+
+	if _, err := r.db.ExecContext(ctx, query, toNanos(usedAt), id); err != nil {
+		return fmt.Errorf("failed to update api key last used: %w", err)
+	}
+
+	return nil
+}
+
+func scanAPIKey(row rowScanner) (*entity.APIKey, error) {
+	var key entity.APIKey
+	var scopes string
+	var expiresAt, lastUsedAt sql.NullInt64
+	var createdAt int64
+
+	if err := row.Scan(
+		&key.ID,
+		&key.ProjectID,
+		&key.Name,
+		&key.Prefix,
+		&key.Hash,
+		&scopes,
+		&expiresAt,
+		&lastUsedAt,
+		&createdAt,
+	); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal([]byte(scopes), &key.Scopes); err != nil {
+		return nil, fmt.Errorf("failed to decode api key scopes: %w", err)
+	}
+	key.ExpiresAt = fromNullNanos(expiresAt)
+	key.LastUsedAt = fromNullNanos(lastUsedAt)
+	key.CreatedAt = fromNanos(createdAt)
+
+	return &key, nil
+}
@@ -0,0 +1,353 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"url-sentinel/internal/domain/entity"
+	"url-sentinel/internal/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+// checkColumns selects a check, its scope condition takes the URL ID as ?1 and the project as ?2
+const (
+	checkColumns = `id, url_id, location, "trigger", status, code, duration, redirects, checked_at`
+	checkScope   = `url_id = ?1 AND (?2 IS NULL OR url_id IN (SELECT id FROM urls WHERE project_id = ?2))`
+)
+
+// nanosPerDay buckets Unix nanoseconds into UTC days
+const nanosPerDay = int64(24 * time.Hour)
+
+type checkRepository struct {
+	db *sql.DB
+}
+
+// NewCheckRepository creates a new SQLite check repository
+func NewCheckRepository(db *sql.DB) repository.CheckRepository {
+	return &checkRepository{db: db}
+}
+
+func (r *checkRepository) Create(ctx context.Context, check *entity.Check) error {
+	query := `
+		INSERT INTO checks (` + checkColumns + `)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	redirects, err := encodeRedirects(check.Redirects)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(
+		ctx,
+		query,
+		check.ID,
+		check.URLID,
+		check.Location,
+		check.Trigger,
+		check.Status,
+		check.Code,
+		int64(check.Duration),
+		redirects,
+		toNanos(check.CheckedAt),
+	)
+
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return repository.ErrURLNotFound
+		}
+		return fmt.Errorf("failed to create check: %w", err)
+	}
+
+	return nil
+}
+
+func (r *checkRepository) ListByURLID(ctx context.Context, projectID, urlID uuid.UUID) ([]*entity.Check, error) {
+	query := `
+		SELECT ` + checkColumns + `
+		FROM checks
+		WHERE ` + checkScope + `
+		ORDER BY checked_at DESC
+	`
+
+	checks, err := r.list(ctx, query, urlID, projectScope(projectID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list checks: %w", err)
+	}
+
+	return checks, nil
+}
+
+func (r *checkRepository) GetLatestByURLID(ctx context.Context, projectID, urlID uuid.UUID) (*entity.Check, error) {
+	query := `
+		SELECT ` + checkColumns + `
+		FROM checks
+		WHERE ` + checkScope + `
+		ORDER BY checked_at DESC
+		LIMIT 1
+	`
+
+	check, err := scanCheck(r.db.QueryRowContext(ctx, query, urlID, projectScope(projectID)))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil // No checks yet
+		}
+		return nil, fmt.Errorf("failed to get latest check: %w", err)
+	}
+
+	return check, nil
+}
+
+func (r *checkRepository) ListLatestByLocation(ctx context.Context, projectID, urlID uuid.UUID) ([]*entity.Check, error) {
+	query := `
+		SELECT ` + checkColumns + `
+		FROM (
+			SELECT *, ROW_NUMBER() OVER (PARTITION BY location ORDER BY checked_at DESC) AS rank
+			FROM checks
+			WHERE ` + checkScope + `
+		)
+		WHERE rank = 1
+		ORDER BY location
+	`
+
+	checks, err := r.list(ctx, query, urlID, projectScope(projectID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list latest checks by location: %w", err)
+	}
+
+	return checks, nil
+}
+
+func (r *checkRepository) DailyUptime(ctx context.Context, projectID, urlID uuid.UUID, since time.Time) ([]*entity.DailyUptime, error) {
+	query := `
+		SELECT checked_at / ?3 * ?3 AS day,
+			COUNT(*) AS total,
+			COALESCE(SUM(status), 0) AS successful
+		FROM checks
+		WHERE ` + checkScope + ` AND checked_at >= ?4
+		GROUP BY day
+		ORDER BY day ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, urlID, projectScope(projectID), nanosPerDay, toNanos(since))
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate daily uptime: %w", err)
+	}
+	defer rows.Close()
+
+	var days []*entity.DailyUptime
+	for rows.Next() {
+		var day entity.DailyUptime
+		var dayNs int64
+
+		if err := rows.Scan(&dayNs, &day.Total, &day.Successful); err != nil {
+			return nil, fmt.Errorf("failed to scan daily uptime: %w", err)
+		}
+
+		day.Day = fromNanos(dayNs)
+		days = append(days, &day)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return days, nil
+}
+
+func (r *checkRepository) Stats(ctx context.Context, projectID, urlID uuid.UUID, since time.Time) (*entity.CheckStats, error) {
+	query := `
+		SELECT COUNT(*) AS total,
+			COALESCE(SUM(status), 0) AS successful,
+			COALESCE(AVG(duration), 0) AS avg_duration_ns
+		FROM checks
+		WHERE ` + checkScope + ` AND checked_at >= ?3
+	`
+
+	var stats entity.CheckStats
+	var avgNs float64
+
+	err := r.db.QueryRowContext(ctx, query, urlID, projectScope(projectID), toNanos(since)).Scan(
+		&stats.Total,
+		&stats.Successful,
+		&avgNs,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate check stats: %w", err)
+	}
+
+	stats.AvgDuration = time.Duration(avgNs)
+
+	return &stats, nil
+}
+
+func (r *checkRepository) StatsByLabel(
+	ctx context.Context,
+	projectID uuid.UUID,
+	key string,
+	selector entity.LabelSelector,
+	since time.Time,
+) ([]*entity.LabelGroupStats, error) {
+	args := []any{projectID, labelPath(key), toNanos(since)}
+	var conditions []string
+	for k, v := range selector {
+		args = append(args, labelPath(k), v)
+		conditions = append(conditions, fmt.Sprintf(" AND json_extract(u.labels, ?%d) = ?%d", len(args)-1, len(args)))
+	}
+
+	query := `
+		SELECT json_extract(u.labels, ?2) AS value,
+			COUNT(DISTINCT u.id) AS urls,
+			COUNT(c.id) AS total,
+			COALESCE(SUM(c.status), 0) AS successful,
+			COALESCE(AVG(c.duration), 0) AS avg_duration_ns
+		FROM urls u
+		LEFT JOIN checks c ON c.url_id = u.id AND c.checked_at >= ?3
+		WHERE u.project_id = ?1 AND json_type(u.labels, ?2) IS NOT NULL` + strings.Join(conditions, "") + `
+		GROUP BY value
+		ORDER BY value ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate check stats by label: %w", err)
+	}
+	defer rows.Close()
+
+	var groups []*entity.LabelGroupStats
+	for rows.Next() {
+		var group entity.LabelGroupStats
+		var avgNs float64
+
+		if err := rows.Scan(
+			&group.Value,
+			&group.URLs,
+			&group.Total,
+			&group.Successful,
+			&avgNs,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan label stats: %w", err)
+		}
+
+		group.AvgDuration = time.Duration(avgNs)
+		groups = append(groups, &group)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return groups, nil
+}
+
+func (r *checkRepository) DeleteBefore(ctx context.Context, before time.Time) error {
+	query := `DELETE FROM checks WHERE checked_at < ?`
+
+	if _, err := r.db.ExecContext(ctx, query, toNanos(before)); err != nil {
+		return fmt.Errorf("failed to delete expired checks: %w", err)
+	}
+
+	return nil
+}
+
+func (r *checkRepository) list(ctx context.Context, query string, args ...any) ([]*entity.Check, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var checks []*entity.Check
+	for rows.Next() {
+		check, err := scanCheck(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan check: %w", err)
+		}
+		checks = append(checks, check)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return checks, nil
+}
+
+func scanCheck(row rowScanner) (*entity.Check, error) {
+	var check entity.Check
+	var durationNs, checkedAt int64
+	var redirects sql.NullString
+
+	if err := row.Scan(
+		&check.ID,
+		&check.URLID,
+		&check.Location,
+		&check.Trigger,
+		&check.Status,
+		&check.Code,
+		&durationNs,
+		&redirects,
+		&checkedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	check.Duration = time.Duration(durationNs)
+	check.CheckedAt = fromNanos(checkedAt)
+	chain, err := decodeRedirects(redirects)
+	if err != nil {
+		return nil, err
+	}
+	check.Redirects = chain
+
+	return &check, nil
+}
+
+// redirect is the JSON representation of entity.Redirect
+type redirect struct {
+	Code     int    `json:"code"`
+	Location string `json:"location"`
+}
+
+// encodeRedirects converts the redirect chain of a check into a JSON array, NULL when empty
+func encodeRedirects(redirects []entity.Redirect) (sql.NullString, error) {
+	if len(redirects) == 0 {
+		return sql.NullString{}, nil
+	}
+
+	chain := make([]redirect, 0, len(redirects))
+	for _, r := range redirects {
+		chain = append(chain, redirect(r))
+	}
+	data, err := json.Marshal(chain)
+	if err != nil {
+		return sql.NullString{}, fmt.Errorf("failed to encode check redirects: %w", err)
+	}
+
+	return sql.NullString{String: string(data), Valid: true}, nil
+}
+
+// decodeRedirects converts a JSON array into the redirect chain of a check, nil for NULL
+func decodeRedirects(data sql.NullString) ([]entity.Redirect, error) {
+	if !data.Valid {
+		return nil, nil
+	}
+
+	var chain []redirect
+	if err := json.Unmarshal([]byte(data.String), &chain); err != nil {
+		return nil, fmt.Errorf("failed to decode check redirects: %w", err)
+	}
+
+	redirects := make([]entity.Redirect, 0, len(chain))
+	for _, r := range chain {
+		redirects = append(redirects, entity.Redirect(r))
+	}
+
+	return redirects, nil
+}
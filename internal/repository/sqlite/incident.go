@@ -0,0 +1,159 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"url-sentinel/internal/domain/entity"
+	"url-sentinel/internal/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+type incidentRepository struct {
+	db *sql.DB
+}
+
+// NewIncidentRepository creates a new SQLite incident repository
+func NewIncidentRepository(db *sql.DB) repository.IncidentRepository {
+	return &incidentRepository{db: db}
+}
+
+// locationStatus is the JSON representation of entity.LocationStatus
+type locationStatus struct {
+	Location  string    `json:"location"`
+	Status    bool      `json:"status"`
+	Code      int       `json:"code"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+func (r *incidentRepository) Create(ctx context.Context, incident *entity.Incident) error {
+	query := `
+		INSERT INTO incidents (id, url_id, locations, started_at, resolved_at)
+		VALUES (?, ?, ?, ?, ?)
+	`
+
+	locations := make([]locationStatus, 0, len(incident.Locations))
+	for _, l := range incident.Locations {
+		locations = append(locations, locationStatus(l))
+	}
+
+	data, err := json.Marshal(locations)
+	if err != nil {
+		return fmt.Errorf("failed to encode incident locations: %w", err)
+	}
+
+	_, err = r.db.ExecContext(
+		ctx,
+		query,
+		incident.ID,
+		incident.URLID,
+		string(data),
+		toNanos(incident.StartedAt),
+		toNullNanos(incident.ResolvedAt),
+	)
+
+	if err != nil {
+		if isUniqueViolation(err) {
+			return repository.ErrIncidentAlreadyOpen
+		}
+		if isForeignKeyViolation(err) {
+			return fmt.Errorf("failed to create incident: %w", repository.ErrURLNotFound)
+		}
+		return fmt.Errorf("failed to create incident: %w", err)
+	}
+
+	return nil
+}
+
+func (r *incidentRepository) GetOpenByURLID(ctx context.Context, urlID uuid.UUID) (*entity.Incident, error) {
+	query := `
+		SELECT id, url_id, locations, started_at, resolved_at
+		FROM incidents
+		WHERE url_id = ? AND resolved_at IS NULL
+	`
+
+	incident, err := scanIncident(r.db.QueryRowContext(ctx, query, urlID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil // No open incident
+		}
+		return nil, fmt.Errorf("failed to get open incident: %w", err)
+	}
+
+	return incident, nil
+}
+
+func (r *incidentRepository) Resolve(ctx context.Context, id uuid.UUID, resolvedAt time.Time) error {
+	query := `UPDATE incidents SET resolved_at = ? WHERE id = ? AND resolved_at IS NULL`
+
+	if _, err := r.db.ExecContext(ctx, query, toNanos(resolvedAt), id); err != nil {
+		return fmt.Errorf("failed to resolve incident: %w", err)
+	}
+
+	return nil
+}
+
+func (r *incidentRepository) ListByURLID(ctx context.Context, urlID uuid.UUID) ([]*entity.Incident, error) {
+	query := `
+		SELECT id, url_id, locations, started_at, resolved_at
+		FROM incidents
+		WHERE url_id = ?
+		ORDER BY started_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, urlID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list incidents: %w", err)
+	}
+	defer rows.Close()
+
+	var incidents []*entity.Incident
+	for rows.Next() {
+		incident, err := scanIncident(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan incident: %w", err)
+		}
+		incidents = append(incidents, incident)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return incidents, nil
+}
+
+func scanIncident(row rowScanner) (*entity.Incident, error) {
+	var incident entity.Incident
+	var data string
+	var startedAt int64
+	var resolvedAt sql.NullInt64
+
+	if err := row.Scan(
+		&incident.ID,
+		&incident.URLID,
+		&data,
+		&startedAt,
+		&resolvedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	var locations []locationStatus
+	if err := json.Unmarshal([]byte(data), &locations); err != nil {
+		return nil, fmt.Errorf("failed to decode incident locations: %w", err)
+	}
+	for _, l := range locations {
+		incident.Locations = append(incident.Locations, entity.LocationStatus(l))
+	}
+
+	incident.StartedAt = fromNanos(startedAt)
+	incident.ResolvedAt = fromNullNanos(resolvedAt)
+
+	return &incident, nil
+}
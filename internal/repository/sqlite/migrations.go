@@ -0,0 +1,24 @@
+package sqlite
+
+import (
+	"database/sql"
+	"embed"
+
+	"url-sentinel/internal/repository/migrate"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// NewMigrator creates a migrator for the embedded migrations. A database file
+// is served by a single process, so no lock is taken.
+func NewMigrator(db *sql.DB) (*migrate.Migrator, error) {
+	migrations, err := migrate.Load(migrationFiles, "migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	return migrate.New(db, migrate.Dialect{
+		Placeholder: func(int) string { return "?" },
+	}, migrations), nil
+}
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS api_keys;
+DROP TABLE IF EXISTS status_pages;
+DROP TABLE IF EXISTS incidents;
+DROP TABLE IF EXISTS checks;
+DROP TABLE IF EXISTS url_status;
+DROP TABLE IF EXISTS urls;
+DROP TABLE IF EXISTS projects;
@@ -0,0 +1,93 @@
+-- Create projects table with the default project
+CREATE TABLE IF NOT EXISTS projects (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    max_urls INTEGER NOT NULL DEFAULT 0,
+    min_interval INTEGER NOT NULL DEFAULT 0,
+    created_at INTEGER NOT NULL
+);
+
+INSERT OR IGNORE INTO projects (id, name, created_at)
+VALUES ('00000000-0000-0000-0000-000000000001', 'default', CAST(strftime('%s', 'now') AS INTEGER) * 1000000000);
+
+-- Create URLs table
+CREATE TABLE IF NOT EXISTS urls (
+    id TEXT PRIMARY KEY,
+    project_id TEXT NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    address TEXT NOT NULL,
+    check_interval INTEGER NOT NULL,
+    quorum INTEGER NOT NULL DEFAULT 1,
+    public_token TEXT NOT NULL UNIQUE,
+    labels TEXT NOT NULL DEFAULT '{}',
+    created_at INTEGER NOT NULL,
+    UNIQUE (project_id, address)
+);
+
+CREATE INDEX IF NOT EXISTS idx_urls_project_created_at ON urls(project_id, created_at, id);
+
+-- Create current status table updated with every check
+CREATE TABLE IF NOT EXISTS url_status (
+    url_id TEXT PRIMARY KEY REFERENCES urls(id) ON DELETE CASCADE,
+    state TEXT NOT NULL,
+    last_status INTEGER NOT NULL,
+    last_code INTEGER NOT NULL,
+    last_duration INTEGER NOT NULL,
+    last_checked_at INTEGER NOT NULL,
+    state_since INTEGER NOT NULL,
+    consecutive_failures INTEGER NOT NULL DEFAULT 0
+);
+
+-- Create checks table
+CREATE TABLE IF NOT EXISTS checks (
+    id TEXT PRIMARY KEY,
+    url_id TEXT NOT NULL REFERENCES urls(id) ON DELETE CASCADE,
+    location TEXT NOT NULL DEFAULT 'central',
+    "trigger" TEXT NOT NULL DEFAULT 'scheduled',
+    status INTEGER NOT NULL,
+    code INTEGER NOT NULL DEFAULT 0,
+    duration INTEGER NOT NULL,
+    checked_at INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_checks_url_id_checked_at ON checks(url_id, checked_at DESC);
+CREATE INDEX IF NOT EXISTS idx_checks_url_id_location ON checks(url_id, location, checked_at DESC);
+
+-- Create incidents table, allowing at most one open incident per URL
+CREATE TABLE IF NOT EXISTS incidents (
+    id TEXT PRIMARY KEY,
+    url_id TEXT NOT NULL REFERENCES urls(id) ON DELETE CASCADE,
+    locations TEXT NOT NULL,
+    started_at INTEGER NOT NULL,
+    resolved_at INTEGER
+);
+
+CREATE INDEX IF NOT EXISTS idx_incidents_url_id ON incidents(url_id, started_at DESC);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_incidents_open ON incidents(url_id) WHERE resolved_at IS NULL;
+
+-- Create status pages table
+CREATE TABLE IF NOT EXISTS status_pages (
+    id TEXT PRIMARY KEY,
+    project_id TEXT NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    slug TEXT NOT NULL UNIQUE,
+    title TEXT NOT NULL,
+    logo_text TEXT NOT NULL DEFAULT '',
+    components TEXT NOT NULL,
+    created_at INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_status_pages_project_id ON status_pages(project_id);
+
+-- Create API keys table
+CREATE TABLE IF NOT EXISTS api_keys (
+    id TEXT PRIMARY KEY,
+    project_id TEXT NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    prefix TEXT NOT NULL UNIQUE,
+    key_hash TEXT NOT NULL,
+    scopes TEXT NOT NULL,
+    expires_at INTEGER,
+    last_used_at INTEGER,
+    created_at INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_api_keys_project_id ON api_keys(project_id);
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_checks_checked_at;
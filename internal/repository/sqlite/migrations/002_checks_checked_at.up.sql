@@ -0,0 +1,2 @@
+-- Create index serving deletion of expired checks
+CREATE INDEX IF NOT EXISTS idx_checks_checked_at ON checks(checked_at);
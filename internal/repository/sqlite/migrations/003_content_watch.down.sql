@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS content_changes;
+DROP TABLE IF EXISTS content_snapshots;
+ALTER TABLE urls DROP COLUMN content_watch;
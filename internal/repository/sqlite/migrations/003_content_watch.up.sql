@@ -0,0 +1,22 @@
+-- Add content watch settings, NULL when changes of the response body are not detected
+ALTER TABLE urls ADD COLUMN content_watch TEXT;
+
+-- Create content snapshots table holding the latest normalized body of every watched URL
+CREATE TABLE IF NOT EXISTS content_snapshots (
+    url_id TEXT PRIMARY KEY REFERENCES urls(id) ON DELETE CASCADE,
+    hash TEXT NOT NULL,
+    text TEXT NOT NULL,
+    captured_at INTEGER NOT NULL
+);
+
+-- Create content changes table
+CREATE TABLE IF NOT EXISTS content_changes (
+    id TEXT PRIMARY KEY,
+    url_id TEXT NOT NULL REFERENCES urls(id) ON DELETE CASCADE,
+    previous_hash TEXT NOT NULL,
+    hash TEXT NOT NULL,
+    diff TEXT NOT NULL,
+    detected_at INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_content_changes_url_id ON content_changes(url_id, detected_at DESC);
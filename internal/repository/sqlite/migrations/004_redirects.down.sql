@@ -0,0 +1,4 @@
+ALTER TABLE urls DROP COLUMN expect_redirect_domain;
+ALTER TABLE urls DROP COLUMN expect_final_url;
+ALTER TABLE checks DROP COLUMN redirects;
+ALTER TABLE urls DROP COLUMN redirect_policy;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_url_status_uptime;
+ALTER TABLE url_status DROP COLUMN uptime;
@@ -0,0 +1,13 @@
+-- Add uptime over the 24 hours up to the last check, stored so the URL list sorts by a stable key
+ALTER TABLE url_status ADD COLUMN uptime REAL;
+
+-- Backfill uptime of URLs checked before the column existed
+UPDATE url_status SET uptime = (
+    SELECT AVG(c.status) FROM checks c
+    WHERE c.url_id = url_status.url_id
+        AND c.checked_at > url_status.last_checked_at - 86400000000000
+        AND c.checked_at <= url_status.last_checked_at
+);
+
+-- Create index serving sorting by uptime
+CREATE INDEX IF NOT EXISTS idx_url_status_uptime ON url_status(uptime);
@@ -0,0 +1,5 @@
+-- Add paused flag of URLs, paused URLs are not checked until resumed
+ALTER TABLE urls ADD COLUMN paused INTEGER NOT NULL DEFAULT 0;
+
+-- Create index serving the paused state filter
+CREATE INDEX IF NOT EXISTS idx_urls_paused ON urls(project_id) WHERE paused;
@@ -0,0 +1,34 @@
+package sqlite_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"url-sentinel/internal/repository/repotest"
+	"url-sentinel/internal/repository/sqlite"
+)
+
+func TestRepositories(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) repotest.Repositories {
+		db, err := sqlite.New(filepath.Join(t.TempDir(), "url-sentinel.db"))
+		if err != nil {
+			t.Fatalf("failed to open database: %v", err)
+		}
+		t.Cleanup(func() { db.Close() })
+
+		migrator, err := sqlite.NewMigrator(db.DB)
+		if err != nil {
+			t.Fatalf("failed to load migrations: %v", err)
+		}
+		if _, err := migrator.Up(context.Background()); err != nil {
+			t.Fatalf("failed to run migrations: %v", err)
+		}
+
+		return repotest.Repositories{
+			URLs:     sqlite.NewURLRepository(db.DB),
+			Checks:   sqlite.NewCheckRepository(db.DB),
+			Contents: sqlite.NewContentRepository(db.DB),
+		}
+	})
+}
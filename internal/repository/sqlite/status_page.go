@@ -0,0 +1,226 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"url-sentinel/internal/domain/entity"
+	"url-sentinel/internal/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+type statusPageRepository struct {
+	db *sql.DB
+}
+
+// NewStatusPageRepository creates a new SQLite status page repository
+func NewStatusPageRepository(db *sql.DB) repository.StatusPageRepository {
+	return &statusPageRepository{db: db}
+}
+
+// statusComponent is the JSON representation of entity.StatusComponent
+type statusComponent struct {
+	Name   string      `json:"name"`
+	URLIDs []uuid.UUID `json:"url_ids"`
+}
+
+func (r *statusPageRepository) Create(ctx context.Context, page *entity.StatusPage) error {
+	query := `
+		INSERT INTO status_pages (id, project_id, slug, title, logo_text, components, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+
+	components, err := encodeComponents(page.Components)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(
+		ctx,
+		query,
+		page.ID,
+		page.ProjectID,
+		page.Slug,
+		page.Title,
+		page.LogoText,
+		components,
+		toNanos(page.CreatedAt),
+	)
+
+	if err != nil {
+		if isUniqueViolation(err) {
+			return repository.ErrStatusPageSlugExists
+		}
+		if isForeignKeyViolation(err) {
+			return fmt.Errorf("failed to create status page: %w", repository.ErrProjectNotFound)
+		}
+		return fmt.Errorf("failed to create status page: %w", err)
+	}
+
+	return nil
+}
+
+func (r *statusPageRepository) GetByID(ctx context.Context, projectID, id uuid.UUID) (*entity.StatusPage, error) {
+	query := `
+		SELECT id, project_id, slug, title, logo_text, components, created_at
+		FROM status_pages
+		WHERE id = ? AND project_id = ?
+	`
+
+	page, err := scanStatusPage(r.db.QueryRowContext(ctx, query, id, projectID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrStatusPageNotFound
+		}
+		return nil, fmt.Errorf("failed to get status page by id: %w", err)
+	}
+
+	return page, nil
+}
+
+func (r *statusPageRepository) GetBySlug(ctx context.Context, slug string) (*entity.StatusPage, error) {
+	query := `
+		SELECT id, project_id, slug, title, logo_text, components, created_at
+		FROM status_pages
+		WHERE slug = ?
+	`
+
+	page, err := scanStatusPage(r.db.QueryRowContext(ctx, query, slug))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrStatusPageNotFound
+		}
+		return nil, fmt.Errorf("failed to get status page by slug: %w", err)
+	}
+
+	return page, nil
+}
+
+func (r *statusPageRepository) List(ctx context.Context, projectID uuid.UUID) ([]*entity.StatusPage, error) {
+	query := `
+		SELECT id, project_id, slug, title, logo_text, components, created_at
+		FROM status_pages
+		WHERE project_id = ?
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list status pages: %w", err)
+	}
+	defer rows.Close()
+
+	var pages []*entity.StatusPage
+	for rows.Next() {
+		page, err := scanStatusPage(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan status page: %w", err)
+		}
+		pages = append(pages, page)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return pages, nil
+}
+
+func (r *statusPageRepository) Update(ctx context.Context, page *entity.StatusPage) error {
+	query := `
+		UPDATE status_pages
+		SET slug = ?3, title = ?4, logo_text = ?5, components = ?6
+		WHERE id = ?1 AND project_id = ?2
+	`
+
+	components, err := encodeComponents(page.Components)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.ExecContext(ctx, query, page.ID, page.ProjectID, page.Slug, page.Title, page.LogoText, components)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return repository.ErrStatusPageSlugExists
+		}
+		return fmt.Errorf("failed to update status page: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrStatusPageNotFound
+	}
+
+	return nil
+}
+
+func (r *statusPageRepository) Delete(ctx context.Context, projectID, id uuid.UUID) error {
+	query := `DELETE FROM status_pages WHERE id = ? AND project_id = ?`
+
+	result, err := r.db.ExecContext(ctx, query, id, projectID)
+	if err != nil {
+		return fmt.Errorf("failed to delete status page: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrStatusPageNotFound
+	}
+
+	return nil
+}
+
+func encodeComponents(components []entity.StatusComponent) (string, error) {
+	encoded := make([]statusComponent, 0, len(components))
+	for _, c := range components {
+		encoded = append(encoded, statusComponent(c))
+	}
+
+	data, err := json.Marshal(encoded)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode status page components: %w", err)
+	}
+
+	return string(data), nil
+}
+
+func scanStatusPage(row rowScanner) (*entity.StatusPage, error) {
+	var page entity.StatusPage
+	var data string
+	var createdAt int64
+
+	if err := row.Scan(
+		&page.ID,
+		&page.ProjectID,
+		&page.Slug,
+		&page.Title,
+		&page.LogoText,
+		&data,
+		&createdAt,
+	); err != nil {
+		return nil, err
+	}
+
+	var components []statusComponent
+	if err := json.Unmarshal([]byte(data), &components); err != nil {
+		return nil, fmt.Errorf("failed to decode status page components: %w", err)
+	}
+	for _, c := range components {
+		page.Components = append(page.Components, entity.StatusComponent(c))
+	}
+	page.CreatedAt = fromNanos(createdAt)
+
+	return &page, nil
+}
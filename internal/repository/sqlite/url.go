@@ -0,0 +1,677 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"url-sentinel/internal/domain/entity"
+	"url-sentinel/internal/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+// urlColumns selects URL u together with its current status s
+const urlColumns = `u.id, u.project_id, u.address, u.check_interval,
+	u.quorum, u.public_token, u.labels, u.content_watch, u.redirect_policy,
+	u.expect_final_url, u.expect_redirect_domain, u.paused, u.created_at,
+	s.state, s.last_status, s.last_code, s.last_duration,
+	s.last_checked_at, s.state_since, s.consecutive_failures, s.uptime`
+
+// urlSort describes how URLs are ordered by a field and how its values
+// are carried in pagination cursors
+type urlSort struct {
+	expr     string // indexed column holding the sort key of URL u
+	nullable bool   // the key is NULL for URLs never checked, carried as an empty cursor value
+	parse    func(value string) (any, error)
+	format   func(value any) string
+}
+
+var urlSorts = map[repository.URLSort]urlSort{
+	repository.URLSortCreatedAt: {
+		expr:   `u.created_at`,
+		parse:  parseTimeCursor,
+		format: formatTimeCursor,
+	},
+	repository.URLSortAddress: {
+		expr:   `u.address`,
+		parse:  func(value string) (any, error) { return value, nil },
+		format: func(value any) string { return fmt.Sprint(value) },
+	},
+	repository.URLSortLastChecked: {
+		expr:     `s.last_checked_at`,
+		nullable: true,
+		parse:    parseTimeCursor,
+		format:   formatTimeCursor,
+	},
+	repository.URLSortUptime: {
+		expr:     `s.uptime`,
+		nullable: true,
+		parse: func(value string) (any, error) {
+			return strconv.ParseFloat(value, 64)
+		},
+		format: func(value any) string {
+			f, ok := value.(float64)
+			if !ok {
+				return ""
+			}
+			return strconv.FormatFloat(f, 'g', -1, 64)
+		},
+	},
+}
+
+// order returns the ORDER BY clause of the sort. URLs never checked come
+// first in ascending order and last in descending order.
+func (s urlSort) order(desc bool) string {
+	switch {
+	case desc && s.nullable:
+		return s.expr + " DESC NULLS LAST, u.id DESC"
+	case desc:
+		return s.expr + " DESC, u.id DESC"
+	case s.nullable:
+		return s.expr + " ASC NULLS FIRST, u.id ASC"
+	default:
+		return s.expr + " ASC, u.id ASC"
+	}
+}
+
+// after returns the condition selecting the URLs that follow the cursor in
+// the order of the sort, with NULL keys ordered before every other key
+func (s urlSort) after(cursor *repository.URLCursor, desc bool, arg func(any) string) (string, error) {
+	op := ">"
+	if desc {
+		op = "<"
+	}
+
+	if s.nullable && cursor.Value == "" {
+		condition := fmt.Sprintf("(%s IS NULL AND u.id %s %s)", s.expr, op, arg(cursor.ID))
+		if !desc {
+			condition = fmt.Sprintf("(%s OR %s IS NOT NULL)", condition, s.expr)
+		}
+		return condition, nil
+	}
+
+	value, err := s.parse(cursor.Value)
+	if err != nil {
+		return "", repository.ErrInvalidCursor
+	}
+	condition := fmt.Sprintf("(%s, u.id) %s (%s, %s)", s.expr, op, arg(value), arg(cursor.ID))
+	if desc && s.nullable {
+		condition = fmt.Sprintf("(%s OR %s IS NULL)", condition, s.expr)
+	}
+	return condition, nil
+}
+
+func parseTimeCursor(value string) (any, error) {
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return nil, err
+	}
+	return toNanos(t), nil
+}
+
+func formatTimeCursor(value any) string {
+	ns, ok := value.(int64)
+	if !ok {
+		return ""
+	}
+	return fromNanos(ns).Format(time.RFC3339Nano)
+}
+
+// likeEscaper escapes LIKE wildcards in user input
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// labelPath returns the JSON path of a label key, keys never contain quotes
+func labelPath(key string) string {
+	return `$."` + key + `"`
+}
+
+type urlRepository struct {
+	db *sql.DB
+}
+
+// NewURLRepository creates a new SQLite URL repository
+func NewURLRepository(db *sql.DB) repository.URLRepository {
+	return &urlRepository{db: db}
+}
+
+// execer is implemented by both sql.DB and sql.Tx
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+func insertURL(ctx context.Context, db execer, url *entity.URL) error {
+	query := `
+		INSERT INTO urls (id, project_id, address, check_interval, quorum, public_token, labels, content_watch, redirect_policy,
+			expect_final_url, expect_redirect_domain, paused, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	labels, err := encodeLabels(url.Labels)
+	if err != nil {
+		return err
+	}
+	watch, err := encodeContentWatch(url.ContentWatch)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.ExecContext(
+		ctx,
+		query,
+		url.ID,
+		url.ProjectID,
+		url.Address,
+		int64(url.CheckInterval),
+		url.Quorum,
+		url.PublicToken,
+		labels,
+		watch,
+		url.Redirects,
+		url.ExpectFinalURL,
+		url.ExpectRedirectDomain,
+		url.Paused,
+		toNanos(url.CreatedAt),
+	)
+
+	switch {
+	case err == nil:
+		return nil
+	case isPrimaryKeyViolation(err):
+		return repository.ErrURLAlreadyExists
+	case isUniqueViolation(err):
+		return repository.ErrURLAddressExists
+	case isForeignKeyViolation(err):
+		return fmt.Errorf("failed to create url: %w", repository.ErrProjectNotFound)
+	default:
+		return fmt.Errorf("failed to create url: %w", err)
+	}
+}
+
+func (r *urlRepository) Create(ctx context.Context, url *entity.URL) error {
+	return insertURL(ctx, r.db, url)
+}
+
+func (r *urlRepository) GetByID(ctx context.Context, projectID, id uuid.UUID) (*entity.URL, error) {
+	query := `
+		SELECT ` + urlColumns + `
+		FROM urls u
+		LEFT JOIN url_status s ON s.url_id = u.id
+		WHERE u.id = ?1 AND (?2 IS NULL OR u.project_id = ?2)
+	`
+
+	url, err := scanURL(r.db.QueryRowContext(ctx, query, id, projectScope(projectID)))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrURLNotFound
+		}
+		return nil, fmt.Errorf("failed to get url by id: %w", err)
+	}
+
+	return url, nil
+}
+
+func (r *urlRepository) List(ctx context.Context, projectID uuid.UUID, filter repository.URLFilter) (*repository.URLPage, error) {
+	// Placeholders are positional, arguments are added in query order
+	var args []any
+	arg := func(value any) string {
+		args = append(args, value)
+		return "?"
+	}
+
+	sort, ok := urlSorts[filter.Sort]
+	if !ok {
+		sort = urlSorts[repository.URLSortCreatedAt]
+	}
+
+	query := `
+		SELECT ` + urlColumns + `, ` + sort.expr + ` AS sort_key
+		FROM urls u
+		LEFT JOIN url_status s ON s.url_id = u.id`
+
+	var conditions []string
+	if projectID != uuid.Nil {
+		conditions = append(conditions, "u.project_id = "+arg(projectID))
+	}
+	for key, value := range filter.Labels {
+		conditions = append(conditions, "json_extract(u.labels, "+arg(labelPath(key))+") = "+arg(value))
+	}
+	if filter.Search != "" {
+		conditions = append(conditions, "u.address LIKE "+arg("%"+likeEscaper.Replace(filter.Search)+"%")+` ESCAPE '\'`)
+	}
+	if filter.Interval > 0 {
+		conditions = append(conditions, "u.check_interval = "+arg(int64(filter.Interval)))
+	}
+	switch filter.State {
+	case "":
+	case entity.StatePaused:
+		conditions = append(conditions, "u.paused")
+	default:
+		conditions = append(conditions, "NOT u.paused AND COALESCE(s.state, 'unknown') = "+arg(string(filter.State)))
+	}
+	if filter.After != nil {
+		condition, err := sort.after(filter.After, filter.Desc, arg)
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, condition)
+	}
+	if len(conditions) > 0 {
+		query += `
+		WHERE ` + strings.Join(conditions, " AND ")
+	}
+	query += `
+		ORDER BY ` + sort.order(filter.Desc)
+	if filter.Limit > 0 {
+		// Fetch one extra row to find out whether there is a next page
+		query += `
+		LIMIT ` + arg(filter.Limit+1)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list urls: %w", err)
+	}
+	defer rows.Close()
+
+	page := &repository.URLPage{}
+	var sortKeys []any
+	for rows.Next() {
+		var sortKey any
+		url, err := scanURL(rows, &sortKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan url: %w", err)
+		}
+		page.URLs = append(page.URLs, url)
+		sortKeys = append(sortKeys, sortKey)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	if filter.Limit > 0 && len(page.URLs) > filter.Limit {
+		page.URLs = page.URLs[:filter.Limit]
+		last := page.URLs[filter.Limit-1]
+		page.Next = &repository.URLCursor{
+			Value: sort.format(sortKeys[filter.Limit-1]),
+			ID:    last.ID,
+		}
+	}
+
+	return page, nil
+}
+
+func (r *urlRepository) Update(ctx context.Context, url *entity.URL) error {
+	query := `
+		UPDATE urls
+		SET check_interval = ?3, quorum = ?4, labels = ?5, content_watch = ?6, redirect_policy = ?7,
+			expect_final_url = ?8, expect_redirect_domain = ?9, paused = ?10
+		WHERE id = ?1 AND project_id = ?2
+	`
+
+	labels, err := encodeLabels(url.Labels)
+	if err != nil {
+		return err
+	}
+	watch, err := encodeContentWatch(url.ContentWatch)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin update: %w", err)
+	}
+	defer tx.Rollback()
+
+	var previous sql.NullString
+	err = tx.QueryRowContext(ctx, `SELECT content_watch FROM urls WHERE id = ? AND project_id = ?`, url.ID, url.ProjectID).
+		Scan(&previous)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return repository.ErrURLNotFound
+		}
+		return fmt.Errorf("failed to get url: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, query, url.ID, url.ProjectID, int64(url.CheckInterval), url.Quorum, labels, watch, url.Redirects, url.ExpectFinalURL, url.ExpectRedirectDomain, url.Paused); err != nil {
+		return fmt.Errorf("failed to update url: %w", err)
+	}
+	if err := resetContentBaseline(ctx, tx, url.ID, previous, url.ContentWatch); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit update: %w", err)
+	}
+
+	return nil
+}
+
+func (r *urlRepository) Delete(ctx context.Context, projectID, id uuid.UUID) error {
+	query := `DELETE FROM urls WHERE id = ?1 AND (?2 IS NULL OR project_id = ?2)`
+
+	result, err := r.db.ExecContext(ctx, query, id, projectScope(projectID))
+	if err != nil {
+		return fmt.Errorf("failed to delete url: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrURLNotFound
+	}
+
+	return nil
+}
+
+func (r *urlRepository) ExistsByAddress(ctx context.Context, projectID uuid.UUID, address string) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM urls WHERE project_id = ? AND address = ?)`
+
+	var exists bool
+	err := r.db.QueryRowContext(ctx, query, projectID, address).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check url existence: %w", err)
+	}
+
+	return exists, nil
+}
+
+func (r *urlRepository) Import(ctx context.Context, projectID uuid.UUID, create, update []*entity.URL) error {
+	updateQuery := `
+		UPDATE urls
+		SET check_interval = ?3, quorum = ?4, labels = ?5, content_watch = ?6, redirect_policy = ?7,
+			expect_final_url = ?8, expect_redirect_domain = ?9
+		WHERE project_id = ?1 AND address = ?2
+		RETURNING id, public_token, created_at
+	`
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin import: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, url := range create {
+		url.ProjectID = projectID
+		if err := insertURL(ctx, tx, url); err != nil {
+			if errors.Is(err, repository.ErrURLAddressExists) {
+				return fmt.Errorf("%w: %s", repository.ErrURLAddressExists, url.Address)
+			}
+			return fmt.Errorf("failed to import url %s: %w", url.Address, err)
+		}
+	}
+
+	for _, url := range update {
+		labels, err := encodeLabels(url.Labels)
+		if err != nil {
+			return err
+		}
+		watch, err := encodeContentWatch(url.ContentWatch)
+		if err != nil {
+			return err
+		}
+
+		var previous sql.NullString
+		err = tx.QueryRowContext(ctx, `SELECT content_watch FROM urls WHERE project_id = ? AND address = ?`, projectID, url.Address).
+			Scan(&previous)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("%w: %s", repository.ErrURLNotFound, url.Address)
+			}
+			return fmt.Errorf("failed to import url %s: %w", url.Address, err)
+		}
+
+		var createdAt int64
+		err = tx.QueryRowContext(ctx, updateQuery, projectID, url.Address, int64(url.CheckInterval), url.Quorum, labels, watch, url.Redirects, url.ExpectFinalURL, url.ExpectRedirectDomain).
+			Scan(&url.ID, &url.PublicToken, &createdAt)
+		if err != nil {
+			return fmt.Errorf("failed to import url %s: %w", url.Address, err)
+		}
+		url.CreatedAt = fromNanos(createdAt)
+
+		if err := resetContentBaseline(ctx, tx, url.ID, previous, url.ContentWatch); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit import: %w", err)
+	}
+
+	return nil
+}
+
+func (r *urlRepository) Count(ctx context.Context, projectID uuid.UUID) (int, error) {
+	query := `SELECT COUNT(*) FROM urls WHERE project_id = ?`
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, projectID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count urls: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *urlRepository) RecordStatus(ctx context.Context, check *entity.Check, state entity.State) (*entity.StateChange, error) {
+	query := `
+		INSERT INTO url_status (url_id, state, last_status, last_code, last_duration,
+			last_checked_at, state_since, consecutive_failures, uptime)
+		VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?6, CASE WHEN ?3 THEN 0 ELSE 1 END,
+			COALESCE(
+				(SELECT AVG(c.status) FROM checks c
+					WHERE c.url_id = ?1 AND c.checked_at > ?7 AND c.checked_at <= ?6),
+				CASE WHEN ?3 THEN 1.0 ELSE 0.0 END))
+		ON CONFLICT (url_id) DO UPDATE SET
+			state = excluded.state,
+			last_status = excluded.last_status,
+			last_code = excluded.last_code,
+			last_duration = excluded.last_duration,
+			last_checked_at = excluded.last_checked_at,
+			state_since = CASE WHEN url_status.state = excluded.state THEN url_status.state_since ELSE excluded.state_since END,
+			consecutive_failures = CASE WHEN excluded.last_status THEN 0 ELSE url_status.consecutive_failures + 1 END,
+			uptime = excluded.uptime
+		WHERE url_status.last_checked_at <= excluded.last_checked_at
+	`
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin recording url status: %w", err)
+	}
+	defer tx.Rollback()
+
+	previous := string(entity.StateUnknown)
+	err = tx.QueryRowContext(ctx, `SELECT state FROM url_status WHERE url_id = ?`, check.URLID).Scan(&previous)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to get url status: %w", err)
+	}
+
+	result, err := tx.ExecContext(
+		ctx,
+		query,
+		check.URLID,
+		string(state),
+		check.Status,
+		check.Code,
+		int64(check.Duration),
+		toNanos(check.CheckedAt),
+		toNanos(check.CheckedAt.Add(-24*time.Hour)),
+	)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return nil, repository.ErrURLNotFound
+		}
+		return nil, fmt.Errorf("failed to record url status: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit url status: %w", err)
+	}
+
+	if rowsAffected == 0 || entity.State(previous) == state {
+		return nil, nil // older than the recorded result or unchanged
+	}
+
+	return &entity.StateChange{
+		URLID: check.URLID,
+		From:  entity.State(previous),
+		To:    state,
+		At:    check.CheckedAt,
+	}, nil
+}
+
+// scanURL scans a row of urlColumns followed by optional extra columns
+func scanURL(row rowScanner, extra ...any) (*entity.URL, error) {
+	var url entity.URL
+	var intervalNs, createdAt int64
+	var labels string
+	var state, watch sql.NullString
+	var lastStatus sql.NullBool
+	var lastCode, lastDurationNs, lastCheckedAt, stateSince, consecutiveFailures sql.NullInt64
+	var uptime sql.NullFloat64
+
+	dest := append([]any{
+		&url.ID,
+		&url.ProjectID,
+		&url.Address,
+		&intervalNs,
+		&url.Quorum,
+		&url.PublicToken,
+		&labels,
+		&watch,
+		&url.Redirects,
+		&url.ExpectFinalURL,
+		&url.ExpectRedirectDomain,
+		&url.Paused,
+		&createdAt,
+		&state,
+		&lastStatus,
+		&lastCode,
+		&lastDurationNs,
+		&lastCheckedAt,
+		&stateSince,
+		&consecutiveFailures,
+		&uptime,
+	}, extra...)
+
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	url.CheckInterval = time.Duration(intervalNs)
+	url.CreatedAt = fromNanos(createdAt)
+	if err := json.Unmarshal([]byte(labels), &url.Labels); err != nil {
+		return nil, fmt.Errorf("failed to decode url labels: %w", err)
+	}
+	settings, err := decodeContentWatch(watch)
+	if err != nil {
+		return nil, err
+	}
+	url.ContentWatch = settings
+
+	if state.Valid {
+		url.Status = &entity.URLStatus{
+			State:               entity.State(state.String),
+			LastStatus:          lastStatus.Bool,
+			LastCode:            int(lastCode.Int64),
+			LastDuration:        time.Duration(lastDurationNs.Int64),
+			LastCheckedAt:       fromNanos(lastCheckedAt.Int64),
+			StateSince:          fromNanos(stateSince.Int64),
+			ConsecutiveFailures: int(consecutiveFailures.Int64),
+			Uptime:              uptime.Float64,
+		}
+	}
+
+	return &url, nil
+}
+
+// encodeLabels converts labels or a label selector into a JSON object
+func encodeLabels[M ~map[string]string](labels M) (string, error) {
+	if labels == nil {
+		return "{}", nil
+	}
+
+	data, err := json.Marshal(labels)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode labels: %w", err)
+	}
+
+	return string(data), nil
+}
+
+// contentWatch is the JSON representation of entity.ContentWatch
+type contentWatch struct {
+	IgnoreSelectors []string `json:"ignore_selectors,omitempty"`
+	IgnorePatterns  []string `json:"ignore_patterns,omitempty"`
+	Alert           bool     `json:"alert,omitempty"`
+}
+
+// encodeContentWatch converts content watch settings into a JSON object, NULL when disabled
+func encodeContentWatch(watch *entity.ContentWatch) (sql.NullString, error) {
+	if watch == nil {
+		return sql.NullString{}, nil
+	}
+
+	data, err := json.Marshal(contentWatch(*watch))
+	if err != nil {
+		return sql.NullString{}, fmt.Errorf("failed to encode content watch: %w", err)
+	}
+
+	return sql.NullString{String: string(data), Valid: true}, nil
+}
+
+// decodeContentWatch converts a JSON object into content watch settings, nil for NULL
+func decodeContentWatch(data sql.NullString) (*entity.ContentWatch, error) {
+	if !data.Valid {
+		return nil, nil
+	}
+
+	var watch contentWatch
+	if err := json.Unmarshal([]byte(data.String), &watch); err != nil {
+		return nil, fmt.Errorf("failed to decode content watch: %w", err)
+	}
+
+	return (*entity.ContentWatch)(&watch), nil
+}
+
+// resetContentBaseline deletes the content snapshot of a URL whose content watch
+// rules changed from the previous ones, stored as JSON. The next check then stores
+// a new baseline instead of reporting what the new rules remove or keep as a change.
+func resetContentBaseline(ctx context.Context, tx *sql.Tx, urlID uuid.UUID, previous sql.NullString, current *entity.ContentWatch) error {
+	watch, err := decodeContentWatch(previous)
+	if err != nil {
+		return err
+	}
+	if watch.SameRules(current) {
+		return nil
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM content_snapshots WHERE url_id = ?`, urlID); err != nil {
+		return fmt.Errorf("failed to reset content snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// projectScope converts a project ID into a query parameter where uuid.Nil
+// becomes NULL, disabling the project filter
+func projectScope(projectID uuid.UUID) any {
+	if projectID == uuid.Nil {
+		return nil
+	}
+	return projectID
+}
@@ -0,0 +1,101 @@
+// Package storage opens the repositories of the configured storage driver.
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+
+	"url-sentinel/internal/config"
+	"url-sentinel/internal/domain/repository"
+	"url-sentinel/internal/repository/memory"
+	"url-sentinel/internal/repository/migrate"
+	"url-sentinel/internal/repository/postgres"
+	"url-sentinel/internal/repository/sqlite"
+)
+
+// Storage holds the repositories of one storage driver
+type Storage struct {
+	URLs        repository.URLRepository
+	Checks      repository.CheckRepository
+	Contents    repository.ContentRepository
+	Incidents   repository.IncidentRepository
+	StatusPages repository.StatusPageRepository
+	APIKeys     repository.APIKeyRepository
+	Projects    repository.ProjectRepository
+
+	// DB is the underlying database, nil for the memory driver
+	DB *sql.DB
+	// Migrator manages the schema of DB, nil for the memory driver
+	Migrator *migrate.Migrator
+}
+
+// Open connects to the configured storage. Migrations are not applied.
+func Open(cfg config.Config) (*Storage, error) {
+	switch cfg.Storage.Driver {
+	case config.StoragePostgres, "":
+		db, err := postgres.New(cfg.Database.DSN())
+		if err != nil {
+			return nil, err
+		}
+		migrator, err := postgres.NewMigrator(db.DB)
+		if err != nil {
+			db.Close()
+			return nil, err
+		}
+		return &Storage{
+			URLs:        postgres.NewURLRepository(db.DB),
+			Checks:      postgres.NewCheckRepository(db.DB),
+			Contents:    postgres.NewContentRepository(db.DB),
+			Incidents:   postgres.NewIncidentRepository(db.DB),
+			StatusPages: postgres.NewStatusPageRepository(db.DB),
+			APIKeys:     postgres.NewAPIKeyRepository(db.DB),
+			Projects:    postgres.NewProjectRepository(db.DB),
+			DB:          db.DB,
+			Migrator:    migrator,
+		}, nil
+
+	case config.StorageSQLite:
+		db, err := sqlite.New(cfg.Storage.Path)
+		if err != nil {
+			return nil, err
+		}
+		migrator, err := sqlite.NewMigrator(db.DB)
+		if err != nil {
+			db.Close()
+			return nil, err
+		}
+		return &Storage{
+			URLs:        sqlite.NewURLRepository(db.DB),
+			Checks:      sqlite.NewCheckRepository(db.DB),
+			Contents:    sqlite.NewContentRepository(db.DB),
+			Incidents:   sqlite.NewIncidentRepository(db.DB),
+			StatusPages: sqlite.NewStatusPageRepository(db.DB),
+			APIKeys:     sqlite.NewAPIKeyRepository(db.DB),
+			Projects:    sqlite.NewProjectRepository(db.DB),
+			DB:          db.DB,
+			Migrator:    migrator,
+		}, nil
+
+	case config.StorageMemory:
+		store := memory.New()
+		return &Storage{
+			URLs:        memory.NewURLRepository(store),
+			Checks:      memory.NewCheckRepository(store),
+			Contents:    memory.NewContentRepository(store),
+			Incidents:   memory.NewIncidentRepository(store),
+			StatusPages: memory.NewStatusPageRepository(store),
+			APIKeys:     memory.NewAPIKeyRepository(store),
+			Projects:    memory.NewProjectRepository(store),
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unknown storage driver %q", cfg.Storage.Driver)
+}
+
+// Close releases the database connection, if any
+func (s *Storage) Close() error {
+	if s.DB == nil {
+		return nil
+	}
+	return s.DB.Close()
+}
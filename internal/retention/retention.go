@@ -0,0 +1,69 @@
+// Package retention deletes expired check results and prepares storage for new ones
+package retention
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"url-sentinel/internal/domain/repository"
+)
+
+// Janitor periodically expires checks and, when checks are stored in time
+// partitions, creates the partitions of the coming weeks
+type Janitor struct {
+	checks      repository.CheckRepository
+	partitioner repository.CheckPartitioner // nil when checks are not partitioned
+	keep        time.Duration               // zero keeps checks forever
+	logger      *slog.Logger
+}
+
+// NewJanitor creates a new janitor deleting checks older than keep
+func NewJanitor(checks repository.CheckRepository, keep time.Duration, logger *slog.Logger) *Janitor {
+	partitioner, _ := checks.(repository.CheckPartitioner)
+
+	return &Janitor{
+		checks:      checks,
+		partitioner: partitioner,
+		keep:        keep,
+		logger:      logger,
+	}
+}
+
+// Sweep creates upcoming partitions and deletes expired checks once
+func (j *Janitor) Sweep(ctx context.Context) error {
+	now := time.Now().UTC()
+
+	if j.partitioner != nil {
+		if err := j.partitioner.EnsurePartitions(ctx, now); err != nil {
+			return err
+		}
+	}
+
+	if j.keep > 0 {
+		before := now.Add(-j.keep)
+		if err := j.checks.DeleteBefore(ctx, before); err != nil {
+			return err
+		}
+		j.logger.Debug("expired checks deleted", slog.Time("before", before))
+	}
+
+	return nil
+}
+
+// Run sweeps every interval until ctx is cancelled
+func (j *Janitor) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := j.Sweep(ctx); err != nil {
+				j.logger.Error("failed to sweep checks", slog.Any("error", err))
+			}
+		}
+	}
+}
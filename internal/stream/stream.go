@@ -0,0 +1,203 @@
+// Package stream fans out check results, state changes and content changes to live subscribers
+package stream
+
+import (
+	"context"
+	"maps"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"url-sentinel/internal/domain/entity"
+	"url-sentinel/internal/events"
+
+	"github.com/google/uuid"
+)
+
+// subscriberBuffer is how many events a subscriber may lag behind before events are dropped
+const subscriberBuffer = 64
+
+// EventType tells what an event reports
+type EventType string
+
+const (
+	EventCheck         EventType = "check"
+	EventStateChange   EventType = "state_change"
+	EventContentChange EventType = "content_change"
+)
+
+// Event is a check result, state change or content change of a URL
+type Event struct {
+	Type      EventType
+	ProjectID uuid.UUID
+	URLID     uuid.UUID
+	Address   string
+	Labels    entity.Labels
+	Check     *entity.Check         // set for EventCheck
+	Change    *entity.StateChange   // set for EventStateChange
+	Content   *entity.ContentChange // set for EventContentChange
+	At        time.Time
+}
+
+// Filter selects the events a subscriber receives
+type Filter struct {
+	ProjectID uuid.UUID   // required, subscribers only see their own project
+	URLIDs    []uuid.UUID // empty matches every URL
+	Labels    entity.LabelSelector
+}
+
+// Matches reports whether the event passes the filter
+func (f Filter) Matches(e *Event) bool {
+	if e.ProjectID != f.ProjectID {
+		return false
+	}
+	if len(f.URLIDs) > 0 {
+		found := false
+		for _, id := range f.URLIDs {
+			if id == e.URLID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return f.Labels.Matches(e.Labels)
+}
+
+// Hub delivers published events to subscribers without ever blocking the publisher
+type Hub struct {
+	mu          sync.RWMutex
+	subscribers map[*Subscription]struct{}
+	closed      bool
+}
+
+// NewHub creates a new hub
+func NewHub() *Hub {
+	return &Hub{subscribers: make(map[*Subscription]struct{})}
+}
+
+// Subscription receives the events matching its filter
+type Subscription struct {
+	hub     *Hub
+	filter  Filter
+	events  chan *Event
+	dropped atomic.Int64
+	once    sync.Once
+}
+
+// Subscribe registers a new subscriber, it must be closed when no longer used
+func (h *Hub) Subscribe(filter Filter) *Subscription {
+	sub := &Subscription{
+		hub:    h,
+		filter: filter,
+		events: make(chan *Event, subscriberBuffer),
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		sub.once.Do(func() { close(sub.events) })
+		return sub
+	}
+	h.subscribers[sub] = struct{}{}
+
+	return sub
+}
+
+// Close ends every subscription, letting streams finish before shutdown
+func (h *Hub) Close() {
+	h.mu.Lock()
+	h.closed = true
+	subs := make([]*Subscription, 0, len(h.subscribers))
+	for sub := range h.subscribers {
+		subs = append(subs, sub)
+	}
+	h.mu.Unlock()
+
+	for _, sub := range subs {
+		sub.Close()
+	}
+}
+
+// Events returns the channel of matching events, closed when the subscription is closed
+func (s *Subscription) Events() <-chan *Event {
+	return s.events
+}
+
+// Dropped returns the number of events dropped because the subscriber lagged behind
+func (s *Subscription) Dropped() int64 {
+	return s.dropped.Load()
+}
+
+// Close unregisters the subscriber
+func (s *Subscription) Close() {
+	s.once.Do(func() {
+		s.hub.mu.Lock()
+		delete(s.hub.subscribers, s)
+		s.hub.mu.Unlock()
+		close(s.events)
+	})
+}
+
+// Publish delivers the event to every matching subscriber. Subscribers with
+// a full buffer miss the event instead of delaying the publisher.
+func (h *Hub) Publish(e *Event) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for sub := range h.subscribers {
+		if !sub.filter.Matches(e) {
+			continue
+		}
+		select {
+		case sub.events <- e:
+		default:
+			sub.dropped.Add(1)
+		}
+	}
+}
+
+// CheckCompleted publishes a check result of the URL
+func (h *Hub) CheckCompleted(_ context.Context, e events.CheckCompleted) error {
+	h.Publish(&Event{
+		Type:      EventCheck,
+		ProjectID: e.URL.ProjectID,
+		URLID:     e.URL.ID,
+		Address:   e.URL.Address,
+		Labels:    maps.Clone(e.URL.Labels),
+		Check:     e.Check,
+		At:        e.Check.CheckedAt,
+	})
+	return nil
+}
+
+// URLStateChanged publishes a state change of the URL
+func (h *Hub) URLStateChanged(_ context.Context, e events.URLStateChanged) error {
+	h.Publish(&Event{
+		Type:      EventStateChange,
+		ProjectID: e.URL.ProjectID,
+		URLID:     e.URL.ID,
+		Address:   e.URL.Address,
+		Labels:    maps.Clone(e.URL.Labels),
+		Change:    e.Change,
+		At:        e.Change.At,
+	})
+	return nil
+}
+
+// ContentChanged publishes a content change of the URL
+func (h *Hub) ContentChanged(_ context.Context, e events.ContentChanged) error {
+	h.Publish(&Event{
+		Type:      EventContentChange,
+		ProjectID: e.URL.ProjectID,
+		URLID:     e.URL.ID,
+		Address:   e.URL.Address,
+		Labels:    maps.Clone(e.URL.Labels),
+		Content:   e.Change,
+		At:        e.Change.DetectedAt,
+	})
+	return nil
+}
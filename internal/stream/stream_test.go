@@ -0,0 +1,44 @@
+package stream
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestPublishDropsForLaggingSubscribers(t *testing.T) {
+	hub := NewHub()
+	defer hub.Close()
+
+	projectID, urlID := uuid.New(), uuid.New()
+	lagging := hub.Subscribe(Filter{ProjectID: projectID})
+	other := hub.Subscribe(Filter{ProjectID: projectID, URLIDs: []uuid.UUID{uuid.New()}})
+
+	// Nobody reads, so everything past the buffer is dropped without blocking
+	const extra = 5
+	for range subscriberBuffer + extra {
+		hub.Publish(&Event{Type: EventCheck, ProjectID: projectID, URLID: urlID})
+	}
+
+	if got := len(lagging.Events()); got != subscriberBuffer {
+		t.Errorf("buffered %d events, want %d", got, subscriberBuffer)
+	}
+	if got := lagging.Dropped(); got != extra {
+		t.Errorf("dropped %d events, want %d", got, extra)
+	}
+
+	// Events filtered out are neither delivered nor counted as dropped
+	if got := len(other.Events()); got != 0 || other.Dropped() != 0 {
+		t.Errorf("other subscriber got %d events and dropped %d, want none", got, other.Dropped())
+	}
+
+	// Closing ends the stream after the buffered events
+	lagging.Close()
+	n := 0
+	for range lagging.Events() {
+		n++
+	}
+	if n != subscriberBuffer {
+		t.Errorf("read %d events after Close, want %d", n, subscriberBuffer)
+	}
+}
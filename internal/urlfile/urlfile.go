@@ -0,0 +1,311 @@
+// Package urlfile encodes and decodes URL definitions as JSON, YAML and CSV files
+package urlfile
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"url-sentinel/internal/domain/entity"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Format is a file format of URL definitions
+type Format string
+
+const (
+	FormatJSON Format = "json"
+	FormatYAML Format = "yaml"
+	FormatCSV  Format = "csv"
+)
+
+var (
+	ErrUnknownFormat = errors.New("unknown format, expected json, yaml or csv")
+	ErrInvalidFile   = errors.New("invalid file")
+)
+
+// csvHeader lists the CSV columns, labels and content_watch are JSON objects
+var csvHeader = []string{
+	"address", "check_interval", "quorum", "labels", "content_watch",
+	"follow_redirects", "expect_final_url", "expect_redirect_domain",
+}
+
+// ParseFormat parses a format name, "yml" is accepted for YAML
+func ParseFormat(name string) (Format, error) {
+	switch strings.ToLower(name) {
+	case "json":
+		return FormatJSON, nil
+	case "yaml", "yml":
+		return FormatYAML, nil
+	case "csv":
+		return FormatCSV, nil
+	default:
+		return "", ErrUnknownFormat
+	}
+}
+
+// ContentType returns the MIME type of the format
+func (f Format) ContentType() string {
+	switch f {
+	case FormatYAML:
+		return "application/yaml"
+	case FormatCSV:
+		return "text/csv"
+	default:
+		return "application/json"
+	}
+}
+
+// Record is a URL definition as written in files
+type Record struct {
+	Address              string            `json:"address" yaml:"address"`
+	CheckInterval        string            `json:"check_interval" yaml:"check_interval"` // e.g. "30s", "1m", "5m"
+	Quorum               int               `json:"quorum,omitempty" yaml:"quorum,omitempty"`
+	Labels               map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+	ContentWatch         *ContentWatch     `json:"content_watch,omitempty" yaml:"content_watch,omitempty"`
+	FollowRedirects      string            `json:"follow_redirects,omitempty" yaml:"follow_redirects,omitempty"` // off or a limit, "on" when empty
+	ExpectFinalURL       string            `json:"expect_final_url,omitempty" yaml:"expect_final_url,omitempty"`
+	ExpectRedirectDomain string            `json:"expect_redirect_domain,omitempty" yaml:"expect_redirect_domain,omitempty"`
+}
+
+// ContentWatch is the content change detection of a URL as written in files
+type ContentWatch struct {
+	IgnoreSelectors []string `json:"ignore_selectors,omitempty" yaml:"ignore_selectors,omitempty"`
+	IgnorePatterns  []string `json:"ignore_patterns,omitempty" yaml:"ignore_patterns,omitempty"`
+	Alert           bool     `json:"alert,omitempty" yaml:"alert,omitempty"`
+}
+
+// Entry is a decoded URL definition with its position in the file. Err is set
+// when the record could not be converted, the rest of the file is still usable.
+type Entry struct {
+	Row        int // 1-based, not counting the CSV header
+	Definition entity.URLDefinition
+	Err        error
+}
+
+// Encode writes the definitions in the given format
+func Encode(w io.Writer, format Format, defs []entity.URLDefinition) error {
+	records := make([]Record, 0, len(defs))
+	for _, def := range defs {
+		rec := Record{
+			Address:              def.Address,
+			CheckInterval:        def.CheckInterval.String(),
+			Quorum:               def.Quorum,
+			Labels:               def.Labels,
+			ContentWatch:         (*ContentWatch)(def.ContentWatch),
+			ExpectFinalURL:       def.ExpectFinalURL,
+			ExpectRedirectDomain: def.ExpectRedirectDomain,
+		}
+		if def.Redirects != entity.FollowRedirects {
+			rec.FollowRedirects = def.Redirects.String()
+		}
+		records = append(records, rec)
+	}
+
+	switch format {
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(records)
+	case FormatYAML:
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(records); err != nil {
+			return err
+		}
+		return enc.Close()
+	case FormatCSV:
+		return encodeCSV(w, records)
+	default:
+		return ErrUnknownFormat
+	}
+}
+
+// Decode reads definitions in the given format. Syntax errors fail the whole
+// file, while invalid values are reported on the entry they belong to.
+func Decode(r io.Reader, format Format) ([]Entry, error) {
+	var records []Record
+
+	switch format {
+	case FormatJSON:
+		dec := json.NewDecoder(r)
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&records); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+		}
+	case FormatYAML:
+		dec := yaml.NewDecoder(r)
+		dec.KnownFields(true)
+		if err := dec.Decode(&records); err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+		}
+	case FormatCSV:
+		return decodeCSV(r)
+	default:
+		return nil, ErrUnknownFormat
+	}
+
+	entries := make([]Entry, 0, len(records))
+	for i, rec := range records {
+		entries = append(entries, newEntry(i+1, rec))
+	}
+
+	return entries, nil
+}
+
+// newEntry converts a record into a definition
+func newEntry(row int, rec Record) Entry {
+	entry := Entry{
+		Row: row,
+		Definition: entity.URLDefinition{
+			Address:              rec.Address,
+			Quorum:               rec.Quorum,
+			Labels:               rec.Labels,
+			ContentWatch:         (*entity.ContentWatch)(rec.ContentWatch),
+			ExpectFinalURL:       rec.ExpectFinalURL,
+			ExpectRedirectDomain: rec.ExpectRedirectDomain,
+		},
+	}
+
+	interval, err := time.ParseDuration(rec.CheckInterval)
+	if err != nil {
+		entry.Err = fmt.Errorf("invalid check_interval %q", rec.CheckInterval)
+		return entry
+	}
+	entry.Definition.CheckInterval = interval
+
+	redirects, err := entity.ParseRedirectPolicy(rec.FollowRedirects)
+	if err != nil {
+		entry.Err = fmt.Errorf("invalid follow_redirects %q", rec.FollowRedirects)
+		return entry
+	}
+	entry.Definition.Redirects = redirects
+
+	return entry
+}
+
+func encodeCSV(w io.Writer, records []Record) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+
+	for _, rec := range records {
+		labels := ""
+		if len(rec.Labels) > 0 {
+			data, err := json.Marshal(rec.Labels)
+			if err != nil {
+				return err
+			}
+			labels = string(data)
+		}
+		contentWatch := ""
+		if rec.ContentWatch != nil {
+			data, err := json.Marshal(rec.ContentWatch)
+			if err != nil {
+				return err
+			}
+			contentWatch = string(data)
+		}
+
+		fields := []string{
+			rec.Address,
+			rec.CheckInterval,
+			strconv.Itoa(rec.Quorum),
+			labels,
+			contentWatch,
+			rec.FollowRedirects,
+			rec.ExpectFinalURL,
+			rec.ExpectRedirectDomain,
+		}
+		if err := cw.Write(fields); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// decodeCSV reads a CSV file whose header names the columns in any order,
+// only the address and check_interval columns are required
+func decodeCSV(r io.Reader) ([]Entry, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range csvHeader[:2] {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("%w: missing %s column", ErrInvalidFile, required)
+		}
+	}
+
+	field := func(fields []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(fields) {
+			return ""
+		}
+		return strings.TrimSpace(fields[i])
+	}
+
+	var entries []Entry
+	for row := 1; ; row++ {
+		fields, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+		}
+
+		rec := Record{
+			Address:              field(fields, "address"),
+			CheckInterval:        field(fields, "check_interval"),
+			FollowRedirects:      field(fields, "follow_redirects"),
+			ExpectFinalURL:       field(fields, "expect_final_url"),
+			ExpectRedirectDomain: field(fields, "expect_redirect_domain"),
+		}
+
+		var rowErr error
+		if quorum := field(fields, "quorum"); quorum != "" {
+			if rec.Quorum, err = strconv.Atoi(quorum); err != nil {
+				rowErr = fmt.Errorf("invalid quorum %q", quorum)
+			}
+		}
+		if labels := field(fields, "labels"); labels != "" {
+			if err := json.Unmarshal([]byte(labels), &rec.Labels); err != nil {
+				rowErr = errors.New("labels must be a JSON object of strings")
+			}
+		}
+		if contentWatch := field(fields, "content_watch"); contentWatch != "" {
+			if err := json.Unmarshal([]byte(contentWatch), &rec.ContentWatch); err != nil {
+				rowErr = errors.New("content_watch must be a JSON object")
+			}
+		}
+
+		entry := newEntry(row, rec)
+		if rowErr != nil {
+			entry.Err = rowErr
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,70 @@
+package urlfile
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"url-sentinel/internal/domain/entity"
+)
+
+// definitions use every setting a file carries
+var definitions = []entity.URLDefinition{
+	{
+		Address:       "https://example.com/health",
+		CheckInterval: 30 * time.Second,
+		Quorum:        2,
+		Labels:        entity.Labels{"team": "payments", "note": `quoted "value", with comma`},
+		ContentWatch: &entity.ContentWatch{
+			IgnoreSelectors: []string{"#clock", "div.ad"},
+			IgnorePatterns:  []string{`\d{2}:\d{2}:\d{2}`},
+			Alert:           true,
+		},
+		Redirects:            3,
+		ExpectFinalURL:       "https://www.example.com/health",
+		ExpectRedirectDomain: "example.com",
+	},
+	{
+		Address:       "https://example.org",
+		CheckInterval: 5 * time.Minute,
+		Quorum:        entity.DefaultQuorum,
+		Redirects:     entity.NoRedirects,
+	},
+	{
+		Address:       "http://example.net/login?next=/",
+		CheckInterval: time.Hour + 30*time.Minute,
+		Quorum:        entity.DefaultQuorum,
+		Labels:        entity.Labels{"env": "prod"},
+		ContentWatch:  &entity.ContentWatch{},
+		Redirects:     entity.FollowRedirects,
+	},
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, format := range []Format{FormatJSON, FormatYAML, FormatCSV} {
+		t.Run(string(format), func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Encode(&buf, format, definitions); err != nil {
+				t.Fatalf("Encode: %v", err)
+			}
+
+			entries, err := Decode(&buf, format)
+			if err != nil {
+				t.Fatalf("Decode: %v", err)
+			}
+			if len(entries) != len(definitions) {
+				t.Fatalf("decoded %d entries, want %d", len(entries), len(definitions))
+			}
+
+			for i, entry := range entries {
+				if entry.Err != nil {
+					t.Errorf("row %d: %v", entry.Row, entry.Err)
+					continue
+				}
+				if !entry.Definition.Equal(definitions[i]) {
+					t.Errorf("row %d changed:\n got %+v\nwant %+v", entry.Row, entry.Definition, definitions[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"time"
+
+	"url-sentinel/internal/domain/entity"
+	"url-sentinel/internal/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+// lastUsedGranularity limits how often the last-used timestamp of a key is written
+const lastUsedGranularity = time.Minute
+
+var (
+	ErrUnauthorized = errors.New("invalid or expired api key")
+)
+
+// AuthUseCase handles business logic for API key management and authentication
+type AuthUseCase struct {
+	apiKeyRepo  repository.APIKeyRepository
+	projectRepo repository.ProjectRepository
+}
+
+// NewAuthUseCase creates a new auth use case
+func NewAuthUseCase(apiKeyRepo repository.APIKeyRepository, projectRepo repository.ProjectRepository) *AuthUseCase {
+	return &AuthUseCase{
+		apiKeyRepo:  apiKeyRepo,
+		projectRepo: projectRepo,
+	}
+}
+
+// CreateAPIKey creates a new API key of the project and returns it with its plaintext value
+func (uc *AuthUseCase) CreateAPIKey(
+	ctx context.Context,
+	projectID uuid.UUID,
+	name string,
+	scopes []entity.Scope,
+	expiresAt *time.Time,
+) (*entity.APIKey, string, error) {
+	if _, err := uc.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, "", fmt.Errorf("failed to get project: %w", err)
+	}
+
+	key, plaintext, err := entity.NewAPIKey(projectID, name, scopes, expiresAt)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create api key entity: %w", err)
+	}
+
+	if err := uc.apiKeyRepo.Create(ctx, key); err != nil {
+		return nil, "", fmt.Errorf("failed to save api key: %w", err)
+	}
+
+	return key, plaintext, nil
+}
+
+// ListAPIKeys retrieves all API keys
+func (uc *AuthUseCase) ListAPIKeys(ctx context.Context) ([]*entity.APIKey, error) {
+	keys, err := uc.apiKeyRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// DeleteAPIKey revokes an API key by its ID
+func (uc *AuthUseCase) DeleteAPIKey(ctx context.Context, id uuid.UUID) error {
+	if err := uc.apiKeyRepo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete api key: %w", err)
+	}
+
+	return nil
+}
+
+// Authenticate resolves a plaintext key to a valid, unexpired API key
+func (uc *AuthUseCase) Authenticate(ctx context.Context, plaintext string) (*entity.APIKey, error) {
+	prefix, ok := entity.ParseAPIKeyPrefix(plaintext)
+	if !ok {
+		return nil, ErrUnauthorized
+	}
+
+	key, err := uc.apiKeyRepo.GetByPrefix(ctx, prefix)
+	if err != nil {
+		if errors.Is(err, repository.ErrAPIKeyNotFound) {
+			return nil, ErrUnauthorized
+		}
+		return nil, fmt.Errorf("failed to get api key: %w", err)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(key.Hash), []byte(entity.HashAPIKey(plaintext))) != 1 {
+		return nil, ErrUnauthorized
+	}
+
+	now := time.Now().UTC()
+	if key.IsExpired(now) {
+		return nil, ErrUnauthorized
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= lastUsedGranularity {
+		if err := uc.apiKeyRepo.TouchLastUsed(ctx, key.ID, now); err != nil {
+			return nil, fmt.Errorf("failed to update api key usage: %w", err)
+		}
+		key.LastUsedAt = &now
+	}
+
+	return key, nil
+}
@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"time"
+
+	"url-sentinel/internal/domain/entity"
+	"url-sentinel/internal/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidPublicToken = errors.New("invalid public token")
+)
+
+// BadgeUseCase handles business logic for public badges
+type BadgeUseCase struct {
+	urlRepo      repository.URLRepository
+	checkRepo    repository.CheckRepository
+	incidentRepo repository.IncidentRepository
+}
+
+// NewBadgeUseCase creates a new badge use case
+func NewBadgeUseCase(
+	urlRepo repository.URLRepository,
+	checkRepo repository.CheckRepository,
+	incidentRepo repository.IncidentRepository,
+) *BadgeUseCase {
+	return &BadgeUseCase{
+		urlRepo:      urlRepo,
+		checkRepo:    checkRepo,
+		incidentRepo: incidentRepo,
+	}
+}
+
+// GetState returns the current state of a URL if the public token matches
+func (uc *BadgeUseCase) GetState(ctx context.Context, id uuid.UUID, token string) (entity.State, error) {
+	url, err := uc.authorize(ctx, id, token)
+	if err != nil {
+		return "", err
+	}
+
+	open, err := uc.incidentRepo.GetOpenByURLID(ctx, id)
+	if err != nil {
+		return "", fmt.Errorf("failed to get open incident: %w", err)
+	}
+
+	return resolveState(ctx, uc.checkRepo, url, open)
+}
+
+// GetStats returns check statistics of a URL over the period if the public token matches
+func (uc *BadgeUseCase) GetStats(ctx context.Context, id uuid.UUID, token string, period time.Duration) (*entity.CheckStats, error) {
+	if _, err := uc.authorize(ctx, id, token); err != nil {
+		return nil, err
+	}
+
+	stats, err := uc.checkRepo.Stats(ctx, uuid.Nil, id, time.Now().UTC().Add(-period))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get check stats: %w", err)
+	}
+
+	return stats, nil
+}
+
+// authorize verifies the public token in constant time; unknown URLs are
+// reported as an invalid token so that URL IDs cannot be probed. Badges are
+// public, so the lookup is not scoped by project.
+func (uc *BadgeUseCase) authorize(ctx context.Context, id uuid.UUID, token string) (*entity.URL, error) {
+	url, err := uc.urlRepo.GetByID(ctx, uuid.Nil, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrURLNotFound) {
+			return nil, ErrInvalidPublicToken
+		}
+		return nil, fmt.Errorf("failed to get url: %w", err)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(url.PublicToken), []byte(token)) != 1 {
+		return nil, ErrInvalidPublicToken
+	}
+
+	return url, nil
+}
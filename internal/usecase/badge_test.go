@@ -0,0 +1,43 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"url-sentinel/internal/domain/entity"
+	"url-sentinel/internal/repository/memory"
+)
+
+func TestBadgeStatePaused(t *testing.T) {
+	ctx := context.Background()
+	store := memory.New()
+	urls, checks := memory.NewURLRepository(store), memory.NewCheckRepository(store)
+	uc := NewBadgeUseCase(urls, checks, memory.NewIncidentRepository(store))
+
+	url, err := entity.NewURL(entity.DefaultProjectID, "https://example.com", time.Minute)
+	if err != nil {
+		t.Fatalf("NewURL: %v", err)
+	}
+	if err := urls.Create(ctx, url); err != nil {
+		t.Fatalf("Create url: %v", err)
+	}
+	if err := checks.Create(ctx, entity.NewCheck(url.ID, "central", true, 200, 100*time.Millisecond)); err != nil {
+		t.Fatalf("Create check: %v", err)
+	}
+
+	state, err := uc.GetState(ctx, url.ID, url.PublicToken)
+	if err != nil || state != entity.StateUp {
+		t.Fatalf("GetState = %q, %v, want up", state, err)
+	}
+
+	// The last result of a paused URL is not its state
+	url.Paused = true
+	if err := urls.Update(ctx, url); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	state, err = uc.GetState(ctx, url.ID, url.PublicToken)
+	if err != nil || state != entity.StatePaused {
+		t.Errorf("GetState = %q, %v, want paused", state, err)
+	}
+}
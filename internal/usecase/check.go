@@ -3,28 +3,46 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"url-sentinel/internal/domain/entity"
 	"url-sentinel/internal/domain/repository"
+	"url-sentinel/internal/events"
 
 	"github.com/google/uuid"
 )
 
+// StateEvaluator defines the interface for evaluating URL state after new check results
+type StateEvaluator interface {
+	Evaluate(ctx context.Context, urlID uuid.UUID) (entity.State, error)
+}
+
 // CheckUseCase handles business logic for check operations
 type CheckUseCase struct {
 	checkRepo repository.CheckRepository
+	urlRepo   repository.URLRepository
+	evaluator StateEvaluator
+	events    events.Publisher
 }
 
 // NewCheckUseCase creates a new check use case
-func NewCheckUseCase(checkRepo repository.CheckRepository) *CheckUseCase {
+func NewCheckUseCase(
+	checkRepo repository.CheckRepository,
+	urlRepo repository.URLRepository,
+	evaluator StateEvaluator,
+	publisher events.Publisher,
+) *CheckUseCase {
 	return &CheckUseCase{
 		checkRepo: checkRepo,
+		urlRepo:   urlRepo,
+		evaluator: evaluator,
+		events:    publisher,
 	}
 }
 
-// GetCheckHistory retrieves all checks for a URL
-func (uc *CheckUseCase) GetCheckHistory(ctx context.Context, urlID uuid.UUID) ([]*entity.Check, error) {
-	checks, err := uc.checkRepo.ListByURLID(ctx, urlID)
+// GetCheckHistory retrieves all checks for a URL of the project
+func (uc *CheckUseCase) GetCheckHistory(ctx context.Context, projectID, urlID uuid.UUID) ([]*entity.Check, error) {
+	checks, err := uc.checkRepo.ListByURLID(ctx, projectID, urlID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get check history: %w", err)
 	}
@@ -32,12 +50,119 @@ func (uc *CheckUseCase) GetCheckHistory(ctx context.Context, urlID uuid.UUID) ([
 	return checks, nil
 }
 
-// GetLatestCheck retrieves the most recent check for a URL
-func (uc *CheckUseCase) GetLatestCheck(ctx context.Context, urlID uuid.UUID) (*entity.Check, error) {
-	check, err := uc.checkRepo.GetLatestByURLID(ctx, urlID)
+// GetLatestCheck retrieves the most recent check for a URL of the project
+func (uc *CheckUseCase) GetLatestCheck(ctx context.Context, projectID, urlID uuid.UUID) (*entity.Check, error) {
+	check, err := uc.checkRepo.GetLatestByURLID(ctx, projectID, urlID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get latest check: %w", err)
 	}
 
 	return check, nil
 }
+
+// GetLocationStatus retrieves the most recent check of a URL of the project from every location
+func (uc *CheckUseCase) GetLocationStatus(ctx context.Context, projectID, urlID uuid.UUID) ([]*entity.Check, error) {
+	checks, err := uc.checkRepo.ListLatestByLocation(ctx, projectID, urlID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get location status: %w", err)
+	}
+
+	return checks, nil
+}
+
+// GetStatsByLabel aggregates check results over the period of the project's URLs
+// matching the selector, grouped by the value of the label key
+func (uc *CheckUseCase) GetStatsByLabel(
+	ctx context.Context,
+	projectID uuid.UUID,
+	key string,
+	selector entity.LabelSelector,
+	period time.Duration,
+) ([]*entity.LabelGroupStats, error) {
+	groups, err := uc.checkRepo.StatsByLabel(ctx, projectID, key, selector, time.Now().UTC().Add(-period))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stats by label: %w", err)
+	}
+
+	return groups, nil
+}
+
+// RecordCheck stores a check result from any location, re-evaluates the URL
+// state and updates the current status of the URL. It returns the state
+// change caused by the check, nil when the state did not change.
+func (uc *CheckUseCase) RecordCheck(ctx context.Context, check *entity.Check) (*entity.StateChange, error) {
+	if err := uc.checkRepo.Create(ctx, check); err != nil {
+		return nil, fmt.Errorf("failed to record check: %w", err)
+	}
+
+	// Evaluate state centrally if evaluator is available, otherwise
+	// the single check decides
+	state := entity.StateDown
+	if check.Status {
+		state = entity.StateUp
+	}
+	if uc.evaluator != nil {
+		var err error
+		state, err = uc.evaluator.Evaluate(ctx, check.URLID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to evaluate url state: %w", err)
+		}
+	}
+
+	change, err := uc.urlRepo.RecordStatus(ctx, check, state)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record url status: %w", err)
+	}
+
+	return change, nil
+}
+
+// ReportCheck stores a check result reported by a remote agent with the error
+// class of its failure, then publishes it to be observed like the checks of
+// the local monitor
+func (uc *CheckUseCase) ReportCheck(ctx context.Context, check *entity.Check, errorClass string) error {
+	url, err := uc.urlRepo.GetByID(ctx, uuid.Nil, check.URLID)
+	if err != nil {
+		return fmt.Errorf("failed to get url: %w", err)
+	}
+
+	return uc.StoreCheck(ctx, events.CheckCompleted{URL: url, Check: check, ErrorClass: errorClass})
+}
+
+// StoreCheck records a completed check synchronously, then publishes it as
+// recorded along with the state change of the URL it caused. Callers that
+// must report storage errors use it instead of the storage subscriber.
+func (uc *CheckUseCase) StoreCheck(ctx context.Context, e events.CheckCompleted) error {
+	change, err := uc.RecordCheck(ctx, e.Check)
+	if err != nil {
+		return err
+	}
+
+	e.Recorded = true
+	uc.events.Publish(ctx, e)
+	if change != nil {
+		uc.events.Publish(ctx, events.URLStateChanged{URL: e.URL, Change: change})
+	}
+
+	return nil
+}
+
+// HandleCheckCompleted is the persistence subscriber: it records the check and
+// publishes the state change of the URL it caused. Checks stored by StoreCheck
+// are skipped.
+func (uc *CheckUseCase) HandleCheckCompleted(ctx context.Context, e events.CheckCompleted) error {
+	if e.Recorded {
+		return nil
+	}
+
+	change, err := uc.RecordCheck(ctx, e.Check)
+	if err != nil {
+		return err
+	}
+
+	if change != nil {
+		uc.events.Publish(ctx, events.URLStateChanged{URL: e.URL, Change: change})
+	}
+
+	return nil
+}
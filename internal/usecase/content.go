@@ -0,0 +1,124 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"url-sentinel/internal/domain/entity"
+	"url-sentinel/internal/domain/repository"
+	"url-sentinel/internal/events"
+
+	"github.com/google/uuid"
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// maxContentDiff is how much of the diff between two snapshots a change keeps
+const maxContentDiff = 64 << 10
+
+// ContentUseCase detects content changes of watched URLs and lists them
+type ContentUseCase struct {
+	contentRepo repository.ContentRepository
+	urlRepo     repository.URLRepository
+	events      events.Publisher
+	logger      *slog.Logger
+}
+
+// NewContentUseCase creates a new content use case
+func NewContentUseCase(
+	contentRepo repository.ContentRepository,
+	urlRepo repository.URLRepository,
+	publisher events.Publisher,
+	logger *slog.Logger,
+) *ContentUseCase {
+	return &ContentUseCase{
+		contentRepo: contentRepo,
+		urlRepo:     urlRepo,
+		events:      publisher,
+		logger:      logger,
+	}
+}
+
+// TrackContent compares the snapshot with the previous one of the URL. When
+// the content differs it records the change with a diff of the text, stores
+// the snapshot and publishes ContentChanged. The first snapshot of a URL is
+// stored as the baseline without a change.
+func (uc *ContentUseCase) TrackContent(ctx context.Context, url *entity.URL, snapshot *entity.ContentSnapshot) (*entity.ContentChange, error) {
+	previous, err := uc.contentRepo.GetSnapshot(ctx, url.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get content snapshot: %w", err)
+	}
+	if previous != nil && previous.Hash == snapshot.Hash {
+		return nil, nil
+	}
+
+	var change *entity.ContentChange
+	if previous != nil {
+		diff, err := contentDiff(previous, snapshot)
+		if err != nil {
+			return nil, err
+		}
+		change = entity.NewContentChange(previous, snapshot, diff)
+	}
+
+	if err := uc.contentRepo.SaveSnapshot(ctx, snapshot, change); err != nil {
+		return nil, fmt.Errorf("failed to save content snapshot: %w", err)
+	}
+	if change == nil {
+		return nil, nil
+	}
+
+	level := slog.LevelInfo
+	if url.ContentWatch != nil && url.ContentWatch.Alert {
+		level = slog.LevelWarn
+	}
+	uc.logger.Log(ctx, level, "url content changed",
+		slog.String("url_id", url.ID.String()),
+		slog.String("address", url.Address),
+		slog.String("change_id", change.ID.String()),
+		slog.String("hash", change.Hash),
+		slog.Any("labels", url.Labels),
+	)
+	uc.events.Publish(ctx, events.ContentChanged{URL: url, Change: change})
+
+	return change, nil
+}
+
+// ListChanges retrieves all content changes of a URL of the project
+func (uc *ContentUseCase) ListChanges(ctx context.Context, projectID, urlID uuid.UUID) ([]*entity.ContentChange, error) {
+	if _, err := uc.urlRepo.GetByID(ctx, projectID, urlID); err != nil {
+		return nil, fmt.Errorf("failed to get url: %w", err)
+	}
+
+	changes, err := uc.contentRepo.ListChanges(ctx, urlID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list content changes: %w", err)
+	}
+
+	return changes, nil
+}
+
+// contentDiff renders the unified diff of the text of two snapshots, truncated
+// to whole lines of at most maxContentDiff bytes
+func contentDiff(previous, current *entity.ContentSnapshot) (string, error) {
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(previous.Text),
+		B:        difflib.SplitLines(current.Text),
+		FromFile: previous.Hash,
+		FromDate: previous.CapturedAt.Format(time.RFC3339),
+		ToFile:   current.Hash,
+		ToDate:   current.CapturedAt.Format(time.RFC3339),
+		Context:  3,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to diff content: %w", err)
+	}
+
+	if len(diff) > maxContentDiff {
+		diff = diff[:strings.LastIndexByte(diff[:maxContentDiff], '\n')+1] + "... diff truncated\n"
+	}
+
+	return diff, nil
+}
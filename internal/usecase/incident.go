@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"url-sentinel/internal/domain/entity"
+	"url-sentinel/internal/domain/repository"
+	"url-sentinel/internal/events"
+
+	"github.com/google/uuid"
+)
+
+// IncidentUseCase evaluates URL state across locations and tracks incidents
+type IncidentUseCase struct {
+	urlRepo      repository.URLRepository
+	checkRepo    repository.CheckRepository
+	incidentRepo repository.IncidentRepository
+	events       events.Publisher
+	logger       *slog.Logger
+}
+
+// NewIncidentUseCase creates a new incident use case
+func NewIncidentUseCase(
+	urlRepo repository.URLRepository,
+	checkRepo repository.CheckRepository,
+	incidentRepo repository.IncidentRepository,
+	publisher events.Publisher,
+	logger *slog.Logger,
+) *IncidentUseCase {
+	return &IncidentUseCase{
+		urlRepo:      urlRepo,
+		checkRepo:    checkRepo,
+		incidentRepo: incidentRepo,
+		events:       publisher,
+		logger:       logger,
+	}
+}
+
+// Evaluate applies the URL's quorum rule to the latest check of every location,
+// opening an incident when the URL goes down and resolving it when it recovers.
+// It returns the resulting state of the URL.
+func (uc *IncidentUseCase) Evaluate(ctx context.Context, urlID uuid.UUID) (entity.State, error) {
+	url, err := uc.urlRepo.GetByID(ctx, uuid.Nil, urlID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get url: %w", err)
+	}
+
+	checks, err := uc.checkRepo.ListLatestByLocation(ctx, uuid.Nil, urlID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get location status: %w", err)
+	}
+
+	down, locations := entity.EvaluateQuorum(checks, url.Quorum, url.QuorumWindow(), time.Now().UTC())
+
+	open, err := uc.incidentRepo.GetOpenByURLID(ctx, urlID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get open incident: %w", err)
+	}
+
+	state := entity.StateUp
+	if down {
+		state = entity.StateDown
+	}
+
+	switch {
+	case down && open == nil:
+		incident := entity.NewIncident(urlID, locations)
+		if err := uc.incidentRepo.Create(ctx, incident); err != nil {
+			if errors.Is(err, repository.ErrIncidentAlreadyOpen) {
+				return state, nil // opened concurrently by another location
+			}
+			return "", fmt.Errorf("failed to open incident: %w", err)
+		}
+		uc.logger.Warn("url is down, incident opened",
+			slog.String("url_id", urlID.String()),
+			slog.String("address", url.Address),
+			slog.String("incident_id", incident.ID.String()),
+			slog.Any("labels", url.Labels),
+			slog.Any("locations", locations),
+		)
+		uc.events.Publish(ctx, events.IncidentOpened{URL: url, Incident: incident})
+
+	case !down && open != nil:
+		if err := uc.incidentRepo.Resolve(ctx, open.ID, time.Now().UTC()); err != nil {
+			return "", fmt.Errorf("failed to resolve incident: %w", err)
+		}
+		uc.logger.Info("url recovered, incident resolved",
+			slog.String("url_id", urlID.String()),
+			slog.String("address", url.Address),
+			slog.String("incident_id", open.ID.String()),
+			slog.Any("labels", url.Labels),
+		)
+	}
+
+	return state, nil
+}
+
+// ListIncidents retrieves all incidents of a URL of the project
+func (uc *IncidentUseCase) ListIncidents(ctx context.Context, projectID, urlID uuid.UUID) ([]*entity.Incident, error) {
+	if _, err := uc.urlRepo.GetByID(ctx, projectID, urlID); err != nil {
+		return nil, fmt.Errorf("failed to get url: %w", err)
+	}
+
+	incidents, err := uc.incidentRepo.ListByURLID(ctx, urlID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list incidents: %w", err)
+	}
+
+	return incidents, nil
+}
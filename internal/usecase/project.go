@@ -0,0 +1,127 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"url-sentinel/internal/domain/entity"
+	"url-sentinel/internal/domain/repository"
+	"url-sentinel/internal/events"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrDefaultProjectProtected = errors.New("default project cannot be deleted")
+)
+
+// ProjectUseCase handles business logic for project operations
+type ProjectUseCase struct {
+	projectRepo repository.ProjectRepository
+	urlRepo     repository.URLRepository
+	monitor     Monitor
+	events      events.Publisher
+}
+
+// NewProjectUseCase creates a new project use case, monitor and publisher may be nil
+func NewProjectUseCase(
+	projectRepo repository.ProjectRepository,
+	urlRepo repository.URLRepository,
+	monitor Monitor,
+	publisher events.Publisher,
+) *ProjectUseCase {
+	return &ProjectUseCase{
+		projectRepo: projectRepo,
+		urlRepo:     urlRepo,
+		monitor:     monitor,
+		events:      publisher,
+	}
+}
+
+// CreateProject creates a new project with validation
+func (uc *ProjectUseCase) CreateProject(ctx context.Context, name string, maxURLs int, minInterval time.Duration) (*entity.Project, error) {
+	project, err := entity.NewProject(name, maxURLs, minInterval)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create project entity: %w", err)
+	}
+
+	if err := uc.projectRepo.Create(ctx, project); err != nil {
+		return nil, fmt.Errorf("failed to save project: %w", err)
+	}
+
+	return project, nil
+}
+
+// GetProject retrieves a project by its ID
+func (uc *ProjectUseCase) GetProject(ctx context.Context, id uuid.UUID) (*entity.Project, error) {
+	project, err := uc.projectRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+
+	return project, nil
+}
+
+// ListProjects retrieves all projects
+func (uc *ProjectUseCase) ListProjects(ctx context.Context) ([]*entity.Project, error) {
+	projects, err := uc.projectRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+
+	return projects, nil
+}
+
+// UpdateProject replaces the name and quotas of a project. Existing URLs
+// exceeding new quotas are kept; quotas only apply to new URLs.
+func (uc *ProjectUseCase) UpdateProject(ctx context.Context, id uuid.UUID, name string, maxURLs int, minInterval time.Duration) (*entity.Project, error) {
+	project, err := uc.projectRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+
+	project.Name = name
+	project.MaxURLs = maxURLs
+	project.MinInterval = minInterval
+	if err := project.Validate(); err != nil {
+		return nil, fmt.Errorf("failed to validate project: %w", err)
+	}
+
+	if err := uc.projectRepo.Update(ctx, project); err != nil {
+		return nil, fmt.Errorf("failed to update project: %w", err)
+	}
+
+	return project, nil
+}
+
+// DeleteProject deletes a project together with its URLs, API keys and
+// status pages and stops monitoring its URLs
+func (uc *ProjectUseCase) DeleteProject(ctx context.Context, id uuid.UUID) error {
+	if id == entity.DefaultProjectID {
+		return ErrDefaultProjectProtected
+	}
+
+	page, err := uc.urlRepo.List(ctx, id, repository.URLFilter{})
+	if err != nil {
+		return fmt.Errorf("failed to list project urls: %w", err)
+	}
+
+	if err := uc.projectRepo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete project: %w", err)
+	}
+
+	if uc.monitor != nil {
+		for _, url := range page.URLs {
+			uc.monitor.RemoveURL(url.ID.String())
+		}
+	}
+	if uc.events != nil {
+		for _, url := range page.URLs {
+			uc.events.Publish(ctx, events.URLDeleted{ProjectID: id, URLID: url.ID})
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,157 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	"url-sentinel/internal/domain/entity"
+	"url-sentinel/internal/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+// ReconcileAction is a change needed to bring a URL in line with its declaration
+type ReconcileAction string
+
+const (
+	ReconcileCreate   ReconcileAction = "create"
+	ReconcileUpdate   ReconcileAction = "update"
+	ReconcileDelete   ReconcileAction = "delete"
+	ReconcileConflict ReconcileAction = "conflict" // declared address exists but is not managed by config, left untouched
+)
+
+// ReconcileChange is a single planned change
+type ReconcileChange struct {
+	Action  ReconcileAction
+	Address string
+	Current *entity.URL // nil for creations
+	Desired *entity.URL // nil for deletions
+}
+
+// ReconcilePlan lists the changes that bring a project in line with the declared URLs
+type ReconcilePlan struct {
+	ProjectID uuid.UUID
+	Changes   []*ReconcileChange
+}
+
+// Count returns the number of changes with the given action
+func (p *ReconcilePlan) Count(action ReconcileAction) int {
+	n := 0
+	for _, c := range p.Changes {
+		if c.Action == action {
+			n++
+		}
+	}
+	return n
+}
+
+// PlanReconcile compares the declared URLs with the project's URLs. Declared URLs
+// are marked with LabelManagedBy=ManagedByConfig and only URLs carrying that marker
+// are updated, or deleted when prune is set and they are no longer declared.
+func (uc *URLUseCase) PlanReconcile(
+	ctx context.Context,
+	projectID uuid.UUID,
+	defs []entity.URLDefinition,
+	prune bool,
+) (*ReconcilePlan, error) {
+	project, err := uc.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+
+	page, err := uc.urlRepo.List(ctx, projectID, repository.URLFilter{Sort: repository.URLSortAddress})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list urls: %w", err)
+	}
+	current := make(map[string]*entity.URL, len(page.URLs))
+	for _, url := range page.URLs {
+		current[url.Address] = url
+	}
+
+	plan := &ReconcilePlan{ProjectID: projectID}
+	declared := make(map[string]struct{}, len(defs))
+	count := len(page.URLs)
+
+	for _, def := range defs {
+		if _, dup := declared[def.Address]; dup {
+			return nil, fmt.Errorf("%s: %w", def.Address, ErrDuplicateAddress)
+		}
+		declared[def.Address] = struct{}{}
+
+		labels := maps.Clone(def.Labels)
+		if labels == nil {
+			labels = entity.Labels{}
+		}
+		labels[entity.LabelManagedBy] = entity.ManagedByConfig
+		def.Labels = labels
+
+		desired, err := entity.NewURLFromDefinition(projectID, def)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", def.Address, err)
+		}
+
+		existing, ok := current[def.Address]
+		switch {
+		case !ok:
+			if err := project.CheckQuota(count, desired.CheckInterval); err != nil {
+				return nil, fmt.Errorf("%s: %w", def.Address, err)
+			}
+			count++
+			plan.Changes = append(plan.Changes, &ReconcileChange{Action: ReconcileCreate, Address: def.Address, Desired: desired})
+		case existing.Labels[entity.LabelManagedBy] != entity.ManagedByConfig:
+			plan.Changes = append(plan.Changes, &ReconcileChange{Action: ReconcileConflict, Address: def.Address, Current: existing})
+		case !existing.Definition().Equal(desired.Definition()):
+			if err := project.CheckInterval(desired.CheckInterval); err != nil {
+				return nil, fmt.Errorf("%s: %w", def.Address, err)
+			}
+			plan.Changes = append(plan.Changes, &ReconcileChange{
+				Action:  ReconcileUpdate,
+				Address: def.Address,
+				Current: existing,
+				Desired: desired,
+			})
+		}
+	}
+
+	if prune {
+		for _, url := range page.URLs {
+			if _, ok := declared[url.Address]; ok || url.Labels[entity.LabelManagedBy] != entity.ManagedByConfig {
+				continue
+			}
+			plan.Changes = append(plan.Changes, &ReconcileChange{Action: ReconcileDelete, Address: url.Address, Current: url})
+		}
+	}
+
+	return plan, nil
+}
+
+// ApplyReconcile creates and updates the planned URLs in a single transaction, then deletes pruned ones
+func (uc *URLUseCase) ApplyReconcile(ctx context.Context, plan *ReconcilePlan) error {
+	var create, update []*entity.URL
+	for _, c := range plan.Changes {
+		switch c.Action {
+		case ReconcileCreate:
+			create = append(create, c.Desired)
+		case ReconcileUpdate:
+			update = append(update, c.Desired)
+		}
+	}
+
+	if len(create) > 0 || len(update) > 0 {
+		if err := uc.importURLs(ctx, plan.ProjectID, create, update); err != nil {
+			return err
+		}
+	}
+
+	for _, c := range plan.Changes {
+		if c.Action != ReconcileDelete {
+			continue
+		}
+		if err := uc.DeleteURL(ctx, plan.ProjectID, c.Current.ID); err != nil {
+			return fmt.Errorf("failed to prune %s: %w", c.Address, err)
+		}
+	}
+
+	return nil
+}
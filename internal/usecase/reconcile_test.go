@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"url-sentinel/internal/domain/entity"
+	"url-sentinel/internal/repository/memory"
+)
+
+func newTestURLUseCase() *URLUseCase {
+	store := memory.New()
+	return NewURLUseCase(memory.NewURLRepository(store), memory.NewProjectRepository(store), nil, nil, nil)
+}
+
+// planActions returns the planned action of every address
+func planActions(plan *ReconcilePlan) map[string]ReconcileAction {
+	actions := make(map[string]ReconcileAction, len(plan.Changes))
+	for _, c := range plan.Changes {
+		actions[c.Address] = c.Action
+	}
+	return actions
+}
+
+func TestReconcile(t *testing.T) {
+	ctx := context.Background()
+	uc := newTestURLUseCase()
+
+	declare := func(address string, interval time.Duration) entity.URLDefinition {
+		return entity.URLDefinition{Address: address, CheckInterval: interval, Labels: entity.Labels{"team": "payments"}}
+	}
+	kept := declare("https://kept.example.com", time.Minute)
+	changed := declare("https://changed.example.com", time.Minute)
+	removed := declare("https://removed.example.com", time.Minute)
+
+	plan, err := uc.PlanReconcile(ctx, entity.DefaultProjectID, []entity.URLDefinition{kept, changed, removed}, true)
+	if err != nil {
+		t.Fatalf("PlanReconcile: %v", err)
+	}
+	if got := plan.Count(ReconcileCreate); got != 3 || len(plan.Changes) != 3 {
+		t.Fatalf("initial plan = %v, want 3 creations", planActions(plan))
+	}
+	if err := uc.ApplyReconcile(ctx, plan); err != nil {
+		t.Fatalf("ApplyReconcile: %v", err)
+	}
+
+	// An address created through the API is not managed by the file
+	manual, err := uc.CreateURL(ctx, entity.DefaultProjectID, declare("https://manual.example.com", time.Hour))
+	if err != nil {
+		t.Fatalf("CreateURL: %v", err)
+	}
+
+	changed.CheckInterval = 5 * time.Minute
+	added := declare("https://added.example.com", time.Minute)
+	defs := []entity.URLDefinition{kept, changed, declare(manual.Address, time.Minute), added}
+
+	plan, err = uc.PlanReconcile(ctx, entity.DefaultProjectID, defs, true)
+	if err != nil {
+		t.Fatalf("PlanReconcile: %v", err)
+	}
+	want := map[string]ReconcileAction{
+		added.Address:   ReconcileCreate,
+		changed.Address: ReconcileUpdate,
+		manual.Address:  ReconcileConflict,
+		removed.Address: ReconcileDelete,
+	}
+	got := planActions(plan)
+	if len(got) != len(want) {
+		t.Errorf("plan = %v, want %v", got, want)
+	}
+	for address, action := range want {
+		if got[address] != action {
+			t.Errorf("plan for %s = %q, want %q", address, got[address], action)
+		}
+	}
+
+	// Without pruning, URLs removed from the file are kept
+	unpruned, err := uc.PlanReconcile(ctx, entity.DefaultProjectID, defs, false)
+	if err != nil {
+		t.Fatalf("PlanReconcile: %v", err)
+	}
+	if got := unpruned.Count(ReconcileDelete); got != 0 {
+		t.Errorf("plan without prune deletes %d urls, want 0", got)
+	}
+
+	if err := uc.ApplyReconcile(ctx, plan); err != nil {
+		t.Fatalf("ApplyReconcile: %v", err)
+	}
+
+	exported, err := uc.ExportURLs(ctx, entity.DefaultProjectID)
+	if err != nil {
+		t.Fatalf("ExportURLs: %v", err)
+	}
+	intervals := make(map[string]time.Duration, len(exported))
+	for _, def := range exported {
+		intervals[def.Address] = def.CheckInterval
+	}
+	if _, ok := intervals[removed.Address]; ok {
+		t.Errorf("pruned url %s still exists", removed.Address)
+	}
+	if intervals[changed.Address] != 5*time.Minute {
+		t.Errorf("interval of %s = %s, want 5m", changed.Address, intervals[changed.Address])
+	}
+	if intervals[manual.Address] != time.Hour {
+		t.Errorf("conflicting url %s was changed to %s", manual.Address, intervals[manual.Address])
+	}
+
+	stored, err := uc.GetURLByID(ctx, entity.DefaultProjectID, manual.ID)
+	if err != nil {
+		t.Fatalf("GetURLByID: %v", err)
+	}
+	if _, ok := stored.Labels[entity.LabelManagedBy]; ok {
+		t.Errorf("conflicting url %s was marked as managed", manual.Address)
+	}
+
+	// Once applied, the file and the project agree
+	plan, err = uc.PlanReconcile(ctx, entity.DefaultProjectID, defs, true)
+	if err != nil {
+		t.Fatalf("PlanReconcile: %v", err)
+	}
+	if len(plan.Changes) != 1 || plan.Changes[0].Action != ReconcileConflict {
+		t.Errorf("plan after apply = %v, want only the conflict", planActions(plan))
+	}
+}
@@ -0,0 +1,37 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"url-sentinel/internal/domain/entity"
+	"url-sentinel/internal/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+// resolveState derives the state of a URL from its open incident and latest
+// check; paused URLs are paused whatever their last result
+func resolveState(
+	ctx context.Context,
+	checkRepo repository.CheckRepository,
+	url *entity.URL,
+	open *entity.Incident,
+) (entity.State, error) {
+	if url.Paused {
+		return entity.StatePaused, nil
+	}
+	if open != nil {
+		return entity.StateDown, nil
+	}
+
+	latest, err := checkRepo.GetLatestByURLID(ctx, uuid.Nil, url.ID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get latest check: %w", err)
+	}
+	if latest == nil {
+		return entity.StateUnknown, nil
+	}
+
+	return entity.StateUp, nil
+}
@@ -0,0 +1,245 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"url-sentinel/internal/domain/entity"
+	"url-sentinel/internal/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+// uptimeDays is the number of days covered by status page uptime bars
+const uptimeDays = 90
+
+// StatusPageReport is the rendered state of a status page
+type StatusPageReport struct {
+	Page        *entity.StatusPage
+	Components  []ComponentReport
+	Incidents   []*entity.Incident // currently open incidents of displayed URLs
+	GeneratedAt time.Time
+}
+
+// ComponentReport is the state of a single status page component
+type ComponentReport struct {
+	Name     string
+	Monitors []MonitorReport
+}
+
+// MonitorReport is the state and uptime history of a single URL
+type MonitorReport struct {
+	URL    *entity.URL
+	State  entity.State
+	Uptime float64               // share of successful checks over the whole period, -1 without data
+	Days   []*entity.DailyUptime // one entry per day, oldest first
+}
+
+// StatusPageUseCase handles business logic for status page operations
+type StatusPageUseCase struct {
+	statusPageRepo repository.StatusPageRepository
+	urlRepo        repository.URLRepository
+	checkRepo      repository.CheckRepository
+	incidentRepo   repository.IncidentRepository
+}
+
+// NewStatusPageUseCase creates a new status page use case
+func NewStatusPageUseCase(
+	statusPageRepo repository.StatusPageRepository,
+	urlRepo repository.URLRepository,
+	checkRepo repository.CheckRepository,
+	incidentRepo repository.IncidentRepository,
+) *StatusPageUseCase {
+	return &StatusPageUseCase{
+		statusPageRepo: statusPageRepo,
+		urlRepo:        urlRepo,
+		checkRepo:      checkRepo,
+		incidentRepo:   incidentRepo,
+	}
+}
+
+// CreateStatusPage creates a new status page of the project with validation
+func (uc *StatusPageUseCase) CreateStatusPage(
+	ctx context.Context,
+	projectID uuid.UUID,
+	slug, title, logoText string,
+	components []entity.StatusComponent,
+) (*entity.StatusPage, error) {
+	page, err := entity.NewStatusPage(projectID, slug, title, logoText, components)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create status page entity: %w", err)
+	}
+
+	if err := uc.checkComponents(ctx, projectID, components); err != nil {
+		return nil, err
+	}
+
+	if err := uc.statusPageRepo.Create(ctx, page); err != nil {
+		return nil, fmt.Errorf("failed to save status page: %w", err)
+	}
+
+	return page, nil
+}
+
+// GetStatusPage retrieves a status page of the project by its ID
+func (uc *StatusPageUseCase) GetStatusPage(ctx context.Context, projectID, id uuid.UUID) (*entity.StatusPage, error) {
+	page, err := uc.statusPageRepo.GetByID(ctx, projectID, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get status page: %w", err)
+	}
+
+	return page, nil
+}
+
+// ListStatusPages retrieves all status pages of the project
+func (uc *StatusPageUseCase) ListStatusPages(ctx context.Context, projectID uuid.UUID) ([]*entity.StatusPage, error) {
+	pages, err := uc.statusPageRepo.List(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list status pages: %w", err)
+	}
+
+	return pages, nil
+}
+
+// UpdateStatusPage replaces the settings of an existing status page
+func (uc *StatusPageUseCase) UpdateStatusPage(
+	ctx context.Context,
+	projectID, id uuid.UUID,
+	slug, title, logoText string,
+	components []entity.StatusComponent,
+) (*entity.StatusPage, error) {
+	page, err := uc.statusPageRepo.GetByID(ctx, projectID, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get status page: %w", err)
+	}
+
+	page.Slug = slug
+	page.Title = title
+	page.LogoText = logoText
+	page.Components = components
+	if err := page.Validate(); err != nil {
+		return nil, fmt.Errorf("failed to validate status page: %w", err)
+	}
+	if err := uc.checkComponents(ctx, projectID, components); err != nil {
+		return nil, err
+	}
+
+	if err := uc.statusPageRepo.Update(ctx, page); err != nil {
+		return nil, fmt.Errorf("failed to update status page: %w", err)
+	}
+
+	return page, nil
+}
+
+// DeleteStatusPage deletes a status page of the project by its ID
+func (uc *StatusPageUseCase) DeleteStatusPage(ctx context.Context, projectID, id uuid.UUID) error {
+	if err := uc.statusPageRepo.Delete(ctx, projectID, id); err != nil {
+		return fmt.Errorf("failed to delete status page: %w", err)
+	}
+
+	return nil
+}
+
+// checkComponents ensures a status page only displays URLs of its own project
+func (uc *StatusPageUseCase) checkComponents(ctx context.Context, projectID uuid.UUID, components []entity.StatusComponent) error {
+	for _, component := range components {
+		for _, urlID := range component.URLIDs {
+			if _, err := uc.urlRepo.GetByID(ctx, projectID, urlID); err != nil {
+				return fmt.Errorf("failed to get url %s: %w", urlID, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// BuildReport collects the current state, uptime history and open incidents
+// of every URL displayed on the status page
+func (uc *StatusPageUseCase) BuildReport(ctx context.Context, slug string) (*StatusPageReport, error) {
+	page, err := uc.statusPageRepo.GetBySlug(ctx, slug)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get status page: %w", err)
+	}
+
+	now := time.Now().UTC()
+	today := now.Truncate(24 * time.Hour)
+	since := today.AddDate(0, 0, -(uptimeDays - 1))
+
+	report := &StatusPageReport{
+		Page:        page,
+		Components:  make([]ComponentReport, 0, len(page.Components)),
+		GeneratedAt: now,
+	}
+
+	for _, component := range page.Components {
+		componentReport := ComponentReport{Name: component.Name}
+
+		for _, urlID := range component.URLIDs {
+			url, err := uc.urlRepo.GetByID(ctx, page.ProjectID, urlID)
+			if err != nil {
+				if errors.Is(err, repository.ErrURLNotFound) {
+					continue // URL was deleted after the page was configured
+				}
+				return nil, fmt.Errorf("failed to get url: %w", err)
+			}
+
+			incident, err := uc.incidentRepo.GetOpenByURLID(ctx, urlID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get open incident: %w", err)
+			}
+			if incident != nil {
+				report.Incidents = append(report.Incidents, incident)
+			}
+
+			state, err := resolveState(ctx, uc.checkRepo, url, incident)
+			if err != nil {
+				return nil, err
+			}
+
+			days, err := uc.checkRepo.DailyUptime(ctx, page.ProjectID, urlID, since)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get daily uptime: %w", err)
+			}
+
+			componentReport.Monitors = append(componentReport.Monitors, newMonitorReport(url, state, days, since))
+		}
+
+		report.Components = append(report.Components, componentReport)
+	}
+
+	return report, nil
+}
+
+// newMonitorReport fills gaps in the daily uptime so there is one entry per day
+func newMonitorReport(url *entity.URL, state entity.State, days []*entity.DailyUptime, since time.Time) MonitorReport {
+	byDay := make(map[time.Time]*entity.DailyUptime, len(days))
+	for _, day := range days {
+		byDay[day.Day] = day
+	}
+
+	report := MonitorReport{
+		URL:   url,
+		State: state,
+		Days:  make([]*entity.DailyUptime, 0, uptimeDays),
+	}
+
+	total, successful := 0, 0
+	for i := 0; i < uptimeDays; i++ {
+		day := since.AddDate(0, 0, i)
+		entry, ok := byDay[day]
+		if !ok {
+			entry = &entity.DailyUptime{Day: day}
+		}
+
+		total += entry.Total
+		successful += entry.Successful
+		report.Days = append(report.Days, entry)
+	}
+
+	summary := entity.DailyUptime{Total: total, Successful: successful}
+	report.Uptime = summary.Ratio()
+
+	return report
+}
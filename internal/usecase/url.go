@@ -2,39 +2,74 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"url-sentinel/internal/domain/entity"
 	"url-sentinel/internal/domain/repository"
+	"url-sentinel/internal/events"
 
 	"github.com/google/uuid"
 )
 
+// manualCheckTimeout bounds how long an on-demand check may take
+const manualCheckTimeout = 30 * time.Second
+
+var (
+	ErrMonitorUnavailable = errors.New("monitor is not available")
+)
+
 // Monitor defines the interface for URL monitoring
 type Monitor interface {
-	AddURL(ctx context.Context, url *entity.URL)
+	AddURL(url *entity.URL)
 	RemoveURL(urlID string)
+	CheckNow(ctx context.Context, url *entity.URL, record func(context.Context, events.CheckCompleted) error) (*entity.Check, error)
+	TestCheck(ctx context.Context, url *entity.URL, request entity.CheckRequest, assertions []entity.Assertion) (*entity.CheckReport, error)
+}
+
+// CheckRecorder stores a completed check before it is published
+type CheckRecorder interface {
+	StoreCheck(ctx context.Context, e events.CheckCompleted) error
 }
 
 // URLUseCase handles business logic for URL operations
 type URLUseCase struct {
-	urlRepo repository.URLRepository
-	monitor Monitor
+	urlRepo     repository.URLRepository
+	projectRepo repository.ProjectRepository
+	monitor     Monitor
+	checks      CheckRecorder
+	events      events.Publisher
 }
 
-// NewURLUseCase creates a new URL use case
-func NewURLUseCase(urlRepo repository.URLRepository, monitor Monitor) *URLUseCase {
+// NewURLUseCase creates a new URL use case, monitor, checks and publisher may be nil
+func NewURLUseCase(
+	urlRepo repository.URLRepository,
+	projectRepo repository.ProjectRepository,
+	monitor Monitor,
+	checks CheckRecorder,
+	publisher events.Publisher,
+) *URLUseCase {
 	return &URLUseCase{
-		urlRepo: urlRepo,
-		monitor: monitor,
+		urlRepo:     urlRepo,
+		projectRepo: projectRepo,
+		monitor:     monitor,
+		checks:      checks,
+		events:      publisher,
 	}
 }
 
-// CreateURL creates a new URL with validation and starts monitoring
-func (uc *URLUseCase) CreateURL(ctx context.Context, address string, interval time.Duration) (*entity.URL, error) {
-	// Check if URL already exists
-	exists, err := uc.urlRepo.ExistsByAddress(ctx, address)
+// CreateURL creates a new URL in the project from its definition with validation
+// and starts monitoring. A zero quorum falls back to entity.DefaultQuorum, a nil
+// content watch disables content change detection and the zero redirect policy
+// follows redirects.
+func (uc *URLUseCase) CreateURL(ctx context.Context, projectID uuid.UUID, def entity.URLDefinition) (*entity.URL, error) {
+	if err := checkReservedLabels(def.Labels); err != nil {
+		return nil, err
+	}
+
+	// Check if URL already exists in the project
+	exists, err := uc.urlRepo.ExistsByAddress(ctx, projectID, def.Address)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check url existence: %w", err)
 	}
@@ -42,8 +77,21 @@ func (uc *URLUseCase) CreateURL(ctx context.Context, address string, interval ti
 		return nil, repository.ErrURLAddressExists
 	}
 
+	// Enforce project quotas
+	project, err := uc.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+	count, err := uc.urlRepo.Count(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count urls: %w", err)
+	}
+	if err := project.CheckQuota(count, def.CheckInterval); err != nil {
+		return nil, err
+	}
+
 	// Create new URL entity
-	url, err := entity.NewURL(address, interval)
+	url, err := entity.NewURLFromDefinition(projectID, def)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create url entity: %w", err)
 	}
@@ -55,15 +103,18 @@ func (uc *URLUseCase) CreateURL(ctx context.Context, address string, interval ti
 
 	// Add to monitoring if monitor is available
 	if uc.monitor != nil {
-		uc.monitor.AddURL(ctx, url)
+		uc.monitor.AddURL(url)
+	}
+	if uc.events != nil {
+		uc.events.Publish(ctx, events.URLCreated{URL: url})
 	}
 
 	return url, nil
 }
 
-// GetURLByID retrieves a URL by its ID
-func (uc *URLUseCase) GetURLByID(ctx context.Context, id uuid.UUID) (*entity.URL, error) {
-	url, err := uc.urlRepo.GetByID(ctx, id)
+// GetURLByID retrieves a URL of the project by its ID
+func (uc *URLUseCase) GetURLByID(ctx context.Context, projectID, id uuid.UUID) (*entity.URL, error) {
+	url, err := uc.urlRepo.GetByID(ctx, projectID, id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get url: %w", err)
 	}
@@ -71,27 +122,186 @@ func (uc *URLUseCase) GetURLByID(ctx context.Context, id uuid.UUID) (*entity.URL
 	return url, nil
 }
 
-// ListURLs retrieves all URLs
-func (uc *URLUseCase) ListURLs(ctx context.Context) ([]*entity.URL, error) {
-	urls, err := uc.urlRepo.List(ctx)
+// ListURLs retrieves a page of the project's URLs matching the filter, uuid.Nil lists every project
+func (uc *URLUseCase) ListURLs(ctx context.Context, projectID uuid.UUID, filter repository.URLFilter) (*repository.URLPage, error) {
+	page, err := uc.urlRepo.List(ctx, projectID, filter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list urls: %w", err)
 	}
 
-	return urls, nil
+	return page, nil
+}
+
+// URLPatch holds the changes of a partial URL update, nil fields are left unchanged
+type URLPatch struct {
+	CheckInterval *time.Duration
+	Quorum        *int
+	Labels        map[string]*string // a nil value removes the label, other labels are kept
+	SetWatch      bool               // replace the content watch with ContentWatch
+	ContentWatch  *entity.ContentWatch
+	Redirects     *entity.RedirectPolicy
+
+	// Redirect expectations, an empty value removes the expectation
+	ExpectFinalURL       *string
+	ExpectRedirectDomain *string
+
+	Paused *bool // a paused URL is not checked until resumed
 }
 
-// DeleteURL deletes a URL by its ID and stops monitoring
-func (uc *URLUseCase) DeleteURL(ctx context.Context, id uuid.UUID) error {
-	// Remove from monitoring first
+// UpdateURL applies a partial update to a URL of the project and restarts its
+// monitoring with the new settings
+func (uc *URLUseCase) UpdateURL(ctx context.Context, projectID, id uuid.UUID, patch URLPatch) (*entity.URL, error) {
+	if err := checkReservedLabels(patch.Labels); err != nil {
+		return nil, err
+	}
+
+	url, err := uc.urlRepo.GetByID(ctx, projectID, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get url: %w", err)
+	}
+
+	if patch.CheckInterval != nil {
+		project, err := uc.projectRepo.GetByID(ctx, url.ProjectID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get project: %w", err)
+		}
+		if err := project.CheckInterval(*patch.CheckInterval); err != nil {
+			return nil, err
+		}
+		url.CheckInterval = *patch.CheckInterval
+	}
+	if patch.Quorum != nil {
+		url.Quorum = *patch.Quorum
+	}
+	if url.Labels == nil {
+		url.Labels = entity.Labels{}
+	}
+	for key, value := range patch.Labels {
+		if value == nil {
+			delete(url.Labels, key)
+		} else {
+			url.Labels[key] = *value
+		}
+	}
+	if patch.SetWatch {
+		url.ContentWatch = patch.ContentWatch
+	}
+	if patch.Redirects != nil {
+		url.Redirects = *patch.Redirects
+	}
+	if patch.ExpectFinalURL != nil {
+		url.ExpectFinalURL = *patch.ExpectFinalURL
+	}
+	if patch.ExpectRedirectDomain != nil {
+		url.ExpectRedirectDomain = *patch.ExpectRedirectDomain
+	}
+	if patch.Paused != nil {
+		url.Paused = *patch.Paused
+	}
+	if err := url.Validate(); err != nil {
+		return nil, fmt.Errorf("failed to update url entity: %w", err)
+	}
+
+	if err := uc.urlRepo.Update(ctx, url); err != nil {
+		return nil, fmt.Errorf("failed to update url: %w", err)
+	}
+
 	if uc.monitor != nil {
-		uc.monitor.RemoveURL(id.String())
+		uc.monitor.RemoveURL(url.ID.String())
+		uc.monitor.AddURL(url)
+	}
+
+	return url, nil
+}
+
+// checkReservedLabels rejects labels set or removed by users that only
+// PlanReconcile may set
+func checkReservedLabels[M ~map[string]V, V any](labels M) error {
+	if _, ok := labels[entity.LabelManagedBy]; ok {
+		return entity.ErrReservedLabel
+	}
+	return nil
+}
+
+// CheckURLNow checks a URL of the project immediately and returns the stored result
+func (uc *URLUseCase) CheckURLNow(ctx context.Context, projectID, id uuid.UUID) (*entity.Check, error) {
+	if uc.monitor == nil {
+		return nil, ErrMonitorUnavailable
+	}
+
+	url, err := uc.urlRepo.GetByID(ctx, projectID, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get url: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, manualCheckTimeout)
+	defer cancel()
+
+	// The check is stored before it is returned, so it shows up in the history right away
+	var record func(context.Context, events.CheckCompleted) error
+	if uc.checks != nil {
+		record = uc.checks.StoreCheck
 	}
 
-	// Delete from repository
-	if err := uc.urlRepo.Delete(ctx, id); err != nil {
+	check, err := uc.monitor.CheckNow(ctx, url, record)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check url: %w", err)
+	}
+
+	return check, nil
+}
+
+// TestURL validates a URL definition like CreateURL would and checks it once with
+// the given request and assertions. Nothing is stored and project quotas do not apply.
+func (uc *URLUseCase) TestURL(
+	ctx context.Context,
+	projectID uuid.UUID,
+	def entity.URLDefinition,
+	request entity.CheckRequest,
+	assertions []entity.Assertion,
+) (*entity.CheckReport, error) {
+	if uc.monitor == nil {
+		return nil, ErrMonitorUnavailable
+	}
+
+	url, err := entity.NewURLFromDefinition(projectID, def)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create url entity: %w", err)
+	}
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+	for _, a := range assertions {
+		if err := a.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, manualCheckTimeout)
+	defer cancel()
+
+	report, err := uc.monitor.TestCheck(ctx, url, request, assertions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to test url: %w", err)
+	}
+
+	return report, nil
+}
+
+// DeleteURL deletes a URL of the project by its ID and stops monitoring
+func (uc *URLUseCase) DeleteURL(ctx context.Context, projectID, id uuid.UUID) error {
+	// Delete from repository first so URLs of other projects keep being monitored
+	if err := uc.urlRepo.Delete(ctx, projectID, id); err != nil {
 		return fmt.Errorf("failed to delete url: %w", err)
 	}
 
+	// Remove from monitoring
+	if uc.monitor != nil {
+		uc.monitor.RemoveURL(id.String())
+	}
+	if uc.events != nil {
+		uc.events.Publish(ctx, events.URLDeleted{ProjectID: projectID, URLID: id})
+	}
+
 	return nil
 }
@@ -0,0 +1,204 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+
+	"url-sentinel/internal/domain/entity"
+	"url-sentinel/internal/domain/repository"
+	"url-sentinel/internal/events"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrUnknownImportMode = errors.New("unknown import mode, expected skip or update")
+	ErrDuplicateAddress  = errors.New("address appears more than once in the import")
+)
+
+// ImportMode tells what an import does with addresses that already exist in the project
+type ImportMode string
+
+const (
+	ImportSkip   ImportMode = "skip"
+	ImportUpdate ImportMode = "update"
+)
+
+// ImportAction is what an import does, or would do in a dry run, with a row
+type ImportAction string
+
+const (
+	ImportCreated ImportAction = "created"
+	ImportUpdated ImportAction = "updated"
+	ImportSkipped ImportAction = "skipped"
+	ImportFailed  ImportAction = "failed"
+)
+
+// ImportRow is a URL definition to import. Err carries a decoding error of the row.
+type ImportRow struct {
+	Row        int
+	Definition entity.URLDefinition
+	Err        error
+}
+
+// ImportRowResult is the outcome of a single row
+type ImportRowResult struct {
+	Row     int
+	Address string
+	Action  ImportAction
+	Err     error
+	URL     *entity.URL // nil for skipped and failed rows
+}
+
+// ImportReport is the outcome of an import, nothing is written when a row failed
+type ImportReport struct {
+	DryRun bool
+	Rows   []*ImportRowResult
+}
+
+// Count returns the number of rows with the given action
+func (r *ImportReport) Count(action ImportAction) int {
+	n := 0
+	for _, row := range r.Rows {
+		if row.Action == action {
+			n++
+		}
+	}
+	return n
+}
+
+// ImportURLs creates the URLs of the rows in the project within a single transaction.
+// Existing addresses are skipped or updated depending on the mode. Every row is
+// validated first and nothing is written if any row fails or in a dry run.
+func (uc *URLUseCase) ImportURLs(
+	ctx context.Context,
+	projectID uuid.UUID,
+	rows []ImportRow,
+	mode ImportMode,
+	dryRun bool,
+) (*ImportReport, error) {
+	if mode != ImportSkip && mode != ImportUpdate {
+		return nil, ErrUnknownImportMode
+	}
+
+	project, err := uc.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+	count, err := uc.urlRepo.Count(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count urls: %w", err)
+	}
+
+	report := &ImportReport{DryRun: dryRun}
+	var create, update []*entity.URL
+	seen := make(map[string]struct{}, len(rows))
+
+	for _, row := range rows {
+		result := &ImportRowResult{Row: row.Row, Address: row.Definition.Address}
+		report.Rows = append(report.Rows, result)
+
+		if row.Err != nil {
+			result.Action, result.Err = ImportFailed, row.Err
+			continue
+		}
+		if _, dup := seen[row.Definition.Address]; dup {
+			result.Action, result.Err = ImportFailed, ErrDuplicateAddress
+			continue
+		}
+		seen[row.Definition.Address] = struct{}{}
+
+		if err := checkReservedLabels(row.Definition.Labels); err != nil {
+			result.Action, result.Err = ImportFailed, err
+			continue
+		}
+
+		url, err := entity.NewURLFromDefinition(projectID, row.Definition)
+		if err != nil {
+			result.Action, result.Err = ImportFailed, err
+			continue
+		}
+
+		exists, err := uc.urlRepo.ExistsByAddress(ctx, projectID, url.Address)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check url existence: %w", err)
+		}
+
+		switch {
+		case exists && mode == ImportSkip:
+			result.Action = ImportSkipped
+		case exists:
+			if err := project.CheckInterval(url.CheckInterval); err != nil {
+				result.Action, result.Err = ImportFailed, err
+				continue
+			}
+			result.Action, result.URL = ImportUpdated, url
+			update = append(update, url)
+		default:
+			if err := project.CheckQuota(count+len(create), url.CheckInterval); err != nil {
+				result.Action, result.Err = ImportFailed, err
+				continue
+			}
+			result.Action, result.URL = ImportCreated, url
+			create = append(create, url)
+		}
+	}
+
+	if dryRun || report.Count(ImportFailed) > 0 {
+		return report, nil
+	}
+
+	if err := uc.importURLs(ctx, projectID, create, update); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// importURLs stores new and changed URLs in a single transaction, then starts
+// monitoring new URLs and restarts updated ones with their new settings
+func (uc *URLUseCase) importURLs(ctx context.Context, projectID uuid.UUID, create, update []*entity.URL) error {
+	if err := uc.urlRepo.Import(ctx, projectID, create, update); err != nil {
+		return fmt.Errorf("failed to import urls: %w", err)
+	}
+
+	if uc.monitor != nil {
+		for _, url := range update {
+			uc.monitor.RemoveURL(url.ID.String())
+			uc.monitor.AddURL(url)
+		}
+		for _, url := range create {
+			uc.monitor.AddURL(url)
+		}
+	}
+	if uc.events != nil {
+		for _, url := range create {
+			uc.events.Publish(ctx, events.URLCreated{URL: url})
+		}
+	}
+
+	return nil
+}
+
+// ExportURLs returns the definitions of all URLs of the project. The reserved
+// LabelManagedBy is left out, so that the export can be imported again.
+func (uc *URLUseCase) ExportURLs(ctx context.Context, projectID uuid.UUID) ([]entity.URLDefinition, error) {
+	page, err := uc.urlRepo.List(ctx, projectID, repository.URLFilter{Sort: repository.URLSortAddress})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list urls: %w", err)
+	}
+
+	defs := make([]entity.URLDefinition, 0, len(page.URLs))
+	for _, url := range page.URLs {
+		def := url.Definition()
+		if _, ok := def.Labels[entity.LabelManagedBy]; ok {
+			def.Labels = maps.Clone(def.Labels)
+			delete(def.Labels, entity.LabelManagedBy)
+		}
+		defs = append(defs, def)
+	}
+
+	return defs, nil
+}